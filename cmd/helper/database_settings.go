@@ -0,0 +1,93 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+// DatabaseSettingsUsage describes the settings accepted by ParseDatabaseSettings
+const DatabaseSettingsUsage = "treecachesize=<entries> syncwrites=<true|false> compression=<none|snappy|zstd> " +
+	"corruptioncheckinterval=<duration> readonly=<true|false> " +
+	"maxentries=<entries> maxbytes=<bytes> maxwritespersecond=<writes>"
+
+// ParseDatabaseSettings applies the setting=value arguments to settings
+func ParseDatabaseSettings(settings *schema.DatabaseSettings, args []string) error {
+	for _, arg := range args {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("invalid setting %s, expected setting=value", arg)
+		}
+		var err error
+		switch strings.ToLower(kv[0]) {
+		case "treecachesize":
+			settings.TreeCacheSize, err = strconv.ParseUint(kv[1], 10, 64)
+		case "syncwrites":
+			settings.SyncWrites, err = strconv.ParseBool(kv[1])
+		case "compression":
+			settings.Compression = strings.ToLower(kv[1])
+		case "corruptioncheckinterval":
+			var interval time.Duration
+			if interval, err = time.ParseDuration(kv[1]); err == nil {
+				settings.CorruptionCheckInterval = uint32(interval / time.Second)
+			}
+		case "readonly":
+			settings.ReadOnly, err = strconv.ParseBool(kv[1])
+		case "maxentries":
+			settings.MaxEntries, err = strconv.ParseUint(kv[1], 10, 64)
+		case "maxbytes":
+			settings.MaxBytes, err = strconv.ParseUint(kv[1], 10, 64)
+		case "maxwritespersecond":
+			var writes uint64
+			if writes, err = strconv.ParseUint(kv[1], 10, 32); err == nil {
+				settings.MaxWritesPerSecond = uint32(writes)
+			}
+		default:
+			return fmt.Errorf("unknown setting %s, supported settings are %s", kv[0], DatabaseSettingsUsage)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid value for setting %s: %v", kv[0], err)
+		}
+	}
+	return nil
+}
+
+// DatabaseSettingsString returns the settings in the setting=value form accepted by ParseDatabaseSettings
+func DatabaseSettingsString(settings *schema.DatabaseSettings) string {
+	if settings == nil {
+		settings = &schema.DatabaseSettings{}
+	}
+	compression := settings.Compression
+	if compression == "" {
+		compression = "none"
+	}
+	return fmt.Sprintf("treecachesize=%d syncwrites=%t compression=%s corruptioncheckinterval=%s readonly=%t "+
+		"maxentries=%d maxbytes=%d maxwritespersecond=%d",
+		settings.TreeCacheSize,
+		settings.SyncWrites,
+		compression,
+		time.Duration(settings.CorruptionCheckInterval)*time.Second,
+		settings.ReadOnly,
+		settings.MaxEntries,
+		settings.MaxBytes,
+		settings.MaxWritesPerSecond)
+}
@@ -31,6 +31,7 @@ import (
 	c "github.com/codenotary/immudb/cmd/helper"
 	"github.com/codenotary/immudb/pkg/auth"
 	"github.com/codenotary/immudb/pkg/fs"
+	"github.com/codenotary/immudb/pkg/logger"
 	"github.com/codenotary/immudb/pkg/server"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -53,6 +54,8 @@ type Backupper interface {
 	stopImmudbService() (func(), error)
 	offlineBackup(src string, uncompressed bool, manualStopStart bool) (string, error)
 	offlineRestore(src string, dst string, manualStopStart bool) (string, error)
+	catalogRestore(backupDir string, id string, dst string, manualStopStart bool) (*server.BackupCatalogEntry, error)
+	archiveRestore(src string, dst string, manualStopStart bool) (*server.BackupManifest, error)
 }
 
 type commandlineBck struct {
@@ -116,11 +119,33 @@ func (cl *commandlineBck) dumpToFile(cmd *cobra.Command) {
 func (cl *commandlineBck) backup(cmd *cobra.Command) {
 	defaultDbDir := server.DefaultOptions().Dir
 	ccmd := &cobra.Command{
-		Use:   "backup [--dbdir] [--manual-stop-start] [--uncompressed]",
+		Use:   "backup [--dbdir] [--manual-stop-start] [--uncompressed] [--online [--db] [--output]]",
 		Short: "Make a copy of the database files and folders",
 		Long: "Pause the immudb server, create and save on the server machine a snapshot " +
-			"of the database files and folders (zip on Windows, tar.gz on Linux or uncompressed).",
+			"of the database files and folders (zip on Windows, tar.gz on Linux or uncompressed).\n" +
+			"With --online the server is not stopped: it takes a consistent snapshot of the databases " +
+			"at their current roots and streams it to a local tar.gz archive.",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			online, err := cmd.Flags().GetBool("online")
+			if err != nil {
+				c.QuitToStdErr(err)
+			}
+			if online {
+				databases, err := cmd.Flags().GetStringSlice("db")
+				if err != nil {
+					c.QuitToStdErr(err)
+				}
+				output, err := cmd.Flags().GetString("output")
+				if err != nil {
+					c.QuitToStdErr(err)
+				}
+				backupPath, err := cl.onlineBackup(cmd, databases, output)
+				if err != nil {
+					c.QuitWithUserError(err)
+				}
+				fmt.Printf("Database backup created: %s\n", backupPath)
+				return nil
+			}
 			dbDir, err := cmd.Flags().GetString("dbdir")
 			if err != nil {
 				c.QuitToStdErr(err)
@@ -149,18 +174,87 @@ func (cl *commandlineBck) backup(cmd *cobra.Command) {
 	ccmd.Flags().String("dbdir", defaultDbDir, fmt.Sprintf("path to the server database directory to backup (default %s)", defaultDbDir))
 	ccmd.Flags().Bool("manual-stop-start", false, "server stop before and restart after the backup are to be handled manually by the user (default false)")
 	ccmd.Flags().BoolP("uncompressed", "u", false, "create an uncompressed backup (i.e. make just a copy of the db directory)")
+	ccmd.Flags().Bool("online", false, "take a snapshot through the running server, without stopping it, and download it")
+	ccmd.Flags().StringSlice("db", nil, "databases to include in the online backup (default all)")
+	ccmd.Flags().StringP("output", "o", "", "file where the online backup is saved (default immudb_online_bkp_<timestamp>.tar.gz)")
+	cl.backupList(ccmd)
+	cmd.AddCommand(ccmd)
+}
+
+func (cl *commandlineBck) backupList(cmd *cobra.Command) {
+	defaultBackupDir := server.DefaultOptions().GetBackupDir()
+	ccmd := &cobra.Command{
+		Use:   "list [--backup-dir]",
+		Short: "List the backups taken by the server on schedule",
+		Long:  "List the backups recorded in the catalog of the server backup directory, with their databases, roots and checksums.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			backupDir, err := cmd.Flags().GetString("backup-dir")
+			if err != nil {
+				c.QuitToStdErr(err)
+			}
+			catalog, err := server.LoadBackupCatalog(backupDir)
+			if err != nil {
+				c.QuitToStdErr(err)
+			}
+			if len(catalog.Backups) == 0 {
+				fmt.Printf("No backups found in %s\n", backupDir)
+				return nil
+			}
+			for _, b := range catalog.Backups {
+				fmt.Printf("%s\t%s\t%d bytes\t%s\n", b.ID, b.Created.Local().Format(time.RFC3339), b.Size, b.File)
+				fmt.Printf("  checksum: %s\n", b.Checksum)
+				for _, bd := range b.Databases {
+					fmt.Printf("  %s\troot %d:%x\n", bd.Name, bd.RootIndex, bd.Root)
+				}
+			}
+			return nil
+		},
+		Args: cobra.NoArgs,
+	}
+	ccmd.Flags().String("backup-dir", defaultBackupDir, "path to the server backup directory")
 	cmd.AddCommand(ccmd)
 }
 
+// onlineBackup downloads from the running server a consistent snapshot of the databases
+func (cl *commandlineBck) onlineBackup(cmd *cobra.Command, databases []string, output string) (string, error) {
+	if output == "" {
+		output = "immudb_online_bkp_" + time.Now().Format("2006-01-02_15-04-05") + ".tar.gz"
+	}
+	if err := cl.checkLoggedInAndConnect(cmd, nil); err != nil {
+		return "", err
+	}
+	defer cl.disconnect(cmd, nil)
+	f, err := os.Create(output)
+	if err != nil {
+		return "", err
+	}
+	if _, err = cl.immuClient.Backup(cl.context, databases, f); err != nil {
+		f.Close()
+		os.Remove(output)
+		return "", err
+	}
+	if err = f.Close(); err != nil {
+		return "", err
+	}
+	absOutput, err := filepath.Abs(output)
+	if err != nil {
+		return output, nil
+	}
+	return absOutput, nil
+}
+
 func (cl *commandlineBck) restore(cmd *cobra.Command) {
 	defaultDbDir := server.DefaultOptions().Dir
 	ccmd := &cobra.Command{
-		Use:   "restore snapshot-path [--dbdir] [--manual-stop-start]",
+		Use:   "restore [snapshot-path | --from-catalog id [--backup-dir]] [--dbdir] [--manual-stop-start]",
 		Short: "Restore the database from a snapshot archive or folder",
 		Long: "Pause the immudb server and restore the database files and folders from a snapshot " +
-			"file (zip or tar.gz) or folder (uncompressed) residing on the server machine.",
+			"file (zip or tar.gz) or folder (uncompressed) residing on the server machine.\n" +
+			"The databases of an archive taken with backup --online are restored one by one " +
+			"and their roots are verified against the ones recorded in the archive manifest.\n" +
+			"With --from-catalog the databases of a backup taken by the server on schedule are restored " +
+			"and their roots are verified against the ones recorded in the catalog.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			snapshotPath := args[0]
 			dbDir, err := cmd.Flags().GetString("dbdir")
 			if err != nil {
 				c.QuitToStdErr(err)
@@ -169,7 +263,45 @@ func (cl *commandlineBck) restore(cmd *cobra.Command) {
 			if err != nil {
 				c.QuitToStdErr(err)
 			}
+			fromCatalog, err := cmd.Flags().GetString("from-catalog")
+			if err != nil {
+				c.QuitToStdErr(err)
+			}
+			if (fromCatalog == "") == (len(args) == 0) {
+				c.QuitToStdErr("either a snapshot path or --from-catalog is required")
+			}
+			if fromCatalog != "" {
+				backupDir, err := cmd.Flags().GetString("backup-dir")
+				if err != nil {
+					c.QuitToStdErr(err)
+				}
+				if backupDir == "" {
+					backupDir = server.DefaultOptions().WithDir(dbDir).GetBackupDir()
+				}
+				cl.askUserConfirmation("restore", manualStopStart)
+				entry, err := cl.catalogRestore(backupDir, fromCatalog, dbDir, manualStopStart)
+				if err != nil {
+					c.QuitToStdErr(err)
+				}
+				for _, bd := range entry.Databases {
+					fmt.Printf("Database %s restored and verified at root %d:%x\n", bd.Name, bd.RootIndex, bd.Root)
+				}
+				fmt.Printf("Backup %s restored, the previous databases have been kept in %s\n", entry.ID, server.ArchiveDir(dbDir))
+				return nil
+			}
+			snapshotPath := args[0]
 			cl.askUserConfirmation("restore", manualStopStart)
+			if server.IsBackupArchive(snapshotPath) {
+				manifest, err := cl.archiveRestore(snapshotPath, dbDir, manualStopStart)
+				if err != nil {
+					c.QuitToStdErr(err)
+				}
+				for _, bd := range manifest.Databases {
+					fmt.Printf("Database %s restored and verified at root %d:%x\n", bd.Name, bd.RootIndex, bd.Root)
+				}
+				fmt.Printf("Backup %s restored, the previous databases have been kept in %s\n", snapshotPath, server.ArchiveDir(dbDir))
+				return nil
+			}
 			autoBackupPath, err := cl.offlineRestore(snapshotPath, dbDir, manualStopStart)
 			if err != nil {
 				c.QuitToStdErr(err)
@@ -178,10 +310,12 @@ func (cl *commandlineBck) restore(cmd *cobra.Command) {
 			fmt.Printf("A backup of the previous database has been also created: %s\n", autoBackupPath)
 			return nil
 		},
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 	}
 	ccmd.Flags().String("dbdir", defaultDbDir, fmt.Sprintf("path to the server database directory which will be replaced by the backup (default %s)", defaultDbDir))
 	ccmd.Flags().Bool("manual-stop-start", false, "server stop before and restart after the backup are to be handled manually by the user (default false)")
+	ccmd.Flags().String("from-catalog", "", "id of a backup in the catalog of the server backup directory (see backup list)")
+	ccmd.Flags().String("backup-dir", "", "path to the server backup directory (default .backups in --dbdir)")
 	cmd.AddCommand(ccmd)
 }
 
@@ -273,6 +407,10 @@ func (b *backupper) offlineBackup(src string, uncompressed bool, manualStopStart
 			"error removing immudb identifier file %s from db snapshot %s: %v",
 			server.IDENTIFIER_FNAME, snapshotPath, err)
 	}
+	// the backups taken by the server on schedule are not part of the snapshot
+	if err = os.RemoveAll(server.DefaultOptions().WithDir(snapshotPath).GetBackupDir()); err != nil {
+		fmt.Fprintf(os.Stderr, "error removing the scheduled backups from db snapshot %s: %v", snapshotPath, err)
+	}
 	if uncompressed {
 		absSnapshotPath, err := filepath.Abs(snapshotPath)
 		if err != nil {
@@ -368,6 +506,14 @@ func (b *backupper) offlineRestore(src string, dst string, manualStopStart bool)
 		return "", err
 	}
 
+	// the backups taken by the server on schedule stay in the db dir
+	backupDir := server.DefaultOptions().WithDir(dst).GetBackupDir()
+	if _, err = os.Stat(backupDir); err == nil {
+		if err = os.Rename(backupDir, server.DefaultOptions().WithDir(extractedSnapshotDir).GetBackupDir()); err != nil {
+			return "", fmt.Errorf("error moving the scheduled backups %s during restore: %v", backupDir, err)
+		}
+	}
+
 	dbDirAutoBackupPath := dst + "_bkp_before_restore_" + now
 	if err = os.Rename(dst, dbDirAutoBackupPath); err != nil {
 		return "", fmt.Errorf(
@@ -382,3 +528,25 @@ func (b *backupper) offlineRestore(src string, dst string, manualStopStart bool)
 
 	return dbDirAutoBackupPath, nil
 }
+
+func (b *backupper) catalogRestore(backupDir string, id string, dst string, manualStopStart bool) (*server.BackupCatalogEntry, error) {
+	if !manualStopStart {
+		startImmudbService, err := b.stopImmudbService()
+		if err != nil {
+			return nil, err
+		}
+		defer startImmudbService()
+	}
+	return server.RestoreFromCatalog(backupDir, id, dst, logger.NewSimpleLogger("immuadmin", os.Stderr))
+}
+
+func (b *backupper) archiveRestore(src string, dst string, manualStopStart bool) (*server.BackupManifest, error) {
+	if !manualStopStart {
+		startImmudbService, err := b.stopImmudbService()
+		if err != nil {
+			return nil, err
+		}
+		defer startImmudbService()
+	}
+	return server.RestoreBackupArchive(src, dst, logger.NewSimpleLogger("immuadmin", os.Stderr))
+}
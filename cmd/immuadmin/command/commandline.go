@@ -26,6 +26,8 @@ import (
 
 type Commandline interface {
 	user(cmd *cobra.Command)
+	role(cmd *cobra.Command)
+	database(cmd *cobra.Command)
 	login(cmd *cobra.Command)
 	logout(cmd *cobra.Command)
 	status(cmd *cobra.Command)
@@ -0,0 +1,233 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immuadmin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	c "github.com/codenotary/immudb/cmd/helper"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/spf13/cobra"
+)
+
+func (cl *commandline) database(cmd *cobra.Command) {
+	ccmd := &cobra.Command{
+		Use:               "database command",
+		Short:             "Issue all database commands",
+		Aliases:           []string{"d"},
+		PersistentPreRunE: cl.connect,
+		PersistentPostRun: cl.disconnect,
+		ValidArgs:         []string{"help", "list", "acknowledge", "quarantine", "unload", "load", "rename", "drop", "settings", "readonly", "readwrite", "checker"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := cl.DatabaseOperations(args)
+			if err != nil {
+				c.QuitToStdErr(err)
+			}
+			fmt.Println(resp)
+			return nil
+		},
+	}
+	cmd.AddCommand(ccmd)
+}
+
+// DatabaseOperations executes the database subcommand described by args
+func (cl *commandline) DatabaseOperations(args []string) (string, error) {
+	var command string
+	if len(args) == 0 {
+		command = "help"
+	} else {
+		command = args[0]
+	}
+	switch command {
+	case "help":
+		fmt.Println("database list  -- shows the databases and whether they have been tampered")
+		fmt.Println()
+		fmt.Println("database acknowledge database_name  -- clears the tamper state after a manual inspection, the database is available again")
+		fmt.Println()
+		fmt.Println("database quarantine database_name  -- keeps a database unavailable until its tampering is acknowledged")
+		fmt.Println()
+		fmt.Println("database unload database_name  -- closes a database, it stays on disk and is not loaded at startup")
+		fmt.Println()
+		fmt.Println("database load database_name  -- opens an unloaded database")
+		fmt.Println()
+		fmt.Println("database rename database_name new_database_name  -- renames a database and the permissions granted on it")
+		fmt.Println()
+		fmt.Println("database drop database_name  -- archives a database on the server and removes it")
+		fmt.Println()
+		fmt.Println("database readonly database_name  -- freezes a database, writes are refused while reads and proofs keep working")
+		fmt.Println()
+		fmt.Println("database readwrite database_name  -- makes a frozen database writable again")
+		fmt.Println()
+		fmt.Println("database settings database_name [setting=value ...]  -- shows or changes the settings of a database, supported settings are:")
+		fmt.Println("  " + c.DatabaseSettingsUsage)
+		fmt.Println()
+		fmt.Println("database checker [database_name]  -- shows the progress and the failures of the consistency checker")
+		fmt.Println()
+		return "", nil
+	case "list":
+		resp, err := cl.immuClient.DatabaseList(context.Background(), &empty.Empty{})
+		if err != nil {
+			return "", err
+		}
+		fmt.Println()
+		fmt.Println("Database\tStatus")
+		for _, val := range resp.Databases {
+			status := "ok"
+			switch {
+			case val.Unloaded:
+				status = "unloaded"
+			case val.Quarantined:
+				status = "quarantined"
+			case val.Tampered:
+				status = fmt.Sprintf("tampered at index %d", val.TamperedIndex)
+			case val.ReadOnly:
+				status = "read-only"
+			}
+			fmt.Printf("%s\t\t%s\n", val.Databasename, status)
+		}
+		return "", nil
+	case "acknowledge", "quarantine":
+		if len(args) != 2 {
+			return "Incorrect number of parameters for this command. Please type 'database help' for more information.", nil
+		}
+		quarantine := command == "quarantine"
+		if err := cl.immuClient.ResolveTampering(context.Background(), args[1], quarantine); err != nil {
+			return "", err
+		}
+		if quarantine {
+			return fmt.Sprintf("Database %s quarantined", args[1]), nil
+		}
+		return fmt.Sprintf("Tampering of database %s acknowledged", args[1]), nil
+	case "unload":
+		if len(args) != 2 {
+			return "Incorrect number of parameters for this command. Please type 'database help' for more information.", nil
+		}
+		if err := cl.immuClient.UnloadDatabase(context.Background(), args[1]); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Database %s unloaded", args[1]), nil
+	case "load":
+		if len(args) != 2 {
+			return "Incorrect number of parameters for this command. Please type 'database help' for more information.", nil
+		}
+		if err := cl.immuClient.LoadDatabase(context.Background(), args[1]); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Database %s loaded", args[1]), nil
+	case "rename":
+		if len(args) != 3 {
+			return "Incorrect number of parameters for this command. Please type 'database help' for more information.", nil
+		}
+		if err := cl.immuClient.RenameDatabase(context.Background(), args[1], args[2]); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Database %s renamed to %s", args[1], args[2]), nil
+	case "drop":
+		if len(args) != 2 {
+			return "Incorrect number of parameters for this command. Please type 'database help' for more information.", nil
+		}
+		archive, err := cl.immuClient.DropDatabase(context.Background(), args[1])
+		if err != nil {
+			return "", err
+		}
+		if archive == "" {
+			return fmt.Sprintf("Database %s dropped", args[1]), nil
+		}
+		return fmt.Sprintf("Database %s dropped, archived on the server to %s", args[1], archive), nil
+	case "readonly", "readwrite":
+		if len(args) != 2 {
+			return "Incorrect number of parameters for this command. Please type 'database help' for more information.", nil
+		}
+		readOnly := command == "readonly"
+		if err := cl.immuClient.SetDatabaseReadOnly(context.Background(), args[1], readOnly); err != nil {
+			return "", err
+		}
+		if readOnly {
+			return fmt.Sprintf("Database %s is now read-only", args[1]), nil
+		}
+		return fmt.Sprintf("Database %s is now writable", args[1]), nil
+	case "settings":
+		if len(args) < 2 {
+			return "Incorrect number of parameters for this command. Please type 'database help' for more information.", nil
+		}
+		resp, err := cl.immuClient.DatabaseList(context.Background(), &empty.Empty{})
+		if err != nil {
+			return "", err
+		}
+		var settings *schema.DatabaseSettings
+		for _, val := range resp.Databases {
+			if val.Databasename == args[1] && !val.Unloaded {
+				settings = val.Settings
+			}
+		}
+		if settings == nil {
+			return "", fmt.Errorf("database %s is not loaded", args[1])
+		}
+		if len(args) == 2 {
+			return c.DatabaseSettingsString(settings), nil
+		}
+		if err = c.ParseDatabaseSettings(settings, args[2:]); err != nil {
+			return "", err
+		}
+		if err = cl.immuClient.UpdateDatabaseSettings(context.Background(), args[1], settings); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Settings of database %s updated: %s", args[1], c.DatabaseSettingsString(settings)), nil
+	case "checker":
+		if len(args) > 2 {
+			return "Incorrect number of parameters for this command. Please type 'database help' for more information.", nil
+		}
+		var name string
+		if len(args) == 2 {
+			name = args[1]
+		}
+		resp, err := cl.immuClient.CorruptionCheckerStatus(context.Background(), name)
+		if err != nil {
+			return "", err
+		}
+		return corruptionCheckerStatusString(resp), nil
+	}
+	return "", fmt.Errorf("Wrong command. Get more information with 'database help'")
+}
+
+func corruptionCheckerStatusString(resp *schema.CorruptionCheckerStatusResponse) string {
+	var sb strings.Builder
+	if !resp.Enabled {
+		sb.WriteString("The consistency checker is disabled, the last recorded progress is shown\n")
+	}
+	fmt.Fprintf(&sb, "Mode: %s\n", resp.Mode)
+	for _, db := range resp.Databases {
+		fmt.Fprintf(&sb, "\nDatabase:           %s\n", db.Databasename)
+		fmt.Fprintf(&sb, "Entries:            %d\n", db.TotalEntries)
+		fmt.Fprintf(&sb, "Scanned entries:    %d (%d complete scans)\n", db.ScannedEntries, db.CompletedScans)
+		fmt.Fprintf(&sb, "Sampled entries:    %d\n", db.SampledEntries)
+		if db.LastVerifiedRoot != nil {
+			fmt.Fprintf(&sb, "Last verified root: %x at index %d\n", db.LastVerifiedRoot.Root, db.LastVerifiedRoot.Index)
+		}
+		if db.LastRun > 0 {
+			fmt.Fprintf(&sb, "Last run:           %s\n", time.Unix(db.LastRun, 0).Format(time.RFC3339))
+		}
+		for _, f := range db.Failures {
+			fmt.Fprintf(&sb, "Failure at index %d (%s): %s\n", f.Index, time.Unix(f.Timestamp, 0).Format(time.RFC3339), f.Error)
+		}
+	}
+	return sb.String()
+}
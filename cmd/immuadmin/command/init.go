@@ -39,6 +39,8 @@ func Init(rootCmd *cobra.Command, cmdName string, cfgFn *string) *cobra.Command
 	cl.hds = client.NewHomedirService()
 
 	cl.user(rootCmd)
+	cl.role(rootCmd)
+	cl.database(rootCmd)
 	cl.login(rootCmd)
 	cl.logout(rootCmd)
 	cl.status(rootCmd)
@@ -53,6 +55,8 @@ func Init(rootCmd *cobra.Command, cmdName string, cfgFn *string) *cobra.Command
 	clb.backup(rootCmd)
 	clb.restore(rootCmd)
 	cl.printTree(rootCmd)
+	cl.verify(rootCmd)
+	cl.verifyDir(rootCmd)
 
 	cld := service.NewCommandLine()
 	cld.Service(rootCmd)
@@ -54,9 +54,13 @@ func (cl *commandline) login(cmd *cobra.Command) {
 			if cl.immuClient, err = client.NewImmuClient(cl.immuClient.GetOptions()); err != nil {
 				c.QuitWithUserError(err)
 			}
-			if string(response.Warning) == auth.WarnDefaultAdminPassword {
+			switch string(response.Warning) {
+			case "":
+			case auth.WarnDefaultAdminPassword, auth.WarnPasswordExpired:
 				c.PrintfColorW(cmd.OutOrStdout(), c.Yellow, "SECURITY WARNING: %s\n", response.Warning)
 				cl.changePassword(user, pass)
+			default:
+				c.PrintfColorW(cmd.OutOrStdout(), c.Yellow, "WARNING: %s\n", response.Warning)
 			}
 			return nil
 		},
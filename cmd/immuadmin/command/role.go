@@ -0,0 +1,174 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immuadmin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	c "github.com/codenotary/immudb/cmd/helper"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/spf13/cobra"
+)
+
+func (cl *commandline) role(cmd *cobra.Command) {
+	ccmd := &cobra.Command{
+		Use:               "role command",
+		Short:             "Issue all role commands",
+		Aliases:           []string{"r"},
+		PersistentPreRunE: cl.connect,
+		PersistentPostRun: cl.disconnect,
+		ValidArgs:         []string{"help", "list", "create", "update", "delete", "grant", "revoke"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := cl.RoleOperations(args)
+			if err != nil {
+				c.QuitToStdErr(err)
+			}
+			fmt.Println(resp)
+			return nil
+		},
+	}
+	cmd.AddCommand(ccmd)
+}
+
+// RoleOperations executes the role subcommand described by args
+func (cl *commandline) RoleOperations(args []string) (string, error) {
+	var command string
+	if len(args) == 0 {
+		command = "help"
+	} else {
+		command = args[0]
+	}
+	switch command {
+	case "help":
+		fmt.Println("role list  -- shows all roles and the permissions they grant")
+		fmt.Println()
+		fmt.Println("role create role_name database_name:permission [database_name:permission ...]  -- creates a role granting the permissions (read,readwrite,admin) on the databases")
+		fmt.Println()
+		fmt.Println("role update role_name database_name:permission [database_name:permission ...]  -- replaces the permissions granted by the role")
+		fmt.Println()
+		fmt.Println("role delete role_name  -- removes the role from the users holding it and deletes it")
+		fmt.Println()
+		fmt.Println("role grant/revoke role_name username  -- assigns the role to or removes it from the user")
+		fmt.Println()
+		return "", nil
+	case "list":
+		rolelist, err := cl.immuClient.ListRoles(context.Background())
+		if err != nil {
+			return "", err
+		}
+		fmt.Println()
+		fmt.Println("Role\tCreated By\tCreated At\t\t\t\t\tDatabase\tPermission")
+		for _, val := range rolelist.Roles {
+			fmt.Printf("%s\t%s\t\t%s\n", val.Name, val.Createdby, val.Createdat)
+			for _, val := range val.Permissions {
+				fmt.Printf("\t\t\t\t\t\t\t\t\t%s\t\t%s\n", val.Database, permissionName(val.Permission))
+			}
+			fmt.Println()
+		}
+		return "", nil
+	case "create", "update":
+		if len(args) < 3 {
+			return "Incorrect number of parameters for this command. Please type 'role help' for more information.", nil
+		}
+		permissions, msg := parseRolePermissions(args[2:])
+		if msg != "" {
+			return msg, nil
+		}
+		if command == "create" {
+			if err := cl.immuClient.CreateRole(context.Background(), &schema.CreateRoleRequest{Name: args[1], Permissions: permissions}); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Created role %s", args[1]), nil
+		}
+		if err := cl.immuClient.UpdateRole(context.Background(), &schema.UpdateRoleRequest{Name: args[1], Permissions: permissions}); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Updated role %s", args[1]), nil
+	case "delete":
+		if len(args) != 2 {
+			return "Incorrect number of parameters for this command. Please type 'role help' for more information.", nil
+		}
+		if err := cl.immuClient.DeleteRole(context.Background(), &schema.DeleteRoleRequest{Name: args[1]}); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Deleted role %s", args[1]), nil
+	case "grant", "revoke":
+		if len(args) != 3 {
+			return "Incorrect number of parameters for this command. Please type 'role help' for more information.", nil
+		}
+		req := &schema.RoleRequest{
+			Role:     args[1],
+			Username: args[2],
+		}
+		var resp *schema.Error
+		var err error
+		if command == "grant" {
+			resp, err = cl.immuClient.GrantRole(context.Background(), req)
+		} else {
+			resp, err = cl.immuClient.RevokeRole(context.Background(), req)
+		}
+		if err != nil {
+			return "", err
+		}
+		return resp.Errormessage, nil
+	}
+	return "", fmt.Errorf("Wrong command. Get more information with 'role help'")
+}
+
+// parseRolePermissions parses database_name:permission arguments, it returns a message for the user if they are malformed
+func parseRolePermissions(args []string) ([]*schema.Permission, string) {
+	var permissions []*schema.Permission
+	for _, arg := range args {
+		parts := strings.Split(arg, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Sprintf("Wrong permission %s. Expected format is database_name:permission", arg)
+		}
+		var permission uint32
+		switch parts[1] {
+		case "read":
+			permission = auth.PermissionR
+		case "readwrite":
+			permission = auth.PermissionRW
+		case "admin":
+			permission = auth.PermissionAdmin
+		default:
+			return nil, "Permission value not recognized. Allowed permissions are read,readwrite,admin"
+		}
+		permissions = append(permissions, &schema.Permission{
+			Database:   parts[0],
+			Permission: permission,
+		})
+	}
+	return permissions, ""
+}
+
+func permissionName(permission uint32) string {
+	switch permission {
+	case auth.PermissionAdmin:
+		return "Admin"
+	case auth.PermissionSysAdmin:
+		return "System Admin"
+	case auth.PermissionR:
+		return "Read"
+	case auth.PermissionRW:
+		return "Read/Write"
+	}
+	return "Unknown"
+}
@@ -57,6 +57,10 @@ func (cl *commandline) stats(cmd *cobra.Command) {
 				c.QuitToStdErr(err)
 			}
 			options := cl.immuClient.GetOptions()
+			database, err := cmd.Flags().GetString("database")
+			if err != nil {
+				c.QuitToStdErr(err)
+			}
 			if raw {
 				if err := stats.ShowMetricsRaw(options.Address); err != nil {
 					c.QuitToStdErr(err)
@@ -68,12 +72,12 @@ func (cl *commandline) stats(cmd *cobra.Command) {
 				c.QuitToStdErr(err)
 			}
 			if text {
-				if err := stats.ShowMetricsAsText(options.Address); err != nil {
+				if err := stats.ShowMetricsAsText(options.Address, database); err != nil {
 					c.QuitToStdErr(err)
 				}
 				return nil
 			}
-			if err := stats.ShowMetricsVisually(options.Address); err != nil {
+			if err := stats.ShowMetricsVisually(options.Address, database); err != nil {
 				c.QuitToStdErr(err)
 			}
 			return nil
@@ -82,5 +86,6 @@ func (cl *commandline) stats(cmd *cobra.Command) {
 	}
 	ccmd.Flags().BoolP("text", "t", false, "show statistics as text instead of the default graphical view")
 	ccmd.Flags().BoolP("raw", "r", false, "show raw statistics")
+	ccmd.Flags().StringP("database", "d", "", "show only the statistics of a database")
 	cmd.AddCommand(ccmd)
 }
@@ -78,6 +78,18 @@ type dbInfo struct {
 	uptimeHours float64
 }
 
+type databaseUsage struct {
+	name               string
+	entries            uint64
+	bytes              uint64
+	writes             uint64
+	maxEntries         uint64
+	maxBytes           uint64
+	maxWritesPerSecond uint64
+	lsmBytes           uint64
+	vlogBytes          uint64
+}
+
 type operations struct {
 	counter     uint64
 	duration    float64
@@ -93,6 +105,7 @@ type memstats struct {
 }
 
 type metrics struct {
+	database             string
 	durationRPCsByMethod map[string]rpcDuration
 	reads                operations
 	writes               operations
@@ -100,6 +113,7 @@ type metrics struct {
 	nbRPCsPerClient      map[string]uint64
 	lastMsgAtPerClient   map[string]uint64
 	db                   dbInfo
+	databases            []databaseUsage
 	memstats             memstats
 }
 
@@ -120,12 +134,22 @@ func (ms *metrics) clientsActiveDuringLastHour() *map[string]time.Time {
 }
 
 func (ms *metrics) populateFrom(metricsFamilies *map[string]*dto.MetricFamily) {
+	ms.withDatabaseUsage(metricsFamilies)
 	ms.withDBInfo(metricsFamilies)
 	ms.withClients(metricsFamilies)
 	ms.withDuration(metricsFamilies)
 	ms.withMemStats(metricsFamilies)
 }
 
+func labelValue(m *dto.Metric, name string) string {
+	for _, labelPair := range m.GetLabel() {
+		if labelPair.GetName() == name {
+			return labelPair.GetValue()
+		}
+	}
+	return ""
+}
+
 func (ms *metrics) withClients(metricsFamilies *map[string]*dto.MetricFamily) {
 	ms.nbRPCsPerClient = map[string]uint64{}
 	clientsMetrics := (*metricsFamilies)["immudb_number_of_rpcs_per_client"].GetMetric()
@@ -170,18 +194,68 @@ func (ms *metrics) withDBInfo(metricsFamilies *map[string]*dto.MetricFamily) {
 	}
 	ms.db.nbEntries = uint64((*metricsFamilies)["immudb_number_of_stored_entries"].GetMetric()[0].GetCounter().GetValue())
 	ms.db.uptimeHours = (*metricsFamilies)["immudb_uptime_hours"].GetMetric()[0].GetCounter().GetValue()
+	if ms.database == "" {
+		return
+	}
+	//show only the selected database
+	for _, u := range ms.databases {
+		if u.name == ms.database {
+			ms.db.name = u.name
+			ms.db.lsmBytes = u.lsmBytes
+			ms.db.vlogBytes = u.vlogBytes
+			ms.db.totalBytes = u.bytes
+			ms.db.nbEntries = u.entries
+			ms.databases = []databaseUsage{u}
+			return
+		}
+	}
+	ms.databases = nil
+}
+
+func (ms *metrics) withDatabaseUsage(metricsFamilies *map[string]*dto.MetricFamily) {
+	byName := map[string]*databaseUsage{}
+	var names []string
+	values := func(family string, set func(u *databaseUsage, v float64)) {
+		for _, m := range (*metricsFamilies)[family].GetMetric() {
+			name := labelValue(m, "database")
+			u, ok := byName[name]
+			if !ok {
+				u = &databaseUsage{name: name}
+				byName[name] = u
+				names = append(names, name)
+			}
+			v := m.GetGauge().GetValue()
+			if m.GetCounter() != nil {
+				v = m.GetCounter().GetValue()
+			}
+			set(u, v)
+		}
+	}
+	values("immudb_database_entries", func(u *databaseUsage, v float64) { u.entries = uint64(v) })
+	values("immudb_database_size_bytes", func(u *databaseUsage, v float64) { u.bytes = uint64(v) })
+	values("immudb_database_writes_total", func(u *databaseUsage, v float64) { u.writes = uint64(v) })
+	values("immudb_database_lsm_size_bytes", func(u *databaseUsage, v float64) { u.lsmBytes = uint64(v) })
+	values("immudb_database_vlog_size_bytes", func(u *databaseUsage, v float64) { u.vlogBytes = uint64(v) })
+	values("immudb_database_quota_entries", func(u *databaseUsage, v float64) { u.maxEntries = uint64(v) })
+	values("immudb_database_quota_bytes", func(u *databaseUsage, v float64) { u.maxBytes = uint64(v) })
+	values("immudb_database_quota_writes_per_second", func(u *databaseUsage, v float64) { u.maxWritesPerSecond = uint64(v) })
+	ms.databases = make([]databaseUsage, 0, len(names))
+	for _, name := range names {
+		ms.databases = append(ms.databases, *byName[name])
+	}
 }
 
 func (ms *metrics) withDuration(metricsFamilies *map[string]*dto.MetricFamily) {
 	ms.durationRPCsByMethod = map[string]rpcDuration{}
-	for _, m := range (*metricsFamilies)["grpc_server_handling_seconds"].GetMetric() {
-		var method string
-		for _, labelPair := range m.GetLabel() {
-			if labelPair.GetName() == "grpc_method" {
-				method = labelPair.GetValue()
-				break
-			}
+	family, methodLabel := "grpc_server_handling_seconds", "grpc_method"
+	if ms.database != "" {
+		family, methodLabel = "immudb_database_request_duration_seconds", "method"
+	}
+	for _, m := range (*metricsFamilies)[family].GetMetric() {
+		if ms.database != "" && labelValue(m, "database") != ms.database {
+			continue
 		}
+		method := labelValue(m, methodLabel)
 		h := m.GetHistogram()
 		c := h.GetSampleCount()
 		td := h.GetSampleSum()
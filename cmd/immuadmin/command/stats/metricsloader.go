@@ -29,16 +29,18 @@ type MetricsLoader interface {
 	Load() (*metrics, error)
 }
 
-func newMetricsLoader(url string) MetricsLoader {
+func newMetricsLoader(url string, database string) MetricsLoader {
 	return &metricsLoader{
-		url:    url,
-		client: newHTTPClient(),
+		url:      url,
+		database: database,
+		client:   newHTTPClient(),
 	}
 }
 
 type metricsLoader struct {
-	url    string
-	client *http.Client
+	url      string
+	database string
+	client   *http.Client
 }
 
 func (ml *metricsLoader) Load() (*metrics, error) {
@@ -56,7 +58,10 @@ func (ml *metricsLoader) Load() (*metrics, error) {
 	if err != nil {
 		return nil, err
 	}
-	ms := &metrics{}
+	ms := &metrics{database: ml.database}
 	ms.populateFrom(&metricsFamilies)
+	if ml.database != "" && ms.db.name != ml.database {
+		return nil, fmt.Errorf("no statistics found for database %s", ml.database)
+	}
 	return ms, nil
 }
@@ -51,9 +51,9 @@ func ShowMetricsRaw(serverAddress string) error {
 	return nil
 }
 
-// ShowMetricsAsText ...
-func ShowMetricsAsText(serverAddress string) error {
-	loader := newMetricsLoader(metricsURL(serverAddress))
+// ShowMetricsAsText shows the statistics of the server, or of a single database if database is not empty
+func ShowMetricsAsText(serverAddress string, database string) error {
+	loader := newMetricsLoader(metricsURL(serverAddress), database)
 	ms, err := loader.Load()
 	if err != nil {
 		return err
@@ -75,6 +75,19 @@ func ShowMetricsAsText(serverAddress string) error {
 	fmt.Printf(strPattern, labelLength, "VLog size", vlogSizeS)
 	fmt.Printf(strPattern, labelLength, "Total size", totalSizeS)
 
+	// print databases usage and quotas
+	if len(ms.databases) > 0 {
+		fmt.Printf(strPattern, labelLength, "Databases (usage / quota)", "")
+		for _, u := range ms.databases {
+			sizeS, _ := byteCountBinary(u.bytes)
+			fmt.Printf("   "+strPattern, labelLength-3, u.name, "")
+			fmt.Printf("      "+strPattern, labelLength-6, "Entries", quotaString(fmt.Sprintf("%d", u.entries), u.maxEntries, fmt.Sprintf("%d", u.maxEntries)))
+			maxSizeS, _ := byteCountBinary(u.maxBytes)
+			fmt.Printf("      "+strPattern, labelLength-6, "Size", quotaString(sizeS, u.maxBytes, maxSizeS))
+			fmt.Printf("      "+strPattern, labelLength-6, "Writes", quotaString(fmt.Sprintf("%d", u.writes), u.maxWritesPerSecond, fmt.Sprintf("%d/s", u.maxWritesPerSecond)))
+		}
+	}
+
 	// print clients
 	fmt.Printf(intPattern, labelLength, "Number of clients", ms.nbClients)
 	fmt.Printf(strPattern, labelLength, "Queries per client", "")
@@ -104,7 +117,14 @@ func ShowMetricsAsText(serverAddress string) error {
 	return nil
 }
 
-// ShowMetricsVisually ...
-func ShowMetricsVisually(serverAddress string) error {
-	return runUI(newMetricsLoader(metricsURL(serverAddress)))
+func quotaString(usage string, quota uint64, quotaS string) string {
+	if quota == 0 {
+		return usage + " / unlimited"
+	}
+	return usage + " / " + quotaS
+}
+
+// ShowMetricsVisually shows the statistics of the server, or of a single database if database is not empty
+func ShowMetricsVisually(serverAddress string, database string) error {
+	return runUI(newMetricsLoader(metricsURL(serverAddress), database))
 }
@@ -65,7 +65,7 @@ func (cl *commandline) UserOperations(args []string) (string, error) {
 		fmt.Println()
 		fmt.Println("user permission grant/revoke username permission_type database_name  -- grants or revokes the permission (read,write,readwrite) for the database")
 		fmt.Println()
-		fmt.Println("user activate/deactivate username  -- activates or deactivates a user")
+		fmt.Println("user activate/deactivate username  -- activates or deactivates a user, activating also unlocks a locked out user")
 		fmt.Println()
 		return "", nil
 	case "list":
@@ -92,6 +92,12 @@ func (cl *commandline) UserOperations(args []string) (string, error) {
 					return "Permission value not recognized. Allowed permissions are read,readwrite,admin", nil
 				}
 			}
+			for _, role := range val.Roles {
+				fmt.Printf("\t\t\t\t\t\t\t\t\t\tRole %s\n", role)
+			}
+			if val.Locked {
+				fmt.Println("\tLocked out after too many failed logins, use 'user activate' to unlock")
+			}
 			fmt.Println()
 		}
 		return "", nil
@@ -0,0 +1,81 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immuadmin
+
+import (
+	"fmt"
+	"io"
+
+	c "github.com/codenotary/immudb/cmd/helper"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/spf13/cobra"
+)
+
+func (cl *commandline) verify(cmd *cobra.Command) {
+	ccmd := &cobra.Command{
+		Use:               "verify",
+		Short:             "Verify a whole database: every entry digest and every tree layer are rebuilt on the server and compared with the stored ones",
+		PersistentPreRunE: cl.connect,
+		PersistentPostRun: cl.disconnect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := cmd.Flags().GetString("db")
+			if err != nil {
+				c.QuitToStdErr(err)
+			}
+			consistent, err := cl.verifyDatabase(cmd.OutOrStdout(), db)
+			if err != nil {
+				c.QuitWithUserError(err)
+			}
+			if !consistent {
+				c.QuitToStdErr("database is NOT consistent")
+			}
+			return nil
+		},
+		Args: cobra.NoArgs,
+	}
+	ccmd.Flags().String("db", "", "database to verify (default is the database in use)")
+	cmd.AddCommand(ccmd)
+}
+
+// verifyDatabase prints the progress and the mismatches of the verification and returns whether the database is consistent
+func (cl *commandline) verifyDatabase(out io.Writer, db string) (bool, error) {
+	onProgress := func(p *schema.VerifyDatabaseProgress) {
+		for _, m := range p.Mismatches {
+			if m.Error != "" {
+				fmt.Fprintf(out, "MISMATCH at layer %d index %d: %s\n", m.Layer, m.Index, m.Error)
+				continue
+			}
+			fmt.Fprintf(out, "MISMATCH at layer %d index %d: expected %x, found %x\n", m.Layer, m.Index, m.Expected, m.Actual)
+		}
+		if !p.Done && p.Total > 0 {
+			fmt.Fprintf(out, "verified %d/%d entries\n", p.Verified, p.Total)
+		}
+	}
+	result, err := cl.immuClient.VerifyDatabase(cl.context, db, onProgress)
+	if err != nil {
+		return false, err
+	}
+	if result.Root == nil || result.Root.Root == nil {
+		fmt.Fprintln(out, "database is empty")
+		return result.Consistent, nil
+	}
+	fmt.Fprintf(out, "verified %d entries against root %x at index %d\n", result.Verified, result.Root.Root, result.Root.Index)
+	if result.Consistent {
+		fmt.Fprintln(out, "database is consistent")
+	}
+	return result.Consistent, nil
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immuadmin
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	c "github.com/codenotary/immudb/cmd/helper"
+	"github.com/codenotary/immudb/pkg/fs"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/store"
+	"github.com/spf13/cobra"
+)
+
+// verifyDirTreeCacheSize the tree is only read while verifying, there is no need for the default cache
+const verifyDirTreeCacheSize = 1000
+
+func (cl *commandline) verifyDir(cmd *cobra.Command) {
+	ccmd := &cobra.Command{
+		Use:   "verify-dir path",
+		Short: "Verify the integrity of a data directory, of a database directory or of a backup archive without a running server",
+		Long: "Open read-only every database found in path, a directory or a .tar.gz/.zip archive created by 'immuadmin backup', " +
+			"the databases of an archive created by 'immuadmin backup --online' are first restored aside and checked against its manifest, " +
+			"recompute the digest of each entry and the whole Merkle tree and compare them with the stored ones. " +
+			"The server must not be running on the same directory.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			consistent, err := verifyDir(cmd.OutOrStdout(), args[0])
+			if err != nil {
+				c.QuitToStdErr(err)
+			}
+			if !consistent {
+				c.QuitToStdErr("integrity verification FAILED")
+			}
+			return nil
+		},
+		Args: cobra.ExactArgs(1),
+	}
+	cmd.AddCommand(ccmd)
+}
+
+// verifyDir verifies all the databases in path, extracting it first if it is an archive
+func verifyDir(out io.Writer, path string) (bool, error) {
+	if _, err := os.Stat(path); err != nil {
+		return false, err
+	}
+	if server.IsBackupArchive(path) {
+		return verifyBackupArchive(out, path)
+	}
+	var extract func(string, string) error
+	switch lower := strings.ToLower(path); {
+	case strings.HasSuffix(lower, ".tar.gz"):
+		extract = fs.UnTarIt
+	case strings.HasSuffix(lower, ".zip"):
+		extract = fs.UnZipIt
+	}
+	dir := path
+	if extract != nil {
+		tmp, err := ioutil.TempDir("", "immudb_verify")
+		if err != nil {
+			return false, err
+		}
+		defer os.RemoveAll(tmp)
+		if err = extract(path, tmp); err != nil {
+			return false, fmt.Errorf("error extracting %s: %v", path, err)
+		}
+		dir = tmp
+	}
+	dbDirs, err := findDatabaseDirs(dir)
+	if err != nil {
+		return false, err
+	}
+	if len(dbDirs) == 0 {
+		return false, fmt.Errorf("no database found in %s", path)
+	}
+	consistent := true
+	for _, dbDir := range dbDirs {
+		name, _ := filepath.Rel(dir, dbDir)
+		if name == "." {
+			name = filepath.Base(path)
+		}
+		ok, err := verifyDbDir(out, name, dbDir)
+		if err != nil {
+			fmt.Fprintf(out, "database %s: %v\n", name, err)
+			ok = false
+		}
+		consistent = consistent && ok
+	}
+	return consistent, nil
+}
+
+// verifyBackupArchive restores aside the databases of an archive taken with backup --online, checking them against
+// the checksums and the roots recorded in its manifest, and verifies each of them
+func verifyBackupArchive(out io.Writer, path string) (bool, error) {
+	tmp, err := ioutil.TempDir("", "immudb_verify")
+	if err != nil {
+		return false, err
+	}
+	defer os.RemoveAll(tmp)
+	log := logger.NewSimpleLoggerWithLevel("immuadmin ", os.Stderr, logger.LogError)
+	manifest, err := server.RestoreBackupArchive(path, tmp, log)
+	if err != nil {
+		fmt.Fprintf(out, "backup %s: %v\n", path, err)
+		return false, nil
+	}
+	fmt.Fprintf(out, "backup %s taken at %s: checksums and roots match the manifest\n",
+		path, manifest.Created.Local().Format(time.RFC3339))
+	consistent := true
+	for _, bd := range manifest.Databases {
+		ok, err := verifyDbDir(out, bd.Name, filepath.Join(tmp, bd.Name))
+		if err != nil {
+			fmt.Fprintf(out, "database %s: %v\n", bd.Name, err)
+			ok = false
+		}
+		consistent = consistent && ok
+	}
+	return consistent, nil
+}
+
+// findDatabaseDirs returns the directories holding badger data
+func findDatabaseDirs(root string) ([]string, error) {
+	var dirs []string
+	err := filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && info.Name() == "MANIFEST" {
+			dirs = append(dirs, filepath.Dir(p))
+		}
+		return nil
+	})
+	sort.Strings(dirs)
+	return dirs, err
+}
+
+// verifyDbDir opens read-only the store in dir, rebuilds its tree and prints the root, the width and the corrupted entries
+func verifyDbDir(out io.Writer, name string, dir string) (bool, error) {
+	log := logger.NewSimpleLoggerWithLevel("immuadmin ", os.Stderr, logger.LogError)
+	storeOpts, badgerOpts := store.DefaultOptions(dir, log)
+	badgerOpts = badgerOpts.WithReadOnly(true).WithTruncate(false)
+	st, err := store.Open(storeOpts.WithTreeCacheSize(verifyDirTreeCacheSize), badgerOpts)
+	if err != nil {
+		return false, err
+	}
+	defer st.Close()
+
+	var corrupted []uint64
+	onProgress := func(verified uint64, total uint64) error { return nil }
+	onMismatch := func(m store.TreeMismatch) error {
+		fmt.Fprintf(out, "  MISMATCH at %s\n", m)
+		if m.Layer == 0 {
+			corrupted = append(corrupted, m.Index)
+		}
+		return nil
+	}
+	fmt.Fprintf(out, "database %s\n", name)
+	root, consistent, err := st.VerifyTree(onProgress, onMismatch)
+	if err != nil {
+		return false, err
+	}
+	if root.Root == nil {
+		fmt.Fprintln(out, "  empty")
+		return consistent, nil
+	}
+	fmt.Fprintf(out, "  width: %d\n  root:  %x\n", root.Index+1, root.Root)
+	if len(corrupted) > 0 {
+		fmt.Fprintf(out, "  corrupted indexes: %v\n", corrupted)
+	}
+	if consistent {
+		fmt.Fprintln(out, "  consistent")
+	} else {
+		fmt.Fprintln(out, "  NOT consistent")
+	}
+	return consistent, nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immuadmin
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/fs"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/store"
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "verify_dir_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	dbDir := filepath.Join(dir, "data", "mydb")
+	require.NoError(t, os.MkdirAll(dbDir, 0755))
+
+	st, err := store.Open(store.DefaultOptions(dbDir, logger.NewSimpleLogger("test ", ioutil.Discard)))
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		_, err = st.SafeSet(schema.SafeSetOptions{Kv: &schema.KeyValue{Key: []byte(strconv.Itoa(i)), Value: []byte("value")}})
+		require.NoError(t, err)
+	}
+	require.NoError(t, st.Close())
+
+	var out bytes.Buffer
+	consistent, err := verifyDir(&out, filepath.Join(dir, "data"))
+	assert.NoError(t, err)
+	assert.True(t, consistent, out.String())
+	assert.Contains(t, out.String(), "database mydb")
+	assert.Contains(t, out.String(), "width: 10")
+
+	archive := filepath.Join(dir, "backup.tar.gz")
+	require.NoError(t, fs.TarIt(filepath.Join(dir, "data"), archive))
+	consistent, err = verifyDir(&out, archive)
+	assert.NoError(t, err)
+	assert.True(t, consistent)
+
+	// TAMPER: overwrite the value of the entry at index 4 keeping its version
+	_, badgerOpts := store.DefaultOptions(dbDir, logger.NewSimpleLogger("test ", ioutil.Discard))
+	db, err := badger.OpenManaged(badgerOpts)
+	require.NoError(t, err)
+	txn := db.NewTransactionAt(math.MaxUint64, true)
+	require.NoError(t, txn.Set([]byte("4"), []byte("tampered")))
+	require.NoError(t, txn.CommitAt(5, nil))
+	require.NoError(t, db.Close())
+
+	out.Reset()
+	consistent, err = verifyDir(&out, dbDir)
+	assert.NoError(t, err)
+	assert.False(t, consistent)
+	assert.Contains(t, out.String(), "corrupted indexes: [4]")
+
+	_, err = verifyDir(&out, filepath.Join(dir, "nodir"))
+	assert.Error(t, err)
+}
+
+func TestVerifyDirOnlineBackupArchive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "verify_dir_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	st, err := store.Open(store.DefaultOptions(filepath.Join(dir, "mydb"), logger.NewSimpleLogger("test ", ioutil.Discard)))
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		_, err = st.SafeSet(schema.SafeSetOptions{Kv: &schema.KeyValue{Key: []byte(strconv.Itoa(i)), Value: []byte("value")}})
+		require.NoError(t, err)
+	}
+	var snapshot bytes.Buffer
+	root, err := st.Backup(&snapshot)
+	require.NoError(t, err)
+	require.NoError(t, st.Close())
+
+	writeArchive := func(path string, bd server.BackupDatabase, content []byte) {
+		manifest, err := json.Marshal(&server.BackupManifest{Version: 1, Created: time.Now(), Databases: []server.BackupDatabase{bd}})
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gw)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: server.BackupManifestName, Mode: 0644, Size: int64(len(manifest))}))
+		_, err = tw.Write(manifest)
+		require.NoError(t, err)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: bd.File, Mode: 0644, Size: int64(len(content))}))
+		_, err = tw.Write(content)
+		require.NoError(t, err)
+		require.NoError(t, tw.Close())
+		require.NoError(t, gw.Close())
+		require.NoError(t, ioutil.WriteFile(path, buf.Bytes(), 0644))
+	}
+	sum := sha256.Sum256(snapshot.Bytes())
+	bd := server.BackupDatabase{
+		Name:      "mydb",
+		File:      "mydb.bak",
+		RootIndex: root.GetIndex(),
+		Root:      root.GetRoot(),
+		Checksum:  hex.EncodeToString(sum[:]),
+	}
+	archive := filepath.Join(dir, "online.tar.gz")
+	writeArchive(archive, bd, snapshot.Bytes())
+	var out bytes.Buffer
+	consistent, err := verifyDir(&out, archive)
+	assert.NoError(t, err)
+	assert.True(t, consistent, out.String())
+	assert.Contains(t, out.String(), "database mydb")
+	assert.Contains(t, out.String(), "width: 10")
+
+	// the snapshot does not lead to the root recorded in the manifest
+	bd.RootIndex--
+	writeArchive(archive, bd, snapshot.Bytes())
+	out.Reset()
+	consistent, err = verifyDir(&out, archive)
+	assert.NoError(t, err)
+	assert.False(t, consistent)
+	assert.Contains(t, out.String(), "does not match the recorded root")
+}
@@ -181,7 +181,8 @@ func options() *client.Options {
 		WithPort(port).
 		WithAddress(address).
 		WithTokenFileName(tokenFileName).
-		WithMTLs(mtls)
+		WithMTLs(mtls).
+		WithServerSigningPubKey(viper.GetString("server-signing-pub-key"))
 	if mtls {
 		// todo https://golang.org/src/crypto/x509/root_linux.go
 		options.MTLsOptions = client.DefaultMTLsOptions().
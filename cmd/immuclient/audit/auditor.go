@@ -18,6 +18,7 @@ package audit
 
 import (
 	"context"
+	"crypto"
 	"errors"
 	"fmt"
 	"os"
@@ -30,6 +31,7 @@ import (
 	"github.com/codenotary/immudb/pkg/client"
 	"github.com/codenotary/immudb/pkg/client/auditor"
 	"github.com/codenotary/immudb/pkg/client/cache"
+	"github.com/codenotary/immudb/pkg/signer"
 	"github.com/spf13/viper"
 )
 
@@ -83,13 +85,32 @@ func (cAgent *auditAgent) InitAgent() (AuditAgent, error) {
 		}
 	}
 
+	var serverSigningPubKey crypto.PublicKey
+	if cliOpts.ServerSigningPubKey != "" {
+		if serverSigningPubKey, err = signer.LoadPublicKey(cliOpts.ServerSigningPubKey); err != nil {
+			return nil, err
+		}
+	}
 	cAgent.ImmuAudit, err = auditor.DefaultAuditor(time.Duration(cAgent.cycleFrequency)*time.Second,
 		fmt.Sprintf("%s:%v", options().Address, options().Port),
 		cliOpts.DialOptions,
 		auditUsername,
 		auditPassword,
 		cache.NewHistoryFileCache(filepath.Join(os.TempDir(), "auditor")),
-		cAgent.metrics.updateMetrics, cAgent.logfile)
+		cAgent.metrics.updateMetrics, cAgent.logfile,
+		auditor.DefaultOptions().
+			WithServerSigningPubKey(serverSigningPubKey).
+			WithAlerts(auditor.DefaultAlertOptions().
+				WithWebhookURL(viper.GetString("audit-alert-webhook")).
+				WithCommand(viper.GetString("audit-alert-command")).
+				WithSyslog(viper.GetBool("audit-alert-syslog")).
+				WithMaxConnectionFailures(viper.GetInt("audit-alert-connection-failures"))).
+			WithGossip(auditor.GossipOptions{}.
+				WithAddress(viper.GetString("audit-gossip-address")).
+				WithSigningKey(viper.GetString("audit-gossip-signing-key")).
+				WithPeers(viper.GetStringSlice("audit-gossip-peers")).
+				WithTrustedKeys(viper.GetStringSlice("audit-gossip-trusted-keys"))).
+			WithSampleSize(viper.GetInt("audit-sample-size")))
 	if err != nil {
 		return nil, err
 	}
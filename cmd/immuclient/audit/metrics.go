@@ -21,6 +21,7 @@ import (
 	"net/http"
 
 	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client/auditor"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/viper"
@@ -53,16 +54,27 @@ var (
 		"audit_prev_root_per_server",
 		"Previous root index used for the latest audit.",
 	)
+	AuditSampleVerifiedPerServer = newAuditGaugeVec(
+		"audit_sample_verified_per_server",
+		"Number of sampled entries verified by the latest audit (-1 = sampling not run).",
+	)
+	AuditSampleFailedPerServer = newAuditGaugeVec(
+		"audit_sample_failed_per_server",
+		"Number of sampled entries that failed verification in the latest audit (-1 = sampling not run).",
+	)
 )
 
 func (p *prometheusMetrics) init(serverid string) {
 	p.server_address = fmt.Sprintf("%s:%s", viper.GetString("immudb-address"), viper.GetString("immudb-port"))
 	p.server_id = serverid
-	prometheus.MustRegister(AuditResultPerServer, AuditCurrRootPerServer, AuditRunAtPerServer, AuditPrevRootPerServer)
+	prometheus.MustRegister(AuditResultPerServer, AuditCurrRootPerServer, AuditRunAtPerServer, AuditPrevRootPerServer,
+		AuditSampleVerifiedPerServer, AuditSampleFailedPerServer)
 	AuditResultPerServer.WithLabelValues(p.server_id, p.server_address).Set(-1)
 	AuditCurrRootPerServer.WithLabelValues(p.server_id, p.server_address).Set(-1)
 	AuditRunAtPerServer.WithLabelValues(p.server_id, p.server_address).SetToCurrentTime()
 	AuditPrevRootPerServer.WithLabelValues(p.server_id, p.server_address).Set(-1)
+	AuditSampleVerifiedPerServer.WithLabelValues(p.server_id, p.server_address).Set(-1)
+	AuditSampleFailedPerServer.WithLabelValues(p.server_id, p.server_address).Set(-1)
 }
 
 func (p *prometheusMetrics) startServer() error {
@@ -96,6 +108,7 @@ func (p *prometheusMetrics) updateMetrics(
 	result bool,
 	prevRoot *schema.Root,
 	currRoot *schema.Root,
+	sample *auditor.SampleResult,
 ) {
 	var r float64
 	if checked && result {
@@ -126,4 +139,13 @@ func (p *prometheusMetrics) updateMetrics(
 		WithLabelValues(p.server_id, p.server_address).Set(currRootIndex)
 	AuditRunAtPerServer.
 		WithLabelValues(p.server_id, p.server_address).SetToCurrentTime()
+	sampleVerified, sampleFailed := -1., -1.
+	if sample != nil {
+		sampleVerified = float64(sample.Verified)
+		sampleFailed = float64(sample.Failed)
+	}
+	AuditSampleVerifiedPerServer.
+		WithLabelValues(p.server_id, p.server_address).Set(sampleVerified)
+	AuditSampleFailedPerServer.
+		WithLabelValues(p.server_id, p.server_address).Set(sampleFailed)
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/codenotary/immudb/cmd/immuclient/cli"
 	"github.com/codenotary/immudb/cmd/immuclient/immuc"
 	"github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/client/auditor"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -140,6 +141,7 @@ func configureOptions(cmd *cobra.Command, o *c.Options) error {
 	cmd.PersistentFlags().String("certificate", client.DefaultMTLsOptions().Certificate, "server certificate file path")
 	cmd.PersistentFlags().String("pkey", client.DefaultMTLsOptions().Pkey, "server private key path")
 	cmd.PersistentFlags().String("clientcas", client.DefaultMTLsOptions().ClientCAs, "clients certificates list. Aka certificate authority")
+	cmd.PersistentFlags().String("server-signing-pub-key", "", "path of the PEM encoded public key the roots returned by the server must be signed with")
 	cmd.PersistentFlags().Bool("value-only", false, "returning only values for get operations")
 	cmd.PersistentFlags().String("roots-filepath", "/tmp/", "Filepath for storing root hashes after every successful audit loop. Default is tempdir of every OS.")
 	cmd.PersistentFlags().String("prometheus-port", "9477", "Launch port of the Prometheus exporter.")
@@ -147,6 +149,15 @@ func configureOptions(cmd *cobra.Command, o *c.Options) error {
 	cmd.PersistentFlags().String("dir", os.TempDir(), "Main directory for audit process tool to initialize")
 	cmd.PersistentFlags().String("audit-username", "", "immudb username used to login during audit")
 	cmd.PersistentFlags().String("audit-password", "", "immudb password used to login during audit; can be plain-text or base64 encoded (must be prefixed with 'enc:' if it is encoded)")
+	cmd.PersistentFlags().String("audit-alert-webhook", "", "URL receiving a POST with a JSON payload when the auditor detects tampering, an empty database or repeated connection failures")
+	cmd.PersistentFlags().String("audit-alert-command", "", "command executed, with the JSON alert on its standard input, when the auditor raises an alert")
+	cmd.PersistentFlags().Bool("audit-alert-syslog", false, "send auditor alerts to the local syslog")
+	cmd.PersistentFlags().Int("audit-sample-size", 0, "number of random entries whose inclusion proof is verified on every audit (0 disables sampling)")
+	cmd.PersistentFlags().String("audit-gossip-address", "", "address the auditor publishes its signed observed roots on, e.g. :9478 (disabled if empty)")
+	cmd.PersistentFlags().String("audit-gossip-signing-key", "", "path of the PEM encoded private key the published observed roots are signed with")
+	cmd.PersistentFlags().StringSlice("audit-gossip-peers", nil, "base URLs of the peer auditors whose observed roots are cross-checked, e.g. http://auditor2:9478")
+	cmd.PersistentFlags().StringSlice("audit-gossip-trusted-keys", nil, "paths of the PEM encoded public keys the observed roots of peer auditors must be signed with (any key is accepted if empty)")
+	cmd.PersistentFlags().Int("audit-alert-connection-failures", auditor.DefaultAlertMaxConnectionFailures, "number of consecutive audits failing to reach the server after which an alert is raised (0 disables it)")
 
 	if err := viper.BindPFlag("immudb-port", cmd.PersistentFlags().Lookup("immudb-port")); err != nil {
 		return err
@@ -172,6 +183,9 @@ func configureOptions(cmd *cobra.Command, o *c.Options) error {
 	if err := viper.BindPFlag("clientcas", cmd.PersistentFlags().Lookup("clientcas")); err != nil {
 		return err
 	}
+	if err := viper.BindPFlag("server-signing-pub-key", cmd.PersistentFlags().Lookup("server-signing-pub-key")); err != nil {
+		return err
+	}
 	if err := viper.BindPFlag("value-only", cmd.PersistentFlags().Lookup("value-only")); err != nil {
 		return err
 	}
@@ -194,6 +208,33 @@ func configureOptions(cmd *cobra.Command, o *c.Options) error {
 	if err := viper.BindPFlag("audit-password", cmd.PersistentFlags().Lookup("audit-password")); err != nil {
 		return err
 	}
+	if err := viper.BindPFlag("audit-alert-webhook", cmd.PersistentFlags().Lookup("audit-alert-webhook")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("audit-alert-command", cmd.PersistentFlags().Lookup("audit-alert-command")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("audit-alert-syslog", cmd.PersistentFlags().Lookup("audit-alert-syslog")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("audit-alert-connection-failures", cmd.PersistentFlags().Lookup("audit-alert-connection-failures")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("audit-sample-size", cmd.PersistentFlags().Lookup("audit-sample-size")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("audit-gossip-address", cmd.PersistentFlags().Lookup("audit-gossip-address")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("audit-gossip-signing-key", cmd.PersistentFlags().Lookup("audit-gossip-signing-key")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("audit-gossip-peers", cmd.PersistentFlags().Lookup("audit-gossip-peers")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("audit-gossip-trusted-keys", cmd.PersistentFlags().Lookup("audit-gossip-trusted-keys")); err != nil {
+		return err
+	}
 
 	viper.SetDefault("immudb-port", client.DefaultOptions().Port)
 	viper.SetDefault("immudb-address", client.DefaultOptions().Address)
@@ -203,12 +244,22 @@ func configureOptions(cmd *cobra.Command, o *c.Options) error {
 	viper.SetDefault("certificate", client.DefaultMTLsOptions().Certificate)
 	viper.SetDefault("pkey", client.DefaultMTLsOptions().Pkey)
 	viper.SetDefault("clientcas", client.DefaultMTLsOptions().ClientCAs)
+	viper.SetDefault("server-signing-pub-key", "")
 	viper.SetDefault("value-only", false)
 	viper.SetDefault("prometheus-port", "9477")
 	viper.SetDefault("prometheus-host", "0.0.0.0")
 	viper.SetDefault("roots-filepath", os.TempDir())
 	viper.SetDefault("audit-password", "")
 	viper.SetDefault("audit-username", "")
+	viper.SetDefault("audit-alert-webhook", "")
+	viper.SetDefault("audit-alert-command", "")
+	viper.SetDefault("audit-alert-syslog", false)
+	viper.SetDefault("audit-alert-connection-failures", auditor.DefaultAlertMaxConnectionFailures)
+	viper.SetDefault("audit-sample-size", 0)
+	viper.SetDefault("audit-gossip-address", "")
+	viper.SetDefault("audit-gossip-signing-key", "")
+	viper.SetDefault("audit-gossip-peers", []string{})
+	viper.SetDefault("audit-gossip-trusted-keys", []string{})
 	viper.SetDefault("dir", os.TempDir())
 	o.InitConfig("")
 	return nil
@@ -235,7 +235,7 @@ func (cl *commandline) database(cmd *cobra.Command) {
 		Use:               "create",
 		PersistentPreRunE: cl.connect,
 		PersistentPostRun: cl.disconnect,
-		Example:           "create database_name",
+		Example:           "create database_name [" + c.DatabaseSettingsUsage + "]",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			resp, err := cl.immucl.CreateDatabase(args)
 			if err != nil {
@@ -244,7 +244,7 @@ func (cl *commandline) database(cmd *cobra.Command) {
 			fmt.Println(resp)
 			return nil
 		},
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MinimumNArgs(1),
 	}
 	ccmd.AddCommand(ccd)
 	ccmd.AddCommand(cc)
@@ -107,7 +107,8 @@ func Options() *client.Options {
 		WithPort(viper.GetInt("immudb-port")).
 		WithAddress(viper.GetString("immudb-address")).
 		WithTokenFileName(viper.GetString("tokenfile")).
-		WithMTLs(viper.GetBool("mtls"))
+		WithMTLs(viper.GetBool("mtls")).
+		WithServerSigningPubKey(viper.GetString("server-signing-pub-key"))
 	if viper.GetBool("mtls") {
 		// todo https://golang.org/src/crypto/x509/root_linux.go
 		options.MTLsOptions = client.DefaultMTLsOptions().
@@ -26,6 +26,7 @@ import (
 	"os"
 	"strconv"
 
+	c "github.com/codenotary/immudb/cmd/helper"
 	"github.com/codenotary/immudb/pkg/api/schema"
 	"github.com/codenotary/immudb/pkg/client"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -190,9 +191,14 @@ func (i *immuc) CreateDatabase(args []string) (string, error) {
 		return "", fmt.Errorf("ERROR: Not enough arguments. Use [command] --help for documentation ")
 	}
 	dbname := args[0]
+	settings := &schema.DatabaseSettings{}
+	if err := c.ParseDatabaseSettings(settings, args[1:]); err != nil {
+		return "", err
+	}
 	ctx := context.Background()
 	resp, err := i.ImmuClient.CreateDatabase(ctx, &schema.Database{
 		Databasename: string(dbname),
+		Settings:     settings,
 	})
 	if err != nil {
 		return "", err
@@ -17,6 +17,9 @@ limitations under the License.
 package immudb
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/codenotary/immudb/cmd/docs/man"
 	c "github.com/codenotary/immudb/cmd/helper"
 	"github.com/codenotary/immudb/cmd/version"
@@ -55,8 +58,17 @@ Environment variables:
   IMMUDB_PKEY=./tools/mtls/3_application/private/localhost.key.pem
   IMMUDB_CERTIFICATE=./tools/mtls/3_application/certs/localhost.cert.pem
   IMMUDB_CLIENTCAS=./tools/mtls/2_intermediate/certs/ca-chain.cert.pem
+  IMMUDB_MTLS_IDENTITY=
+  IMMUDB_MTLS_IDENTITY_MAP=
   IMMUDB_DEVMODE=true
   IMMUDB_MAINTENANCE=false
+  IMMUDB_READONLY=false
+  IMMUDB_MAX_LOGIN_ATTEMPTS=5
+  IMMUDB_LOGIN_LOCKOUT=15m
+  IMMUDB_PASSWORD_MIN_LENGTH=8
+  IMMUDB_PASSWORD_CHAR_CLASSES=upper,digit,special
+  IMMUDB_PASSWORD_HISTORY=0
+  IMMUDB_PASSWORD_MAX_AGE=0s
   IMMUDB_ADMIN_PASSWORD=immudb`,
 		DisableAutoGenTag: true,
 		RunE:              Immudb,
@@ -142,6 +154,31 @@ func parseOptions(cmd *cobra.Command) (options server.Options, err error) {
 	noHistograms := viper.GetBool("no-histograms")
 	detached := viper.GetBool("detached")
 	consistencyCheck := viper.GetBool("consistency-check")
+	consistencyCheckerOptions := server.DefaultCorruptionCheckerOptions().
+		WithMode(viper.GetString("consistency-check-mode")).
+		WithEntriesPerSecond(viper.GetInt("consistency-check-rate")).
+		WithBatchSize(uint64(viper.GetInt64("consistency-check-batch"))).
+		WithSampleSize(uint64(viper.GetInt64("consistency-check-sample")))
+	if err = consistencyCheckerOptions.Validate(); err != nil {
+		return options, err
+	}
+	signingKey, err := c.ResolvePath(viper.GetString("signing-key"), true)
+	if err != nil {
+		return options, err
+	}
+	backupDir, err := c.ResolvePath(viper.GetString("backup-dir"), true)
+	if err != nil {
+		return options, err
+	}
+	backupOptions := server.DefaultBackupOptions().
+		WithSchedule(viper.GetString("backup-schedule")).
+		WithDir(backupDir).
+		WithDatabases(viper.GetStringSlice("backup-databases")).
+		WithRetentionCount(viper.GetInt("backup-retention-count")).
+		WithRetentionAge(viper.GetDuration("backup-retention-age"))
+	if err = backupOptions.Validate(); err != nil {
+		return options, err
+	}
 	certificate, err := c.ResolvePath(viper.GetString("certificate"), true)
 	if err != nil {
 		return options, err
@@ -154,9 +191,30 @@ func parseOptions(cmd *cobra.Command) (options server.Options, err error) {
 	if err != nil {
 		return options, err
 	}
+	mtlsIdentity := viper.GetString("mtls-identity")
+	if mtlsIdentity != "" && mtlsIdentity != server.CertIdentityCN && mtlsIdentity != server.CertIdentitySAN {
+		return options, fmt.Errorf("invalid mtls-identity %s: allowed values are %s and %s",
+			mtlsIdentity, server.CertIdentityCN, server.CertIdentitySAN)
+	}
+	mtlsIdentityMap, err := parseIdentityMap(viper.GetStringSlice("mtls-identity-map"))
+	if err != nil {
+		return options, err
+	}
 	devMode := viper.GetBool("devmode")
 	adminPassword := viper.GetString("admin-password")
 	maintenance := viper.GetBool("maintenance")
+	readOnly := viper.GetBool("readonly")
+	maxLoginAttempts := viper.GetInt("max-login-attempts")
+	loginLockout := viper.GetDuration("login-lockout")
+	passwordPolicy := server.DefaultOptions().PasswordPolicy.
+		WithMinLength(viper.GetInt("password-min-length")).
+		WithMaxLength(viper.GetInt("password-max-length")).
+		WithCharClasses(viper.GetStringSlice("password-char-classes")).
+		WithHistoryDepth(viper.GetInt("password-history")).
+		WithMaxAge(viper.GetDuration("password-max-age"))
+	if err = passwordPolicy.Validate(); err != nil {
+		return options, err
+	}
 
 	options = server.
 		DefaultOptions().
@@ -171,19 +229,42 @@ func parseOptions(cmd *cobra.Command) (options server.Options, err error) {
 		WithNoHistograms(noHistograms).
 		WithDetached(detached).
 		WithCorruptionCheck(consistencyCheck).
+		WithCorruptionCheckerOptions(consistencyCheckerOptions).
+		WithBackupOptions(backupOptions).
+		WithSigningKey(signingKey).
 		WithDevMode(devMode).
 		WithAdminPassword(adminPassword).
-		WithMaintenance(maintenance)
+		WithMaintenance(maintenance).
+		WithReadOnly(readOnly).
+		WithLoginGuardOptions(server.DefaultLoginGuardOptions().
+			WithMaxAttempts(maxLoginAttempts).
+			WithLockoutDuration(loginLockout)).
+		WithPasswordPolicy(passwordPolicy)
 	if mtls {
 		// todo https://golang.org/src/crypto/x509/root_linux.go
 		options.MTLsOptions = server.DefaultMTLsOptions().
 			WithCertificate(certificate).
 			WithPkey(pkey).
-			WithClientCAs(clientcas)
+			WithClientCAs(clientcas).
+			WithIdentityField(mtlsIdentity).
+			WithIdentityMap(mtlsIdentityMap)
 	}
 	return options, nil
 }
 
+// parseIdentityMap parses identity=username entries
+func parseIdentityMap(entries []string) (map[string]string, error) {
+	identityMap := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		i := strings.LastIndex(entry, "=")
+		if i <= 0 || i == len(entry)-1 {
+			return nil, fmt.Errorf("invalid mtls-identity-map entry %s: expected identity=username", entry)
+		}
+		identityMap[entry[:i]] = entry[i+1:]
+	}
+	return identityMap, nil
+}
+
 func setupFlags(cmd *cobra.Command, options server.Options, mtlsOptions server.MTLsOptions) {
 	cmd.Flags().String("dir", options.Dir, "data folder")
 	cmd.Flags().IntP("port", "p", options.Port, "port number")
@@ -195,13 +276,33 @@ func setupFlags(cmd *cobra.Command, options server.Options, mtlsOptions server.M
 	cmd.Flags().BoolP("auth", "s", options.MTLs, "enable auth")
 	cmd.Flags().Bool("no-histograms", options.MTLs, "disable collection of histogram metrics like query durations")
 	cmd.Flags().Bool("consistency-check", options.CorruptionCheck, "enable consistency check monitor routine. To disable: --consistency-check=false")
+	cmd.Flags().String("consistency-check-mode", options.CorruptionCheckerOptions.Mode, "how the consistency checker picks entries: scan (index ranges, resumed after restarts) or sample (random entries)")
+	cmd.Flags().Int("consistency-check-rate", options.CorruptionCheckerOptions.EntriesPerSecond, "maximum number of entries verified per second by the consistency checker (0 means no limit)")
+	cmd.Flags().Uint64("consistency-check-batch", options.CorruptionCheckerOptions.BatchSize, "entries scanned per database in each round of the consistency checker (0 scans up to the current root)")
+	cmd.Flags().Uint64("consistency-check-sample", options.CorruptionCheckerOptions.SampleSize, "random entries verified per database in each round of the consistency checker in sample mode")
+	cmd.Flags().String("signing-key", options.SigningKey, "path of a PEM encoded ed25519 or ECDSA private key used to sign every root returned to clients")
+	cmd.Flags().String("backup-schedule", options.BackupOptions.Schedule, "when the server takes backups: a cron expression like \"30 2 * * *\", @hourly, @daily, @weekly, @monthly or @every <duration> (empty disables scheduled backups)")
+	cmd.Flags().String("backup-dir", options.BackupOptions.Dir, "directory where scheduled backups and their catalog are stored (default .backups in the data dir)")
+	cmd.Flags().StringSlice("backup-databases", nil, "databases included in scheduled backups (default all)")
+	cmd.Flags().Int("backup-retention-count", options.BackupOptions.RetentionCount, "number of scheduled backups kept (0 means no limit)")
+	cmd.Flags().Duration("backup-retention-age", options.BackupOptions.RetentionAge, "how long scheduled backups are kept (0 means no limit)")
 	cmd.Flags().BoolP(c.DetachedFlag, c.DetachedShortFlag, options.Detached, "run immudb in background")
 	cmd.Flags().String("certificate", mtlsOptions.Certificate, "server certificate file path")
 	cmd.Flags().String("pkey", mtlsOptions.Pkey, "server private key path")
 	cmd.Flags().String("clientcas", mtlsOptions.ClientCAs, "clients certificates list. Aka certificate authority")
+	cmd.Flags().String("mtls-identity", mtlsOptions.IdentityField, "authenticate mtls clients without login by mapping their certificate cn or san to an immudb user")
+	cmd.Flags().StringSlice("mtls-identity-map", nil, "certificate identity to immudb username mappings, e.g. spiffe://mesh/ns/app=app_user (unmapped identities are used as usernames)")
 	cmd.Flags().Bool("devmode", options.DevMode, "enable dev mode: accept remote connections without auth")
 	cmd.Flags().String("admin-password", options.AdminPassword, "admin password (default is 'immu') as plain-text or base64 encoded (must be prefixed with 'enc:' if it is encoded)")
 	cmd.Flags().Bool("maintenance", options.GetMaintenance(), "override the authentication flag")
+	cmd.Flags().Bool("readonly", options.GetReadOnly(), "refuse writes to every database, user management keeps working")
+	cmd.Flags().Int("max-login-attempts", options.LoginGuardOptions.MaxAttempts, "consecutive failed logins after which a user or a client address are locked out (0 disables the lockout)")
+	cmd.Flags().Duration("login-lockout", options.LoginGuardOptions.LockoutDuration, "how long a user or a client address stay locked out after too many failed logins")
+	cmd.Flags().Int("password-min-length", options.PasswordPolicy.MinLength, "minimum length of user passwords")
+	cmd.Flags().Int("password-max-length", options.PasswordPolicy.MaxLength, "maximum length of user passwords, at most 72")
+	cmd.Flags().StringSlice("password-char-classes", options.PasswordPolicy.CharClasses, "character classes required in user passwords, any of upper,lower,digit,special")
+	cmd.Flags().Int("password-history", options.PasswordPolicy.HistoryDepth, "number of most recent passwords a user can not reuse (0 allows reuse)")
+	cmd.Flags().Duration("password-max-age", options.PasswordPolicy.MaxAge, "how long a password stays valid before it must be changed, e.g. 2160h (0 never expires)")
 }
 
 func bindFlags(cmd *cobra.Command) error {
@@ -247,6 +348,12 @@ func bindFlags(cmd *cobra.Command) error {
 	if err := viper.BindPFlag("clientcas", cmd.Flags().Lookup("clientcas")); err != nil {
 		return err
 	}
+	if err := viper.BindPFlag("mtls-identity", cmd.Flags().Lookup("mtls-identity")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("mtls-identity-map", cmd.Flags().Lookup("mtls-identity-map")); err != nil {
+		return err
+	}
 	if err := viper.BindPFlag("devmode", cmd.Flags().Lookup("devmode")); err != nil {
 		return err
 	}
@@ -256,6 +363,60 @@ func bindFlags(cmd *cobra.Command) error {
 	if err := viper.BindPFlag("maintenance", cmd.Flags().Lookup("maintenance")); err != nil {
 		return err
 	}
+	if err := viper.BindPFlag("consistency-check-mode", cmd.Flags().Lookup("consistency-check-mode")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("consistency-check-rate", cmd.Flags().Lookup("consistency-check-rate")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("consistency-check-batch", cmd.Flags().Lookup("consistency-check-batch")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("consistency-check-sample", cmd.Flags().Lookup("consistency-check-sample")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("readonly", cmd.Flags().Lookup("readonly")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("signing-key", cmd.Flags().Lookup("signing-key")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("backup-schedule", cmd.Flags().Lookup("backup-schedule")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("backup-dir", cmd.Flags().Lookup("backup-dir")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("backup-databases", cmd.Flags().Lookup("backup-databases")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("backup-retention-count", cmd.Flags().Lookup("backup-retention-count")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("backup-retention-age", cmd.Flags().Lookup("backup-retention-age")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("max-login-attempts", cmd.Flags().Lookup("max-login-attempts")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("login-lockout", cmd.Flags().Lookup("login-lockout")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("password-min-length", cmd.Flags().Lookup("password-min-length")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("password-max-length", cmd.Flags().Lookup("password-max-length")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("password-char-classes", cmd.Flags().Lookup("password-char-classes")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("password-history", cmd.Flags().Lookup("password-history")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("password-max-age", cmd.Flags().Lookup("password-max-age")); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -269,11 +430,29 @@ func setupDefaults(options server.Options, mtlsOptions server.MTLsOptions) {
 	viper.SetDefault("auth", options.GetAuth())
 	viper.SetDefault("no-histograms", options.NoHistograms)
 	viper.SetDefault("consistency-check", options.CorruptionCheck)
+	viper.SetDefault("consistency-check-mode", options.CorruptionCheckerOptions.Mode)
+	viper.SetDefault("consistency-check-rate", options.CorruptionCheckerOptions.EntriesPerSecond)
+	viper.SetDefault("consistency-check-batch", options.CorruptionCheckerOptions.BatchSize)
+	viper.SetDefault("consistency-check-sample", options.CorruptionCheckerOptions.SampleSize)
+	viper.SetDefault("signing-key", options.SigningKey)
+	viper.SetDefault("backup-schedule", options.BackupOptions.Schedule)
+	viper.SetDefault("backup-dir", options.BackupOptions.Dir)
+	viper.SetDefault("backup-retention-count", options.BackupOptions.RetentionCount)
+	viper.SetDefault("backup-retention-age", options.BackupOptions.RetentionAge)
 	viper.SetDefault("detached", options.Detached)
 	viper.SetDefault("certificate", mtlsOptions.Certificate)
 	viper.SetDefault("pkey", mtlsOptions.Pkey)
 	viper.SetDefault("clientcas", mtlsOptions.ClientCAs)
+	viper.SetDefault("mtls-identity", mtlsOptions.IdentityField)
 	viper.SetDefault("devmode", options.DevMode)
 	viper.SetDefault("admin-password", options.AdminPassword)
 	viper.SetDefault("maintenance", options.GetMaintenance())
+	viper.SetDefault("readonly", options.GetReadOnly())
+	viper.SetDefault("max-login-attempts", options.LoginGuardOptions.MaxAttempts)
+	viper.SetDefault("login-lockout", options.LoginGuardOptions.LockoutDuration)
+	viper.SetDefault("password-min-length", options.PasswordPolicy.MinLength)
+	viper.SetDefault("password-max-length", options.PasswordPolicy.MaxLength)
+	viper.SetDefault("password-char-classes", options.PasswordPolicy.CharClasses)
+	viper.SetDefault("password-history", options.PasswordPolicy.HistoryDepth)
+	viper.SetDefault("password-max-age", options.PasswordPolicy.MaxAge)
 }
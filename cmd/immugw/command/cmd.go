@@ -21,6 +21,7 @@ import (
 	c "github.com/codenotary/immudb/cmd/helper"
 	"github.com/codenotary/immudb/cmd/version"
 	"github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/client/auditor"
 	"github.com/codenotary/immudb/pkg/gw"
 	"github.com/codenotary/immudb/pkg/logger"
 	"github.com/spf13/cobra"
@@ -129,6 +130,24 @@ func parseOptions(cmd *cobra.Command) (options gw.Options, err error) {
 	auditInterval := viper.GetDuration("audit-interval")
 	auditUsername := viper.GetString("audit-username")
 	auditPassword := viper.GetString("audit-password")
+	auditAlerts := auditor.DefaultAlertOptions().
+		WithWebhookURL(viper.GetString("audit-alert-webhook")).
+		WithCommand(viper.GetString("audit-alert-command")).
+		WithSyslog(viper.GetBool("audit-alert-syslog")).
+		WithMaxConnectionFailures(viper.GetInt("audit-alert-connection-failures"))
+	auditGossipSigningKey, err := c.ResolvePath(viper.GetString("audit-gossip-signing-key"), true)
+	if err != nil {
+		return options, err
+	}
+	auditGossip := auditor.GossipOptions{}.
+		WithAddress(viper.GetString("audit-gossip-address")).
+		WithSigningKey(auditGossipSigningKey).
+		WithPeers(viper.GetStringSlice("audit-gossip-peers")).
+		WithTrustedKeys(viper.GetStringSlice("audit-gossip-trusted-keys"))
+	serverSigningPubKey, err := c.ResolvePath(viper.GetString("server-signing-pub-key"), true)
+	if err != nil {
+		return options, err
+	}
 	pidfile, err := c.ResolvePath(viper.GetString("pidfile"), true)
 	if err != nil {
 		return options, err
@@ -163,6 +182,10 @@ func parseOptions(cmd *cobra.Command) (options gw.Options, err error) {
 		WithAuditInterval(auditInterval).
 		WithAuditUsername(auditUsername).
 		WithAuditPassword(auditPassword).
+		WithAuditAlerts(auditAlerts).
+		WithAuditGossip(auditGossip).
+		WithAuditSampleSize(viper.GetInt("audit-sample-size")).
+		WithServerSigningPubKey(serverSigningPubKey).
 		WithPidfile(pidfile).
 		WithLogfile(logfile).
 		WithMTLs(mtls).
@@ -189,6 +212,16 @@ func setupFlags(cmd *cobra.Command, options gw.Options, mtlsOptions client.MTLsO
 	cmd.Flags().Duration("audit-interval", options.AuditInterval, "interval at which audit should run")
 	cmd.Flags().String("audit-username", options.AuditUsername, "immudb username used to login during audit")
 	cmd.Flags().String("audit-password", options.AuditPassword, "immudb password used to login during audit; can be plain-text or base64 encoded (must be prefixed with 'enc:' if it is encoded)")
+	cmd.Flags().String("audit-alert-webhook", options.AuditAlerts.WebhookURL, "URL receiving a POST with a JSON payload when the auditor detects tampering, an empty database or repeated connection failures")
+	cmd.Flags().String("audit-alert-command", options.AuditAlerts.Command, "command executed, with the JSON alert on its standard input, when the auditor raises an alert")
+	cmd.Flags().Bool("audit-alert-syslog", options.AuditAlerts.Syslog, "send auditor alerts to the local syslog")
+	cmd.Flags().Int("audit-alert-connection-failures", options.AuditAlerts.MaxConnectionFailures, "number of consecutive audits failing to reach immudb after which an alert is raised (0 disables it)")
+	cmd.Flags().Int("audit-sample-size", options.AuditSampleSize, "number of random entries whose inclusion proof is verified on every audit (0 disables sampling)")
+	cmd.Flags().String("audit-gossip-address", options.AuditGossip.Address, "address the auditor publishes its signed observed roots on, e.g. :9478 (disabled if empty)")
+	cmd.Flags().String("audit-gossip-signing-key", options.AuditGossip.SigningKey, "path of the PEM encoded private key the published observed roots are signed with")
+	cmd.Flags().StringSlice("audit-gossip-peers", options.AuditGossip.Peers, "base URLs of the peer auditors whose observed roots are cross-checked, e.g. http://auditor2:9478")
+	cmd.Flags().StringSlice("audit-gossip-trusted-keys", options.AuditGossip.TrustedKeys, "paths of the PEM encoded public keys the observed roots of peer auditors must be signed with (any key is accepted if empty)")
+	cmd.Flags().String("server-signing-pub-key", options.ServerSigningPubKey, "path of the PEM encoded public key the roots returned by immudb must be signed with")
 	cmd.Flags().String("pidfile", options.Pidfile, "pid path with filename. E.g. /var/run/immugw.pid")
 	cmd.Flags().String("logfile", options.Logfile, "log path with filename. E.g. /tmp/immugw/immugw.log")
 	cmd.Flags().BoolP("mtls", "m", options.MTLs, "enable mutual tls")
@@ -227,6 +260,36 @@ func bindFlags(cmd *cobra.Command) error {
 	if err := viper.BindPFlag("audit-password", cmd.Flags().Lookup("audit-password")); err != nil {
 		return err
 	}
+	if err := viper.BindPFlag("audit-alert-webhook", cmd.Flags().Lookup("audit-alert-webhook")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("audit-alert-command", cmd.Flags().Lookup("audit-alert-command")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("audit-alert-syslog", cmd.Flags().Lookup("audit-alert-syslog")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("audit-alert-connection-failures", cmd.Flags().Lookup("audit-alert-connection-failures")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("audit-sample-size", cmd.Flags().Lookup("audit-sample-size")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("audit-gossip-address", cmd.Flags().Lookup("audit-gossip-address")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("audit-gossip-signing-key", cmd.Flags().Lookup("audit-gossip-signing-key")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("audit-gossip-peers", cmd.Flags().Lookup("audit-gossip-peers")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("audit-gossip-trusted-keys", cmd.Flags().Lookup("audit-gossip-trusted-keys")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("server-signing-pub-key", cmd.Flags().Lookup("server-signing-pub-key")); err != nil {
+		return err
+	}
 	if err := viper.BindPFlag("pidfile", cmd.Flags().Lookup("pidfile")); err != nil {
 		return err
 	}
@@ -264,6 +327,16 @@ func setupDefaults(options gw.Options, mtlsOptions client.MTLsOptions) {
 	viper.SetDefault("audit-interval", options.AuditInterval)
 	viper.SetDefault("audit-username", options.AuditUsername)
 	viper.SetDefault("audit-password", options.AuditPassword)
+	viper.SetDefault("audit-alert-webhook", options.AuditAlerts.WebhookURL)
+	viper.SetDefault("audit-alert-command", options.AuditAlerts.Command)
+	viper.SetDefault("audit-alert-syslog", options.AuditAlerts.Syslog)
+	viper.SetDefault("audit-alert-connection-failures", options.AuditAlerts.MaxConnectionFailures)
+	viper.SetDefault("audit-sample-size", options.AuditSampleSize)
+	viper.SetDefault("audit-gossip-address", options.AuditGossip.Address)
+	viper.SetDefault("audit-gossip-signing-key", options.AuditGossip.SigningKey)
+	viper.SetDefault("audit-gossip-peers", options.AuditGossip.Peers)
+	viper.SetDefault("audit-gossip-trusted-keys", options.AuditGossip.TrustedKeys)
+	viper.SetDefault("server-signing-pub-key", options.ServerSigningPubKey)
 	viper.SetDefault("pidfile", options.Pidfile)
 	viper.SetDefault("logfile", options.Logfile)
 	viper.SetDefault("mtls", options.MTLs)
@@ -180,6 +180,8 @@ type User struct {
 	Createdby            string        `protobuf:"bytes,4,opt,name=createdby,proto3" json:"createdby,omitempty"`
 	Createdat            string        `protobuf:"bytes,5,opt,name=createdat,proto3" json:"createdat,omitempty"`
 	Active               bool          `protobuf:"varint,6,opt,name=active,proto3" json:"active,omitempty"`
+	Roles                []string      `protobuf:"bytes,7,rep,name=roles,proto3" json:"roles,omitempty"`
+	Locked               bool          `protobuf:"varint,8,opt,name=locked,proto3" json:"locked,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
 	XXX_unrecognized     []byte        `json:"-"`
 	XXX_sizecache        int32         `json:"-"`
@@ -252,6 +254,20 @@ func (m *User) GetActive() bool {
 	return false
 }
 
+func (m *User) GetRoles() []string {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+func (m *User) GetLocked() bool {
+	if m != nil {
+		return m.Locked
+	}
+	return false
+}
+
 type UserList struct {
 	Users                []*User  `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1309,19 +1325,67 @@ func (m *StructuredItemList) GetItems() []*StructuredItem {
 	return nil
 }
 
-type Root struct {
-	Index                uint64   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
-	Root                 []byte   `protobuf:"bytes,2,opt,name=root,proto3" json:"root,omitempty"`
+type Signature struct {
+	PublicKey            []byte   `protobuf:"bytes,1,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *Signature) Reset()         { *m = Signature{} }
+func (m *Signature) String() string { return proto.CompactTextString(m) }
+func (*Signature) ProtoMessage()    {}
+func (*Signature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{26}
+}
+
+func (m *Signature) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Signature.Unmarshal(m, b)
+}
+func (m *Signature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Signature.Marshal(b, m, deterministic)
+}
+func (m *Signature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Signature.Merge(m, src)
+}
+func (m *Signature) XXX_Size() int {
+	return xxx_messageInfo_Signature.Size(m)
+}
+func (m *Signature) XXX_DiscardUnknown() {
+	xxx_messageInfo_Signature.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Signature proto.InternalMessageInfo
+
+func (m *Signature) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *Signature) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type Root struct {
+	Index                uint64     `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Root                 []byte     `protobuf:"bytes,2,opt,name=root,proto3" json:"root,omitempty"`
+	Signature            *Signature `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
 func (m *Root) Reset()         { *m = Root{} }
 func (m *Root) String() string { return proto.CompactTextString(m) }
 func (*Root) ProtoMessage()    {}
 func (*Root) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{26}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{27}
 }
 
 func (m *Root) XXX_Unmarshal(b []byte) error {
@@ -1356,6 +1420,13 @@ func (m *Root) GetRoot() []byte {
 	return nil
 }
 
+func (m *Root) GetSignature() *Signature {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 type ScanOptions struct {
 	Prefix               []byte   `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
 	Offset               []byte   `protobuf:"bytes,2,opt,name=offset,proto3" json:"offset,omitempty"`
@@ -1371,7 +1442,7 @@ func (m *ScanOptions) Reset()         { *m = ScanOptions{} }
 func (m *ScanOptions) String() string { return proto.CompactTextString(m) }
 func (*ScanOptions) ProtoMessage()    {}
 func (*ScanOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{27}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{28}
 }
 
 func (m *ScanOptions) XXX_Unmarshal(b []byte) error {
@@ -1438,7 +1509,7 @@ func (m *KeyPrefix) Reset()         { *m = KeyPrefix{} }
 func (m *KeyPrefix) String() string { return proto.CompactTextString(m) }
 func (*KeyPrefix) ProtoMessage()    {}
 func (*KeyPrefix) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{28}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{29}
 }
 
 func (m *KeyPrefix) XXX_Unmarshal(b []byte) error {
@@ -1477,7 +1548,7 @@ func (m *ItemsCount) Reset()         { *m = ItemsCount{} }
 func (m *ItemsCount) String() string { return proto.CompactTextString(m) }
 func (*ItemsCount) ProtoMessage()    {}
 func (*ItemsCount) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{29}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{30}
 }
 
 func (m *ItemsCount) XXX_Unmarshal(b []byte) error {
@@ -1520,7 +1591,7 @@ func (m *InclusionProof) Reset()         { *m = InclusionProof{} }
 func (m *InclusionProof) String() string { return proto.CompactTextString(m) }
 func (*InclusionProof) ProtoMessage()    {}
 func (*InclusionProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{30}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{31}
 }
 
 func (m *InclusionProof) XXX_Unmarshal(b []byte) error {
@@ -1591,7 +1662,7 @@ func (m *ConsistencyProof) Reset()         { *m = ConsistencyProof{} }
 func (m *ConsistencyProof) String() string { return proto.CompactTextString(m) }
 func (*ConsistencyProof) ProtoMessage()    {}
 func (*ConsistencyProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{31}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{32}
 }
 
 func (m *ConsistencyProof) XXX_Unmarshal(b []byte) error {
@@ -1648,22 +1719,23 @@ func (m *ConsistencyProof) GetPath() [][]byte {
 }
 
 type Proof struct {
-	Leaf                 []byte   `protobuf:"bytes,1,opt,name=leaf,proto3" json:"leaf,omitempty"`
-	Index                uint64   `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
-	Root                 []byte   `protobuf:"bytes,3,opt,name=root,proto3" json:"root,omitempty"`
-	At                   uint64   `protobuf:"varint,4,opt,name=at,proto3" json:"at,omitempty"`
-	InclusionPath        [][]byte `protobuf:"bytes,5,rep,name=inclusionPath,proto3" json:"inclusionPath,omitempty"`
-	ConsistencyPath      [][]byte `protobuf:"bytes,6,rep,name=consistencyPath,proto3" json:"consistencyPath,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Leaf                 []byte     `protobuf:"bytes,1,opt,name=leaf,proto3" json:"leaf,omitempty"`
+	Index                uint64     `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Root                 []byte     `protobuf:"bytes,3,opt,name=root,proto3" json:"root,omitempty"`
+	At                   uint64     `protobuf:"varint,4,opt,name=at,proto3" json:"at,omitempty"`
+	InclusionPath        [][]byte   `protobuf:"bytes,5,rep,name=inclusionPath,proto3" json:"inclusionPath,omitempty"`
+	ConsistencyPath      [][]byte   `protobuf:"bytes,6,rep,name=consistencyPath,proto3" json:"consistencyPath,omitempty"`
+	Signature            *Signature `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *Proof) Reset()         { *m = Proof{} }
 func (m *Proof) String() string { return proto.CompactTextString(m) }
 func (*Proof) ProtoMessage()    {}
 func (*Proof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{32}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{33}
 }
 
 func (m *Proof) XXX_Unmarshal(b []byte) error {
@@ -1726,6 +1798,13 @@ func (m *Proof) GetConsistencyPath() [][]byte {
 	return nil
 }
 
+func (m *Proof) GetSignature() *Signature {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 type SafeItem struct {
 	Item                 *Item    `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
 	Proof                *Proof   `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
@@ -1738,7 +1817,7 @@ func (m *SafeItem) Reset()         { *m = SafeItem{} }
 func (m *SafeItem) String() string { return proto.CompactTextString(m) }
 func (*SafeItem) ProtoMessage()    {}
 func (*SafeItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{33}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{34}
 }
 
 func (m *SafeItem) XXX_Unmarshal(b []byte) error {
@@ -1785,7 +1864,7 @@ func (m *SafeStructuredItem) Reset()         { *m = SafeStructuredItem{} }
 func (m *SafeStructuredItem) String() string { return proto.CompactTextString(m) }
 func (*SafeStructuredItem) ProtoMessage()    {}
 func (*SafeStructuredItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{34}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{35}
 }
 
 func (m *SafeStructuredItem) XXX_Unmarshal(b []byte) error {
@@ -1832,7 +1911,7 @@ func (m *SafeSetOptions) Reset()         { *m = SafeSetOptions{} }
 func (m *SafeSetOptions) String() string { return proto.CompactTextString(m) }
 func (*SafeSetOptions) ProtoMessage()    {}
 func (*SafeSetOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{35}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{36}
 }
 
 func (m *SafeSetOptions) XXX_Unmarshal(b []byte) error {
@@ -1879,7 +1958,7 @@ func (m *SafeSetSVOptions) Reset()         { *m = SafeSetSVOptions{} }
 func (m *SafeSetSVOptions) String() string { return proto.CompactTextString(m) }
 func (*SafeSetSVOptions) ProtoMessage()    {}
 func (*SafeSetSVOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{36}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{37}
 }
 
 func (m *SafeSetSVOptions) XXX_Unmarshal(b []byte) error {
@@ -1926,7 +2005,7 @@ func (m *SafeGetOptions) Reset()         { *m = SafeGetOptions{} }
 func (m *SafeGetOptions) String() string { return proto.CompactTextString(m) }
 func (*SafeGetOptions) ProtoMessage()    {}
 func (*SafeGetOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{37}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{38}
 }
 
 func (m *SafeGetOptions) XXX_Unmarshal(b []byte) error {
@@ -1973,7 +2052,7 @@ func (m *SafeReferenceOptions) Reset()         { *m = SafeReferenceOptions{} }
 func (m *SafeReferenceOptions) String() string { return proto.CompactTextString(m) }
 func (*SafeReferenceOptions) ProtoMessage()    {}
 func (*SafeReferenceOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{38}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{39}
 }
 
 func (m *SafeReferenceOptions) XXX_Unmarshal(b []byte) error {
@@ -2009,18 +2088,19 @@ func (m *SafeReferenceOptions) GetRootIndex() *Index {
 }
 
 type HealthResponse struct {
-	Status               bool     `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
-	Version              string   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Status               bool        `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Version              string      `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Tampered             []*Database `protobuf:"bytes,3,rep,name=tampered,proto3" json:"tampered,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *HealthResponse) Reset()         { *m = HealthResponse{} }
 func (m *HealthResponse) String() string { return proto.CompactTextString(m) }
 func (*HealthResponse) ProtoMessage()    {}
 func (*HealthResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{39}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{40}
 }
 
 func (m *HealthResponse) XXX_Unmarshal(b []byte) error {
@@ -2055,6 +2135,13 @@ func (m *HealthResponse) GetVersion() string {
 	return ""
 }
 
+func (m *HealthResponse) GetTampered() []*Database {
+	if m != nil {
+		return m.Tampered
+	}
+	return nil
+}
+
 type ReferenceOptions struct {
 	Reference            []byte   `protobuf:"bytes,1,opt,name=reference,proto3" json:"reference,omitempty"`
 	Key                  []byte   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
@@ -2067,7 +2154,7 @@ func (m *ReferenceOptions) Reset()         { *m = ReferenceOptions{} }
 func (m *ReferenceOptions) String() string { return proto.CompactTextString(m) }
 func (*ReferenceOptions) ProtoMessage()    {}
 func (*ReferenceOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{40}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{41}
 }
 
 func (m *ReferenceOptions) XXX_Unmarshal(b []byte) error {
@@ -2119,7 +2206,7 @@ func (m *ZAddOptions) Reset()         { *m = ZAddOptions{} }
 func (m *ZAddOptions) String() string { return proto.CompactTextString(m) }
 func (*ZAddOptions) ProtoMessage()    {}
 func (*ZAddOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{41}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{42}
 }
 
 func (m *ZAddOptions) XXX_Unmarshal(b []byte) error {
@@ -2175,7 +2262,7 @@ func (m *ZScanOptions) Reset()         { *m = ZScanOptions{} }
 func (m *ZScanOptions) String() string { return proto.CompactTextString(m) }
 func (*ZScanOptions) ProtoMessage()    {}
 func (*ZScanOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{42}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{43}
 }
 
 func (m *ZScanOptions) XXX_Unmarshal(b []byte) error {
@@ -2236,7 +2323,7 @@ func (m *IScanOptions) Reset()         { *m = IScanOptions{} }
 func (m *IScanOptions) String() string { return proto.CompactTextString(m) }
 func (*IScanOptions) ProtoMessage()    {}
 func (*IScanOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{43}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{44}
 }
 
 func (m *IScanOptions) XXX_Unmarshal(b []byte) error {
@@ -2283,7 +2370,7 @@ func (m *Page) Reset()         { *m = Page{} }
 func (m *Page) String() string { return proto.CompactTextString(m) }
 func (*Page) ProtoMessage()    {}
 func (*Page) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{44}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{45}
 }
 
 func (m *Page) XXX_Unmarshal(b []byte) error {
@@ -2331,7 +2418,7 @@ func (m *SPage) Reset()         { *m = SPage{} }
 func (m *SPage) String() string { return proto.CompactTextString(m) }
 func (*SPage) ProtoMessage()    {}
 func (*SPage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{45}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{46}
 }
 
 func (m *SPage) XXX_Unmarshal(b []byte) error {
@@ -2385,7 +2472,7 @@ func (m *SafeZAddOptions) Reset()         { *m = SafeZAddOptions{} }
 func (m *SafeZAddOptions) String() string { return proto.CompactTextString(m) }
 func (*SafeZAddOptions) ProtoMessage()    {}
 func (*SafeZAddOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{46}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{47}
 }
 
 func (m *SafeZAddOptions) XXX_Unmarshal(b []byte) error {
@@ -2432,7 +2519,7 @@ func (m *SafeIndexOptions) Reset()         { *m = SafeIndexOptions{} }
 func (m *SafeIndexOptions) String() string { return proto.CompactTextString(m) }
 func (*SafeIndexOptions) ProtoMessage()    {}
 func (*SafeIndexOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{47}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{48}
 }
 
 func (m *SafeIndexOptions) XXX_Unmarshal(b []byte) error {
@@ -2479,7 +2566,7 @@ func (m *Error) Reset()         { *m = Error{} }
 func (m *Error) String() string { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()    {}
 func (*Error) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{48}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{49}
 }
 
 func (m *Error) XXX_Unmarshal(b []byte) error {
@@ -2515,17 +2602,23 @@ func (m *Error) GetErrormessage() string {
 }
 
 type Database struct {
-	Databasename         string   `protobuf:"bytes,1,opt,name=databasename,proto3" json:"databasename,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Databasename         string            `protobuf:"bytes,1,opt,name=databasename,proto3" json:"databasename,omitempty"`
+	Tampered             bool              `protobuf:"varint,2,opt,name=tampered,proto3" json:"tampered,omitempty"`
+	TamperedIndex        uint64            `protobuf:"varint,3,opt,name=tamperedIndex,proto3" json:"tamperedIndex,omitempty"`
+	Quarantined          bool              `protobuf:"varint,4,opt,name=quarantined,proto3" json:"quarantined,omitempty"`
+	Unloaded             bool              `protobuf:"varint,5,opt,name=unloaded,proto3" json:"unloaded,omitempty"`
+	Settings             *DatabaseSettings `protobuf:"bytes,6,opt,name=settings,proto3" json:"settings,omitempty"`
+	ReadOnly             bool              `protobuf:"varint,7,opt,name=readOnly,proto3" json:"readOnly,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *Database) Reset()         { *m = Database{} }
 func (m *Database) String() string { return proto.CompactTextString(m) }
 func (*Database) ProtoMessage()    {}
 func (*Database) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{49}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{50}
 }
 
 func (m *Database) XXX_Unmarshal(b []byte) error {
@@ -2553,6 +2646,48 @@ func (m *Database) GetDatabasename() string {
 	return ""
 }
 
+func (m *Database) GetTampered() bool {
+	if m != nil {
+		return m.Tampered
+	}
+	return false
+}
+
+func (m *Database) GetTamperedIndex() uint64 {
+	if m != nil {
+		return m.TamperedIndex
+	}
+	return 0
+}
+
+func (m *Database) GetQuarantined() bool {
+	if m != nil {
+		return m.Quarantined
+	}
+	return false
+}
+
+func (m *Database) GetUnloaded() bool {
+	if m != nil {
+		return m.Unloaded
+	}
+	return false
+}
+
+func (m *Database) GetSettings() *DatabaseSettings {
+	if m != nil {
+		return m.Settings
+	}
+	return nil
+}
+
+func (m *Database) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
 type UseDatabaseReply struct {
 	Error                *Error   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
 	Token                string   `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
@@ -2565,7 +2700,7 @@ func (m *UseDatabaseReply) Reset()         { *m = UseDatabaseReply{} }
 func (m *UseDatabaseReply) String() string { return proto.CompactTextString(m) }
 func (*UseDatabaseReply) ProtoMessage()    {}
 func (*UseDatabaseReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{50}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{51}
 }
 
 func (m *UseDatabaseReply) XXX_Unmarshal(b []byte) error {
@@ -2611,7 +2746,7 @@ func (m *CreateDatabaseReply) Reset()         { *m = CreateDatabaseReply{} }
 func (m *CreateDatabaseReply) String() string { return proto.CompactTextString(m) }
 func (*CreateDatabaseReply) ProtoMessage()    {}
 func (*CreateDatabaseReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{51}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{52}
 }
 
 func (m *CreateDatabaseReply) XXX_Unmarshal(b []byte) error {
@@ -2653,7 +2788,7 @@ func (m *ChangePermissionRequest) Reset()         { *m = ChangePermissionRequest
 func (m *ChangePermissionRequest) String() string { return proto.CompactTextString(m) }
 func (*ChangePermissionRequest) ProtoMessage()    {}
 func (*ChangePermissionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{52}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{53}
 }
 
 func (m *ChangePermissionRequest) XXX_Unmarshal(b []byte) error {
@@ -2714,7 +2849,7 @@ func (m *SetActiveUserRequest) Reset()         { *m = SetActiveUserRequest{} }
 func (m *SetActiveUserRequest) String() string { return proto.CompactTextString(m) }
 func (*SetActiveUserRequest) ProtoMessage()    {}
 func (*SetActiveUserRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{53}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{54}
 }
 
 func (m *SetActiveUserRequest) XXX_Unmarshal(b []byte) error {
@@ -2760,7 +2895,7 @@ func (m *DatabaseListResponse) Reset()         { *m = DatabaseListResponse{} }
 func (m *DatabaseListResponse) String() string { return proto.CompactTextString(m) }
 func (*DatabaseListResponse) ProtoMessage()    {}
 func (*DatabaseListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c5fb4d8cc22d66a, []int{54}
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{55}
 }
 
 func (m *DatabaseListResponse) XXX_Unmarshal(b []byte) error {
@@ -2788,287 +2923,1492 @@ func (m *DatabaseListResponse) GetDatabases() []*Database {
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("immudb.schema.ErrorCodes", ErrorCodes_name, ErrorCodes_value)
-	proto.RegisterEnum("immudb.schema.PermissionAction", PermissionAction_name, PermissionAction_value)
-	proto.RegisterType((*Key)(nil), "immudb.schema.Key")
-	proto.RegisterType((*Permission)(nil), "immudb.schema.Permission")
-	proto.RegisterType((*User)(nil), "immudb.schema.User")
-	proto.RegisterType((*UserList)(nil), "immudb.schema.UserList")
-	proto.RegisterType((*CreateUserRequest)(nil), "immudb.schema.CreateUserRequest")
-	proto.RegisterType((*UserRequest)(nil), "immudb.schema.UserRequest")
-	proto.RegisterType((*UserResponse)(nil), "immudb.schema.UserResponse")
-	proto.RegisterType((*ChangePasswordRequest)(nil), "immudb.schema.ChangePasswordRequest")
-	proto.RegisterType((*LoginRequest)(nil), "immudb.schema.LoginRequest")
-	proto.RegisterType((*LoginResponse)(nil), "immudb.schema.LoginResponse")
-	proto.RegisterType((*AuthConfig)(nil), "immudb.schema.AuthConfig")
-	proto.RegisterType((*MTLSConfig)(nil), "immudb.schema.MTLSConfig")
-	proto.RegisterType((*Node)(nil), "immudb.schema.Node")
-	proto.RegisterType((*Layer)(nil), "immudb.schema.Layer")
-	proto.RegisterType((*Tree)(nil), "immudb.schema.Tree")
-	proto.RegisterType((*KeyValue)(nil), "immudb.schema.KeyValue")
-	proto.RegisterType((*StructuredKeyValue)(nil), "immudb.schema.StructuredKeyValue")
-	proto.RegisterType((*Content)(nil), "immudb.schema.Content")
-	proto.RegisterType((*Index)(nil), "immudb.schema.Index")
-	proto.RegisterType((*Item)(nil), "immudb.schema.Item")
-	proto.RegisterType((*StructuredItem)(nil), "immudb.schema.StructuredItem")
-	proto.RegisterType((*KVList)(nil), "immudb.schema.KVList")
-	proto.RegisterType((*SKVList)(nil), "immudb.schema.SKVList")
-	proto.RegisterType((*KeyList)(nil), "immudb.schema.KeyList")
-	proto.RegisterType((*ItemList)(nil), "immudb.schema.ItemList")
-	proto.RegisterType((*StructuredItemList)(nil), "immudb.schema.StructuredItemList")
-	proto.RegisterType((*Root)(nil), "immudb.schema.Root")
-	proto.RegisterType((*ScanOptions)(nil), "immudb.schema.ScanOptions")
-	proto.RegisterType((*KeyPrefix)(nil), "immudb.schema.KeyPrefix")
-	proto.RegisterType((*ItemsCount)(nil), "immudb.schema.ItemsCount")
-	proto.RegisterType((*InclusionProof)(nil), "immudb.schema.InclusionProof")
-	proto.RegisterType((*ConsistencyProof)(nil), "immudb.schema.ConsistencyProof")
-	proto.RegisterType((*Proof)(nil), "immudb.schema.Proof")
-	proto.RegisterType((*SafeItem)(nil), "immudb.schema.SafeItem")
-	proto.RegisterType((*SafeStructuredItem)(nil), "immudb.schema.SafeStructuredItem")
-	proto.RegisterType((*SafeSetOptions)(nil), "immudb.schema.SafeSetOptions")
-	proto.RegisterType((*SafeSetSVOptions)(nil), "immudb.schema.SafeSetSVOptions")
-	proto.RegisterType((*SafeGetOptions)(nil), "immudb.schema.SafeGetOptions")
-	proto.RegisterType((*SafeReferenceOptions)(nil), "immudb.schema.SafeReferenceOptions")
-	proto.RegisterType((*HealthResponse)(nil), "immudb.schema.HealthResponse")
-	proto.RegisterType((*ReferenceOptions)(nil), "immudb.schema.ReferenceOptions")
-	proto.RegisterType((*ZAddOptions)(nil), "immudb.schema.ZAddOptions")
-	proto.RegisterType((*ZScanOptions)(nil), "immudb.schema.ZScanOptions")
-	proto.RegisterType((*IScanOptions)(nil), "immudb.schema.IScanOptions")
-	proto.RegisterType((*Page)(nil), "immudb.schema.Page")
-	proto.RegisterType((*SPage)(nil), "immudb.schema.SPage")
-	proto.RegisterType((*SafeZAddOptions)(nil), "immudb.schema.SafeZAddOptions")
-	proto.RegisterType((*SafeIndexOptions)(nil), "immudb.schema.SafeIndexOptions")
-	proto.RegisterType((*Error)(nil), "immudb.schema.Error")
-	proto.RegisterType((*Database)(nil), "immudb.schema.Database")
-	proto.RegisterType((*UseDatabaseReply)(nil), "immudb.schema.UseDatabaseReply")
-	proto.RegisterType((*CreateDatabaseReply)(nil), "immudb.schema.CreateDatabaseReply")
-	proto.RegisterType((*ChangePermissionRequest)(nil), "immudb.schema.ChangePermissionRequest")
-	proto.RegisterType((*SetActiveUserRequest)(nil), "immudb.schema.SetActiveUserRequest")
-	proto.RegisterType((*DatabaseListResponse)(nil), "immudb.schema.DatabaseListResponse")
+type RenameDatabaseRequest struct {
+	Databasename         string   `protobuf:"bytes,1,opt,name=databasename,proto3" json:"databasename,omitempty"`
+	NewDatabasename      string   `protobuf:"bytes,2,opt,name=newDatabasename,proto3" json:"newDatabasename,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("schema.proto", fileDescriptor_1c5fb4d8cc22d66a) }
+func (m *RenameDatabaseRequest) Reset()         { *m = RenameDatabaseRequest{} }
+func (m *RenameDatabaseRequest) String() string { return proto.CompactTextString(m) }
+func (*RenameDatabaseRequest) ProtoMessage()    {}
+func (*RenameDatabaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{56}
+}
 
-var fileDescriptor_1c5fb4d8cc22d66a = []byte{
-	// 3066 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x4b, 0x53, 0x23, 0xc9,
-	0xb5, 0xa6, 0xf4, 0x00, 0x74, 0x78, 0x8c, 0x6e, 0x4e, 0x4f, 0xa3, 0x51, 0xd3, 0x83, 0xc8, 0x7e,
-	0xd1, 0x0c, 0x8d, 0xfa, 0x31, 0x73, 0xe7, 0x46, 0x5f, 0x82, 0x7b, 0x05, 0xc8, 0xb4, 0x06, 0x1a,
-	0x88, 0x92, 0x9a, 0xb1, 0xb1, 0x27, 0x88, 0x92, 0x2a, 0x25, 0xd5, 0x20, 0x55, 0xc9, 0x55, 0x29,
-	0x68, 0x41, 0x74, 0x4c, 0x8c, 0xb7, 0xde, 0x8d, 0xb7, 0xde, 0xda, 0x0b, 0xff, 0x15, 0x2f, 0xbd,
-	0x9b, 0xb5, 0xd7, 0xfe, 0x0d, 0x8e, 0x7c, 0xd4, 0x43, 0xa5, 0x2a, 0x41, 0x13, 0xde, 0x34, 0x95,
-	0x99, 0x27, 0xcf, 0x77, 0xce, 0xc9, 0xcc, 0x93, 0x79, 0xbe, 0x16, 0xcc, 0x3a, 0x8d, 0x36, 0xe9,
-	0x6a, 0xeb, 0x3d, 0xdb, 0xa2, 0x16, 0x9a, 0x33, 0xba, 0xdd, 0xbe, 0x5e, 0x5f, 0x17, 0x9d, 0xf9,
-	0xc5, 0x96, 0x65, 0xb5, 0x3a, 0xa4, 0xa8, 0xf5, 0x8c, 0xa2, 0x66, 0x9a, 0x16, 0xd5, 0xa8, 0x61,
-	0x99, 0x8e, 0x10, 0xce, 0xdf, 0x93, 0xa3, 0xbc, 0x55, 0xef, 0x37, 0x8b, 0xa4, 0xdb, 0xa3, 0x03,
-	0x39, 0xb8, 0xc6, 0xff, 0x34, 0x9e, 0xb5, 0x88, 0xf9, 0xcc, 0xb9, 0xd0, 0x5a, 0x2d, 0x62, 0x17,
-	0xad, 0x1e, 0x9f, 0x1e, 0xa1, 0x6a, 0xa6, 0x57, 0x2f, 0xf6, 0xea, 0xa2, 0x81, 0x17, 0x20, 0xb9,
-	0x47, 0x06, 0x28, 0x0b, 0xc9, 0x33, 0x32, 0xc8, 0x29, 0x05, 0x65, 0x65, 0x56, 0x65, 0x9f, 0xf8,
-	0x0d, 0xc0, 0x11, 0xb1, 0xbb, 0x86, 0xe3, 0x18, 0x96, 0x89, 0xf2, 0x30, 0xad, 0x6b, 0x54, 0xab,
-	0x6b, 0x0e, 0xe1, 0x42, 0x19, 0xd5, 0x6b, 0xa3, 0x2f, 0x00, 0x7a, 0x9e, 0x64, 0x2e, 0x51, 0x50,
-	0x56, 0xe6, 0xd4, 0x40, 0x0f, 0xfe, 0xbb, 0x02, 0xa9, 0x77, 0x0e, 0xb1, 0x11, 0x82, 0x54, 0xdf,
-	0x21, 0xb6, 0x44, 0xe1, 0xdf, 0xd7, 0x4d, 0x46, 0xff, 0x0b, 0x33, 0x7e, 0xcb, 0xc9, 0x25, 0x0b,
-	0xc9, 0x95, 0x99, 0x97, 0x9f, 0xaf, 0x0f, 0x85, 0x6e, 0xdd, 0x37, 0x54, 0x0d, 0x4a, 0xa3, 0x45,
-	0xc8, 0x34, 0x6c, 0xa2, 0x51, 0xa2, 0xd7, 0x07, 0xb9, 0x14, 0x37, 0xdb, 0xef, 0x08, 0x8c, 0x6a,
-	0x34, 0x97, 0x1e, 0x1a, 0xd5, 0x28, 0xba, 0x0b, 0x93, 0x5a, 0x83, 0x1a, 0xe7, 0x24, 0x37, 0x59,
-	0x50, 0x56, 0xa6, 0x55, 0xd9, 0xc2, 0x5f, 0xc3, 0x34, 0x73, 0x66, 0xdf, 0x70, 0x28, 0x7a, 0x0a,
-	0x69, 0xe6, 0x84, 0x93, 0x53, 0xb8, 0x59, 0x9f, 0x86, 0xcc, 0x62, 0x72, 0xaa, 0x90, 0xc0, 0x3f,
-	0xc2, 0x7f, 0x6d, 0x73, 0xdd, 0xbc, 0x93, 0xfc, 0xbe, 0x4f, 0x1c, 0x1a, 0x19, 0x90, 0x3c, 0x4c,
-	0xf7, 0x34, 0xc7, 0xb9, 0xb0, 0x6c, 0x9d, 0x87, 0x63, 0x56, 0xf5, 0xda, 0xa1, 0x60, 0x25, 0x47,
-	0x82, 0x15, 0x5c, 0xa5, 0xd4, 0xf0, 0x2a, 0xe1, 0x65, 0x98, 0xb9, 0x06, 0x1a, 0x6f, 0xc1, 0xac,
-	0x10, 0x71, 0x7a, 0x96, 0xe9, 0x90, 0xdb, 0xac, 0x17, 0xb6, 0xe0, 0xb3, 0xed, 0xb6, 0x66, 0xb6,
-	0xc8, 0x91, 0x34, 0x7a, 0x9c, 0xaf, 0x05, 0x98, 0xb1, 0x3a, 0xfa, 0xd1, 0xb0, 0xbb, 0xc1, 0x2e,
-	0x26, 0x61, 0x92, 0x0b, 0x4f, 0x22, 0x29, 0x24, 0x02, 0x5d, 0x78, 0x13, 0x66, 0xf7, 0xad, 0x96,
-	0x61, 0xde, 0x32, 0xa6, 0xf8, 0xff, 0x60, 0x4e, 0xce, 0x97, 0x5e, 0xdf, 0x81, 0x34, 0xb5, 0xce,
-	0x88, 0x29, 0x35, 0x88, 0x06, 0xca, 0xc1, 0xd4, 0x85, 0x66, 0x9b, 0x86, 0xd9, 0x92, 0x1a, 0xdc,
-	0x26, 0x2e, 0x00, 0x94, 0xfa, 0xb4, 0xbd, 0x6d, 0x99, 0x4d, 0xa3, 0xc5, 0xe0, 0xcf, 0x0c, 0x53,
-	0xe7, 0x93, 0xe7, 0x54, 0xfe, 0x8d, 0x1f, 0x03, 0xbc, 0xad, 0xed, 0x57, 0xa5, 0x44, 0x0e, 0xa6,
-	0x88, 0xa9, 0xd5, 0x3b, 0x44, 0x08, 0x4d, 0xab, 0x6e, 0x13, 0xdb, 0x90, 0x3a, 0xb0, 0x74, 0x82,
-	0x66, 0x41, 0x31, 0x24, 0xba, 0x62, 0xb0, 0x56, 0x5b, 0x62, 0x2a, 0x6d, 0xa6, 0xdf, 0x26, 0xcd,
-	0x33, 0x19, 0x09, 0xfe, 0xcd, 0x0e, 0xaf, 0x4d, 0x9a, 0x7c, 0xc5, 0xa7, 0x55, 0xf6, 0xc9, 0x7c,
-	0x68, 0x68, 0x8d, 0x36, 0xe1, 0xdb, 0x7a, 0x5a, 0x15, 0x0d, 0x3e, 0xd7, 0xb2, 0xa8, 0xdc, 0xd0,
-	0xfc, 0x1b, 0xaf, 0x42, 0x7a, 0x5f, 0x1b, 0x10, 0x1b, 0x2d, 0x83, 0xd2, 0x89, 0xd9, 0xc7, 0xcc,
-	0x28, 0x55, 0xe9, 0xe0, 0x55, 0x48, 0xd5, 0x6c, 0x42, 0x10, 0x06, 0x85, 0x4a, 0xd1, 0x3b, 0x21,
-	0x51, 0xae, 0x4b, 0x55, 0x28, 0x7e, 0x09, 0xd3, 0x7b, 0x64, 0x70, 0xac, 0x75, 0xfa, 0x64, 0x34,
-	0xb9, 0x30, 0xfb, 0xce, 0xd9, 0x90, 0xf4, 0x4b, 0x34, 0x70, 0x0d, 0x50, 0x95, 0xda, 0xfd, 0x06,
-	0xed, 0xdb, 0x44, 0x1f, 0x33, 0x7b, 0x2d, 0x38, 0x7b, 0xe6, 0xe5, 0xdd, 0x90, 0x0d, 0xdb, 0x96,
-	0x49, 0x89, 0x49, 0x5d, 0xad, 0x25, 0x98, 0x92, 0x3d, 0xec, 0xc4, 0x53, 0xa3, 0x4b, 0x1c, 0xaa,
-	0x75, 0x7b, 0x5c, 0x61, 0x4a, 0xf5, 0x3b, 0xd8, 0xc2, 0xf4, 0xb4, 0x41, 0xc7, 0xd2, 0xdc, 0x4d,
-	0xe2, 0x36, 0xf1, 0x7d, 0x48, 0x57, 0x4c, 0x9d, 0xbc, 0x67, 0x76, 0x1b, 0xec, 0x43, 0x4e, 0x16,
-	0x0d, 0xbc, 0x03, 0xa9, 0x0a, 0x25, 0xdd, 0x9b, 0xfa, 0xe9, 0x6b, 0x49, 0x06, 0xb5, 0x34, 0x61,
-	0xde, 0xf7, 0x3e, 0x46, 0xdf, 0x47, 0x79, 0x1e, 0x83, 0xf3, 0x0a, 0x26, 0xf7, 0x8e, 0x65, 0xfa,
-	0x4a, 0xee, 0x1d, 0xbb, 0xc9, 0x6b, 0x21, 0xa4, 0xcb, 0x8d, 0xbf, 0xca, 0x64, 0xf0, 0xff, 0xc3,
-	0x54, 0x55, 0xce, 0xfa, 0x1a, 0x52, 0x55, 0x7f, 0xda, 0x72, 0x68, 0xda, 0xe8, 0x02, 0xaa, 0x5c,
-	0x1c, 0xbf, 0x80, 0xa9, 0x3d, 0x32, 0xe0, 0x1a, 0x1e, 0x43, 0xea, 0x8c, 0x0c, 0x5c, 0x0d, 0x68,
-	0x14, 0x58, 0xe5, 0xe3, 0x2c, 0xd5, 0xb2, 0x38, 0xb8, 0xa9, 0xd6, 0xa0, 0xa4, 0x1b, 0x97, 0x6a,
-	0x99, 0x9c, 0x2a, 0x24, 0x70, 0x25, 0xb8, 0x8d, 0x3c, 0x05, 0xaf, 0x86, 0x15, 0xdc, 0x8f, 0xb5,
-	0x3b, 0xa8, 0xea, 0x39, 0xa4, 0x54, 0xcb, 0xa2, 0xd1, 0xeb, 0xee, 0x9d, 0xa7, 0x84, 0x3c, 0x8b,
-	0xec, 0x3c, 0xfd, 0xa4, 0xc0, 0x4c, 0xb5, 0xa1, 0x99, 0x87, 0xe2, 0xfa, 0x65, 0xd7, 0x48, 0xcf,
-	0x26, 0x4d, 0xe3, 0xbd, 0x5c, 0x46, 0xd9, 0x62, 0xfd, 0x56, 0xb3, 0xe9, 0x10, 0x77, 0xb6, 0x6c,
-	0x31, 0xa4, 0x8e, 0xd1, 0x35, 0xa8, 0xbb, 0x66, 0xbc, 0xc1, 0xb6, 0xa6, 0x4d, 0xce, 0x89, 0x2d,
-	0xf3, 0xfa, 0xb4, 0xea, 0x36, 0x99, 0x0d, 0x3a, 0x21, 0x3d, 0x79, 0xd0, 0xf9, 0x37, 0x7e, 0x00,
-	0x99, 0x3d, 0x32, 0x38, 0xf2, 0x80, 0xa2, 0x0c, 0xc0, 0x18, 0x80, 0x79, 0xea, 0x6c, 0x5b, 0x7d,
-	0x93, 0xc3, 0x36, 0xd8, 0x87, 0xeb, 0x20, 0x6f, 0x60, 0x1b, 0xe6, 0x2b, 0x66, 0xa3, 0xd3, 0x67,
-	0x99, 0xfd, 0xc8, 0xb6, 0xac, 0x26, 0x9a, 0x87, 0x84, 0xe6, 0x0a, 0x25, 0xb4, 0x40, 0x60, 0x12,
-	0x51, 0x81, 0x49, 0xfa, 0x81, 0x61, 0x7d, 0x1d, 0xa2, 0x89, 0x2c, 0x35, 0xab, 0xf2, 0x6f, 0xd6,
-	0xd7, 0xd3, 0x68, 0x3b, 0x97, 0x2e, 0x24, 0x59, 0x1f, 0xfb, 0xc6, 0x3f, 0x2b, 0x90, 0xdd, 0xb6,
-	0x4c, 0xc7, 0x70, 0x28, 0x31, 0x1b, 0x03, 0x01, 0x7b, 0x07, 0xd2, 0x4d, 0xc3, 0x76, 0x3c, 0xf3,
-	0x78, 0x83, 0xb9, 0xe6, 0x90, 0x86, 0x65, 0xea, 0x12, 0x5d, 0xb6, 0xd8, 0x31, 0xe7, 0x02, 0xaa,
-	0x6f, 0x83, 0xdf, 0xc1, 0x6e, 0x30, 0x21, 0xc7, 0x87, 0x85, 0x39, 0x81, 0x9e, 0x48, 0xa3, 0xfe,
-	0xa2, 0x40, 0x5a, 0x58, 0xe2, 0xba, 0xa1, 0x04, 0xdc, 0xb8, 0x79, 0x10, 0x44, 0xf8, 0x52, 0x5e,
-	0xf8, 0x1e, 0xc2, 0x9c, 0xe1, 0x05, 0xd8, 0x07, 0x1d, 0xee, 0x44, 0x2b, 0xf0, 0x49, 0x23, 0x10,
-	0x11, 0x26, 0x37, 0xc9, 0xe5, 0xc2, 0xdd, 0xf8, 0x14, 0xa6, 0xab, 0x5a, 0x93, 0xf0, 0xec, 0xf1,
-	0x04, 0x52, 0x6c, 0x13, 0x73, 0x4b, 0x63, 0x0e, 0x0c, 0x17, 0x40, 0xab, 0x90, 0xee, 0x31, 0xdf,
-	0x64, 0x52, 0x09, 0xa7, 0x74, 0xee, 0xb7, 0x2a, 0x44, 0xb0, 0x03, 0x88, 0x01, 0x84, 0x12, 0xd5,
-	0x8b, 0x21, 0xa8, 0x6b, 0x8e, 0xd6, 0xc7, 0x83, 0x76, 0x61, 0x9e, 0x83, 0x12, 0xea, 0x9e, 0xaa,
-	0x27, 0x90, 0x38, 0x3b, 0x97, 0x70, 0xb1, 0x89, 0x2b, 0x71, 0x76, 0x8e, 0x5e, 0x42, 0x86, 0x05,
-	0xbe, 0xe2, 0x2d, 0xcf, 0x28, 0x14, 0x1f, 0x53, 0x7d, 0x31, 0x7c, 0x05, 0x59, 0x09, 0x57, 0x3d,
-	0x76, 0x01, 0x5f, 0x41, 0xd2, 0xf1, 0x10, 0x6f, 0x90, 0xf3, 0x98, 0xf4, 0xad, 0xc0, 0x8f, 0x85,
-	0xaf, 0xbb, 0xbe, 0xaf, 0xa3, 0xb7, 0xc0, 0xed, 0x9c, 0xba, 0xc3, 0xf4, 0xaa, 0xa4, 0x49, 0x6c,
-	0x62, 0x36, 0x88, 0xab, 0xbd, 0x08, 0x09, 0xdb, 0x92, 0x7e, 0x2d, 0x85, 0x94, 0x84, 0x85, 0xd5,
-	0x84, 0x6d, 0xdd, 0x0a, 0x7c, 0x0b, 0xe6, 0xdf, 0x10, 0xad, 0x43, 0xdb, 0xde, 0x23, 0x8b, 0x1d,
-	0x5d, 0xaa, 0xd1, 0xbe, 0x23, 0xdf, 0x40, 0xb2, 0xc5, 0x12, 0x1d, 0xcb, 0x6b, 0xee, 0xdb, 0x32,
-	0xa3, 0xba, 0x4d, 0xbc, 0x05, 0xd9, 0x11, 0xe3, 0x17, 0x21, 0x63, 0xbb, 0x7d, 0x32, 0x40, 0x7e,
-	0x87, 0x1b, 0xb8, 0x84, 0x5f, 0xd3, 0xec, 0xc2, 0xcc, 0x49, 0x49, 0xd7, 0x03, 0x91, 0x65, 0x09,
-	0x58, 0x46, 0x56, 0x66, 0x5f, 0xa7, 0x61, 0xd9, 0xe2, 0x7e, 0x55, 0x54, 0xd1, 0x70, 0x15, 0x25,
-	0x7d, 0x45, 0x6d, 0x98, 0x3d, 0x09, 0x66, 0xf9, 0x51, 0x4d, 0xff, 0xa1, 0xfc, 0x8e, 0xbf, 0x85,
-	0xd9, 0x4a, 0x10, 0x89, 0x3f, 0x65, 0x5b, 0xa4, 0x6a, 0x5c, 0x12, 0x99, 0x0c, 0xbd, 0x36, 0x7f,
-	0x9b, 0x6b, 0x2d, 0x72, 0xd0, 0xef, 0xd6, 0x89, 0x2d, 0x93, 0x51, 0xa0, 0x07, 0x97, 0x21, 0x75,
-	0xa4, 0xb5, 0xc8, 0x47, 0xdc, 0xa5, 0x2c, 0x89, 0x75, 0x59, 0x3c, 0x92, 0xe2, 0x7a, 0x61, 0xdf,
-	0xf8, 0x07, 0x48, 0x57, 0xb9, 0x9e, 0xdb, 0x5c, 0xa9, 0xe2, 0x95, 0xc5, 0x4d, 0x92, 0x16, 0xba,
-	0xcd, 0x48, 0xac, 0x0b, 0xf8, 0x84, 0x6d, 0xdb, 0xe0, 0xaa, 0x3d, 0x87, 0xf4, 0xa5, 0xd5, 0xa3,
-	0x8e, 0xdc, 0xb4, 0xf9, 0x10, 0x6a, 0x40, 0x54, 0x15, 0x82, 0xb7, 0xda, 0xb2, 0xbf, 0x13, 0x49,
-	0x80, 0x37, 0x5c, 0xe4, 0xe8, 0x57, 0xc0, 0x6d, 0xb4, 0xeb, 0x90, 0x2e, 0xdb, 0xb6, 0x65, 0xa3,
-	0x6f, 0x20, 0x43, 0xd8, 0x47, 0xc3, 0xd2, 0xc5, 0x7a, 0xce, 0x8f, 0x14, 0xb7, 0x5c, 0x70, 0xdb,
-	0xd2, 0x89, 0xa3, 0xfa, 0xb2, 0x08, 0xc3, 0x2c, 0x6f, 0x74, 0x89, 0xe3, 0x68, 0x2d, 0x22, 0x4f,
-	0xcb, 0x50, 0x1f, 0x5e, 0x87, 0xe9, 0x1d, 0xb7, 0x48, 0xc7, 0x30, 0xeb, 0x96, 0x82, 0xa6, 0xd6,
-	0x75, 0x8b, 0xf8, 0xa1, 0x3e, 0x5c, 0x83, 0xec, 0x3b, 0x87, 0xb8, 0x53, 0x54, 0xd2, 0xeb, 0x0c,
-	0x58, 0x9e, 0xe6, 0x3a, 0x65, 0xb4, 0xef, 0x44, 0x19, 0xa7, 0x0a, 0x11, 0xbf, 0x72, 0x12, 0xc6,
-	0x88, 0x06, 0x2e, 0xc1, 0xa7, 0xa2, 0xf2, 0xbd, 0xb5, 0x62, 0xfc, 0x37, 0x05, 0x16, 0x64, 0x55,
-	0xe9, 0x57, 0xfa, 0xb2, 0xde, 0xfb, 0x46, 0xd4, 0xe9, 0x96, 0x29, 0xc3, 0xb7, 0x14, 0xcb, 0x0d,
-	0x94, 0xb8, 0x98, 0x2a, 0xc5, 0xd9, 0x49, 0x62, 0xc5, 0x21, 0x8f, 0x86, 0x30, 0xd8, 0x6b, 0x0f,
-	0x15, 0xd2, 0xc9, 0xb1, 0x74, 0x47, 0x6a, 0xa4, 0x02, 0xfe, 0x16, 0xee, 0x54, 0x09, 0x2d, 0x71,
-	0xb6, 0x20, 0x58, 0x71, 0xfb, 0x84, 0x82, 0x12, 0x24, 0x14, 0xc6, 0xd9, 0x81, 0xdf, 0xc2, 0x1d,
-	0x37, 0x6a, 0xec, 0x11, 0xeb, 0xa5, 0xcf, 0xaf, 0x21, 0xe3, 0xda, 0x13, 0xf7, 0x7e, 0xf7, 0xa2,
-	0xed, 0x4b, 0xae, 0xfe, 0x59, 0x01, 0xf0, 0xb7, 0x13, 0x9a, 0x84, 0xc4, 0xe1, 0x59, 0x76, 0x02,
-	0x2d, 0x42, 0xae, 0xac, 0xaa, 0x87, 0xea, 0x69, 0xb5, 0xbc, 0x5f, 0xde, 0xae, 0x55, 0x0e, 0x76,
-	0x4f, 0x77, 0x4a, 0xb5, 0xd2, 0x56, 0xa9, 0x5a, 0xce, 0x2a, 0xe8, 0x29, 0x3c, 0x12, 0xa3, 0x07,
-	0x87, 0xa7, 0x47, 0x65, 0xf5, 0x6d, 0xa5, 0x5a, 0xad, 0x1c, 0x1e, 0x9c, 0xfe, 0xea, 0x50, 0x3d,
-	0xad, 0xbd, 0xa9, 0x54, 0x7d, 0xd1, 0x04, 0x2a, 0xc0, 0xa2, 0x10, 0x7d, 0x57, 0x2d, 0xab, 0xa7,
-	0x6f, 0x4a, 0xd5, 0xd3, 0x83, 0xc3, 0xda, 0xe9, 0xfe, 0xe1, 0xee, 0x6e, 0x79, 0xe7, 0xb4, 0x72,
-	0x90, 0x4d, 0xa2, 0x7b, 0xb0, 0x20, 0x24, 0x76, 0xb6, 0x4e, 0x77, 0x0e, 0xcb, 0x42, 0xa0, 0xfc,
-	0xeb, 0x4a, 0xb5, 0x96, 0x4d, 0xad, 0x3e, 0x85, 0x6c, 0x78, 0xb5, 0x50, 0x06, 0xd2, 0xbb, 0x6a,
-	0xe9, 0xa0, 0x96, 0x9d, 0x40, 0x00, 0x93, 0x6a, 0xf9, 0xf8, 0x70, 0xaf, 0x9c, 0x55, 0x5e, 0xfe,
-	0xf5, 0x11, 0xcc, 0x54, 0xba, 0xdd, 0x7e, 0x95, 0xd8, 0xe7, 0x46, 0x83, 0x20, 0x0d, 0x32, 0x2c,
-	0x40, 0x2c, 0xde, 0x0e, 0xba, 0xbb, 0x2e, 0xc8, 0xb2, 0x75, 0x97, 0x2c, 0x5b, 0x2f, 0x77, 0x7b,
-	0x74, 0x90, 0x5f, 0x88, 0xe0, 0x67, 0xd8, 0x2c, 0xfc, 0xe0, 0x0f, 0xff, 0xf8, 0xe7, 0x9f, 0x12,
-	0xf7, 0xd1, 0xbd, 0xe2, 0xf9, 0x8b, 0x22, 0x93, 0xb1, 0x89, 0x43, 0x7b, 0xb6, 0xf5, 0x7e, 0x50,
-	0x64, 0x4b, 0x51, 0xec, 0xb0, 0x02, 0xc2, 0x80, 0xa9, 0x5d, 0xc2, 0x11, 0x50, 0x3e, 0x8a, 0xe8,
-	0x11, 0xcb, 0x9c, 0xbf, 0x17, 0x39, 0x26, 0xd6, 0x0d, 0x3f, 0xe2, 0x40, 0x4b, 0xe8, 0x7e, 0x0c,
-	0xd0, 0x15, 0xfb, 0xf7, 0x03, 0x32, 0x01, 0x7c, 0xb2, 0x08, 0x15, 0xc2, 0x55, 0x5e, 0x98, 0x47,
-	0x1a, 0x8f, 0xb9, 0xcc, 0x31, 0xef, 0xe1, 0xbb, 0xd1, 0x98, 0xaf, 0x95, 0x55, 0xf4, 0x93, 0x02,
-	0xf3, 0xc3, 0xac, 0x0d, 0x7a, 0x18, 0x06, 0x8d, 0x22, 0x75, 0xf2, 0x31, 0x91, 0xc6, 0x2f, 0x38,
-	0xe6, 0x97, 0xf8, 0x71, 0x8c, 0x9f, 0x2e, 0xfb, 0x52, 0x6c, 0x70, 0xb5, 0xcc, 0x06, 0x13, 0xe6,
-	0xaa, 0x84, 0x06, 0x28, 0xc7, 0xa8, 0x6b, 0x29, 0x16, 0xf0, 0x39, 0x07, 0x5c, 0xc5, 0x8f, 0xe2,
-	0x00, 0x3d, 0xbd, 0x45, 0x87, 0x50, 0x86, 0x67, 0xc3, 0xfc, 0x0e, 0xe1, 0x47, 0xd0, 0x8d, 0xf3,
-	0xb8, 0x55, 0x8d, 0xc3, 0x5d, 0xe3, 0xb8, 0x8f, 0xf1, 0x72, 0x0c, 0xae, 0xee, 0x41, 0x30, 0xcc,
-	0x5d, 0xc8, 0xbe, 0xeb, 0xe9, 0x1a, 0x25, 0x01, 0xc2, 0x28, 0x9c, 0xee, 0xfd, 0xa1, 0x58, 0xd0,
-	0x09, 0x5f, 0x51, 0x80, 0x57, 0x0a, 0x2b, 0xf2, 0x87, 0xc6, 0x28, 0x7a, 0x0d, 0x99, 0x23, 0xdb,
-	0x30, 0x29, 0xe7, 0x75, 0xe2, 0xce, 0x4d, 0x78, 0x25, 0x98, 0x30, 0x9e, 0x40, 0x67, 0x90, 0xe6,
-	0xcc, 0x19, 0x0a, 0x6f, 0xbf, 0x20, 0x1f, 0x97, 0x5f, 0x8c, 0x1e, 0x94, 0x9b, 0xf3, 0xc9, 0xcf,
-	0xa5, 0x44, 0x7d, 0x82, 0x07, 0x71, 0x11, 0x2f, 0x8c, 0x06, 0xb1, 0xc3, 0xa4, 0x59, 0xe8, 0xbe,
-	0x87, 0xc9, 0x7d, 0xab, 0x65, 0xf5, 0x69, 0xac, 0x95, 0x71, 0x4e, 0xca, 0xc3, 0x8d, 0x73, 0x91,
-	0xda, 0xad, 0x3e, 0xdf, 0x0d, 0xdf, 0x41, 0xb2, 0x4a, 0x28, 0x8a, 0xab, 0x25, 0xf2, 0x91, 0x37,
-	0xfa, 0xb8, 0xa3, 0xc5, 0x1e, 0x3b, 0x4c, 0xf1, 0x16, 0xa4, 0x79, 0x21, 0x81, 0xae, 0x2f, 0x1a,
-	0x62, 0x40, 0x26, 0x50, 0x13, 0xa6, 0x64, 0x41, 0x82, 0x46, 0xde, 0x58, 0x43, 0x75, 0x51, 0x3e,
-	0xb2, 0x8c, 0xc2, 0x8f, 0xb9, 0x99, 0x05, 0x7c, 0x2f, 0xda, 0xcc, 0xa2, 0xa3, 0x35, 0xf9, 0xf6,
-	0xdc, 0x81, 0x8c, 0x57, 0xf8, 0xa0, 0xa5, 0x68, 0x24, 0xaf, 0x24, 0x8a, 0xc1, 0x9a, 0x40, 0x35,
-	0x48, 0xee, 0x12, 0x8a, 0x22, 0x68, 0x9d, 0x7c, 0xd4, 0x91, 0xc6, 0x0f, 0xb9, 0x75, 0x5f, 0xa0,
-	0xc5, 0x18, 0xeb, 0xae, 0xce, 0xc8, 0xe0, 0x03, 0xda, 0x80, 0xf4, 0x2e, 0xb7, 0x2b, 0x4a, 0xef,
-	0xf8, 0x97, 0x27, 0x9e, 0x40, 0x5d, 0x11, 0xc1, 0xdd, 0x98, 0x08, 0xfa, 0xd5, 0xd6, 0xc8, 0x1d,
-	0xe1, 0x96, 0xd3, 0x78, 0x95, 0x9b, 0xf9, 0x10, 0x2f, 0x8d, 0x09, 0x62, 0xb1, 0x25, 0x72, 0xcb,
-	0xa1, 0x08, 0xa4, 0x30, 0xf8, 0x1a, 0xc0, 0xe5, 0xa8, 0x38, 0x87, 0xed, 0x67, 0x75, 0x3d, 0xa1,
-	0x5b, 0x1a, 0x6d, 0xb4, 0xd1, 0x67, 0xe1, 0x00, 0x70, 0x5a, 0x2e, 0x66, 0xf3, 0x8c, 0x59, 0xfa,
-	0x3a, 0xd3, 0xe6, 0x66, 0xc3, 0x0d, 0x00, 0x17, 0xa0, 0x7a, 0x8c, 0xc2, 0xbc, 0x62, 0x75, 0x2c,
-	0xc6, 0x04, 0x6a, 0xc0, 0xf4, 0xae, 0x6b, 0xde, 0xdd, 0xd1, 0xf5, 0xe1, 0x73, 0x17, 0x22, 0xd6,
-	0x9e, 0x5f, 0xbe, 0xd7, 0x9a, 0x28, 0x83, 0x5a, 0x01, 0xd8, 0x8d, 0x37, 0xd1, 0x85, 0x59, 0x1e,
-	0xbb, 0x15, 0x38, 0x20, 0xb3, 0x37, 0xc5, 0x6a, 0xaa, 0x91, 0x8c, 0x1f, 0x28, 0xb4, 0x6e, 0x65,
-	0xaf, 0xd8, 0x08, 0x0d, 0xcd, 0x14, 0xf6, 0x4e, 0x32, 0x7d, 0xd5, 0xe3, 0xb1, 0x30, 0x37, 0xb2,
-	0xf7, 0x0c, 0xd2, 0x82, 0xa6, 0xcb, 0x8d, 0x7a, 0x2d, 0x68, 0xbe, 0xfc, 0xe7, 0x11, 0xe6, 0x0a,
-	0x6e, 0x0f, 0x3f, 0xe3, 0x06, 0x3f, 0x41, 0x8f, 0x62, 0x0c, 0xe6, 0x5c, 0x5f, 0xf1, 0x4a, 0xf0,
-	0x82, 0x1f, 0xd0, 0x29, 0xcc, 0x6c, 0xf7, 0x6d, 0x9b, 0x98, 0x82, 0x2e, 0xbb, 0xe9, 0xa5, 0xc0,
-	0x84, 0xf1, 0x03, 0x3f, 0x9d, 0xe7, 0x50, 0x44, 0x56, 0xe4, 0x24, 0x98, 0x0d, 0x19, 0x8f, 0x55,
-	0x44, 0x91, 0x5b, 0x6a, 0xe4, 0x40, 0x0f, 0xb3, 0x90, 0xee, 0x6d, 0x8f, 0x56, 0x22, 0x3c, 0x72,
-	0x25, 0x39, 0x75, 0x54, 0xbc, 0xe2, 0x35, 0xda, 0x07, 0xf4, 0x1e, 0x66, 0x02, 0xa4, 0x62, 0x0c,
-	0xea, 0xd2, 0x28, 0x9d, 0x3e, 0x44, 0x43, 0xe2, 0x97, 0x1c, 0x77, 0x0d, 0xad, 0x8e, 0xe2, 0x06,
-	0x98, 0xb8, 0x61, 0xe4, 0x3a, 0x4c, 0x6d, 0x0d, 0xe4, 0xff, 0x1e, 0x44, 0xa2, 0x46, 0x26, 0x45,
-	0xf9, 0xae, 0x40, 0x0f, 0x63, 0xd6, 0x8c, 0x2b, 0xf7, 0x30, 0x2e, 0x61, 0x66, 0x6b, 0xe0, 0x95,
-	0xab, 0x91, 0xa9, 0x3b, 0x58, 0xc8, 0xc6, 0x27, 0x39, 0xf9, 0x6e, 0x43, 0x4f, 0xc7, 0x25, 0xb9,
-	0x61, 0xec, 0x2d, 0xc8, 0x48, 0xff, 0xaa, 0xc7, 0x37, 0x5c, 0xcd, 0x88, 0xf4, 0x36, 0xf5, 0xc6,
-	0x70, 0xa8, 0x65, 0x0f, 0x22, 0xd3, 0x7b, 0xec, 0x51, 0x7c, 0xc2, 0xcd, 0x5d, 0x46, 0x11, 0x39,
-	0xb9, 0x2d, 0xf4, 0xc9, 0xdb, 0x63, 0x07, 0x32, 0x12, 0x20, 0xe6, 0x06, 0xb9, 0xd1, 0x31, 0x34,
-	0x61, 0x52, 0xd0, 0x58, 0xb1, 0x87, 0x22, 0xec, 0xe9, 0x30, 0xeb, 0x85, 0x9f, 0xf9, 0xc7, 0x03,
-	0xa3, 0x42, 0x84, 0xd1, 0x5c, 0xdc, 0x76, 0xab, 0xbc, 0x1f, 0x20, 0xe3, 0x51, 0x5e, 0xe8, 0x3a,
-	0x72, 0xee, 0xe3, 0x2f, 0x00, 0x8f, 0x29, 0x63, 0xd9, 0xea, 0x02, 0xe6, 0x86, 0xf8, 0x41, 0xf4,
-	0x20, 0x62, 0x8f, 0x5c, 0x8b, 0x29, 0x8e, 0xc9, 0x97, 0x1c, 0xf3, 0x11, 0x8e, 0xf0, 0x90, 0x6f,
-	0xa0, 0x21, 0xe0, 0xdf, 0x42, 0xea, 0xa4, 0xa4, 0xeb, 0x68, 0x0c, 0x8f, 0xf3, 0xf1, 0xaf, 0xaf,
-	0x4b, 0x4d, 0xd7, 0x99, 0x72, 0x0d, 0xd2, 0x9c, 0xa7, 0x1b, 0x79, 0xa2, 0x9e, 0xdc, 0x28, 0xd5,
-	0xe3, 0xf8, 0x87, 0xe9, 0xa5, 0x9b, 0xe6, 0xf7, 0x60, 0xea, 0x44, 0xe6, 0xf9, 0xb1, 0x20, 0x37,
-	0xda, 0x61, 0x6d, 0xc1, 0xdf, 0xf3, 0x80, 0x7c, 0x11, 0xb1, 0x00, 0xe3, 0x82, 0x72, 0xed, 0x5b,
-	0x8f, 0xc7, 0xde, 0x8d, 0xcc, 0xf7, 0x90, 0xae, 0x44, 0x46, 0x26, 0xc8, 0x36, 0x8e, 0xe4, 0xa6,
-	0x23, 0xad, 0x45, 0xc6, 0x45, 0xc5, 0x70, 0xa3, 0xb2, 0x09, 0x53, 0x95, 0x98, 0xa8, 0x0c, 0x01,
-	0x84, 0x9d, 0xe0, 0xc4, 0x22, 0x9e, 0x40, 0x87, 0x90, 0xda, 0xe9, 0x77, 0x7b, 0xb1, 0x07, 0x0d,
-	0xd6, 0x7b, 0x75, 0xf9, 0xf2, 0x19, 0xb7, 0x0f, 0xf4, 0x7e, 0xb7, 0xf7, 0x5a, 0x59, 0x7d, 0xae,
-	0xa0, 0x4b, 0x98, 0x1f, 0x66, 0xa1, 0x50, 0x1c, 0x61, 0x92, 0xc7, 0x91, 0xf5, 0xf6, 0x10, 0x7b,
-	0x35, 0x6e, 0x8b, 0x7b, 0x3f, 0x21, 0xe1, 0xe2, 0x2c, 0x18, 0x1f, 0xf8, 0x4f, 0x2f, 0xae, 0x07,
-	0x5e, 0x1a, 0x2d, 0x40, 0x87, 0x51, 0xbf, 0xe2, 0xa8, 0xeb, 0x68, 0x2d, 0xb2, 0xda, 0x74, 0x21,
-	0x8b, 0x57, 0x41, 0x56, 0xef, 0x03, 0xfa, 0x11, 0xb2, 0x61, 0xf2, 0x0c, 0x3d, 0x8e, 0x2e, 0xef,
-	0xc3, 0xec, 0x5a, 0x3e, 0x92, 0x96, 0x73, 0x5f, 0x14, 0x18, 0x47, 0x78, 0xcf, 0x15, 0xf9, 0xe5,
-	0xb6, 0xf0, 0x7f, 0x6e, 0x88, 0x11, 0x1b, 0xcd, 0x2d, 0x11, 0x7c, 0x59, 0x6c, 0x3d, 0x57, 0xe4,
-	0xe0, 0x4f, 0xf1, 0xc3, 0x98, 0x92, 0xdb, 0x21, 0x54, 0xf3, 0x94, 0x31, 0xf8, 0x2b, 0x98, 0x0d,
-	0x92, 0x68, 0xb1, 0x7b, 0xea, 0x41, 0xcc, 0xba, 0x04, 0x99, 0x37, 0xbc, 0xce, 0xd1, 0x57, 0xf0,
-	0x83, 0xb8, 0x82, 0x5f, 0x4e, 0xea, 0x18, 0x0e, 0x7b, 0xb5, 0x6e, 0xfd, 0x31, 0xf9, 0x73, 0xe9,
-	0x97, 0x04, 0xfa, 0x97, 0x02, 0x9f, 0x08, 0xed, 0x05, 0xb5, 0x5c, 0xad, 0x15, 0x4a, 0x47, 0x15,
-	0xf4, 0x8b, 0xb2, 0x51, 0xdf, 0xac, 0xbc, 0x3d, 0x3a, 0x54, 0x6b, 0xa5, 0x83, 0xda, 0x46, 0xb1,
-	0xbe, 0xf9, 0xba, 0x50, 0xea, 0x74, 0x0a, 0x1b, 0x0d, 0x4b, 0x27, 0x9b, 0x2d, 0x42, 0x37, 0x8a,
-	0xfc, 0xab, 0xa0, 0x99, 0xba, 0xec, 0x64, 0x47, 0x38, 0x30, 0xd0, 0xec, 0x9b, 0x9c, 0x25, 0x73,
-	0x0a, 0x36, 0xa1, 0x7d, 0xdb, 0x2c, 0x6c, 0xf4, 0x37, 0x19, 0xf8, 0x7f, 0x7f, 0xf5, 0x8c, 0x98,
-	0x4c, 0x44, 0xdf, 0x28, 0xf6, 0x37, 0x0b, 0x67, 0x64, 0xe0, 0x70, 0x25, 0xfc, 0x67, 0x01, 0xce,
-	0x5a, 0xe1, 0xa2, 0x6d, 0x74, 0x48, 0x41, 0xf3, 0xb0, 0x9c, 0x38, 0x2c, 0x27, 0x0a, 0x8b, 0xbc,
-	0xef, 0x91, 0x06, 0x8d, 0xc1, 0x32, 0xcc, 0x5e, 0x9f, 0x3a, 0xeb, 0x27, 0xbf, 0x81, 0xef, 0x60,
-	0xb2, 0x4e, 0x34, 0x9b, 0xd8, 0xe8, 0xed, 0x74, 0x02, 0xfd, 0x4f, 0xa9, 0x4f, 0xdb, 0xc4, 0xa4,
-	0x46, 0x83, 0xff, 0x16, 0xad, 0xc0, 0xb9, 0xe1, 0xb5, 0x82, 0x78, 0x74, 0x12, 0xbd, 0x50, 0x1f,
-	0x14, 0xb6, 0xb8, 0xf4, 0x6b, 0xf9, 0xb7, 0xb0, 0xc1, 0x45, 0x36, 0xf3, 0x73, 0x6c, 0xa6, 0x65,
-	0x1b, 0x97, 0x62, 0x62, 0xa2, 0x3e, 0x0b, 0xe0, 0xa9, 0x9e, 0x38, 0xf9, 0xb2, 0x65, 0xd0, 0x76,
-	0xbf, 0xbe, 0xde, 0xb0, 0xba, 0xdc, 0x52, 0xd3, 0xa2, 0x9a, 0x3d, 0x28, 0x8a, 0x60, 0x17, 0x7b,
-	0x67, 0x2d, 0xfe, 0x83, 0x3a, 0xb1, 0xa4, 0xf5, 0x49, 0xbe, 0xe4, 0xaf, 0xfe, 0x1d, 0x00, 0x00,
-	0xff, 0xff, 0xe9, 0xc2, 0xcb, 0x57, 0x89, 0x27, 0x00, 0x00,
+func (m *RenameDatabaseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenameDatabaseRequest.Unmarshal(m, b)
+}
+func (m *RenameDatabaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenameDatabaseRequest.Marshal(b, m, deterministic)
+}
+func (m *RenameDatabaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenameDatabaseRequest.Merge(m, src)
+}
+func (m *RenameDatabaseRequest) XXX_Size() int {
+	return xxx_messageInfo_RenameDatabaseRequest.Size(m)
+}
+func (m *RenameDatabaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenameDatabaseRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+var xxx_messageInfo_RenameDatabaseRequest proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *RenameDatabaseRequest) GetDatabasename() string {
+	if m != nil {
+		return m.Databasename
+	}
+	return ""
+}
 
-// ImmuServiceClient is the client API for ImmuService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ImmuServiceClient interface {
-	ListUsers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*UserList, error)
-	GetUser(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*UserResponse, error)
-	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*UserResponse, error)
-	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	SetPermission(ctx context.Context, in *Item, opts ...grpc.CallOption) (*empty.Empty, error)
-	DeactivateUser(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	UpdateAuthConfig(ctx context.Context, in *AuthConfig, opts ...grpc.CallOption) (*empty.Empty, error)
-	UpdateMTLSConfig(ctx context.Context, in *MTLSConfig, opts ...grpc.CallOption) (*empty.Empty, error)
-	PrintTree(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Tree, error)
-	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
-	Logout(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+func (m *RenameDatabaseRequest) GetNewDatabasename() string {
+	if m != nil {
+		return m.NewDatabasename
+	}
+	return ""
+}
+
+type DropDatabaseReply struct {
+	Archive              string   `protobuf:"bytes,1,opt,name=archive,proto3" json:"archive,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DropDatabaseReply) Reset()         { *m = DropDatabaseReply{} }
+func (m *DropDatabaseReply) String() string { return proto.CompactTextString(m) }
+func (*DropDatabaseReply) ProtoMessage()    {}
+func (*DropDatabaseReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{57}
+}
+
+func (m *DropDatabaseReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DropDatabaseReply.Unmarshal(m, b)
+}
+func (m *DropDatabaseReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DropDatabaseReply.Marshal(b, m, deterministic)
+}
+func (m *DropDatabaseReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DropDatabaseReply.Merge(m, src)
+}
+func (m *DropDatabaseReply) XXX_Size() int {
+	return xxx_messageInfo_DropDatabaseReply.Size(m)
+}
+func (m *DropDatabaseReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_DropDatabaseReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DropDatabaseReply proto.InternalMessageInfo
+
+func (m *DropDatabaseReply) GetArchive() string {
+	if m != nil {
+		return m.Archive
+	}
+	return ""
+}
+
+type DatabaseSettings struct {
+	TreeCacheSize           uint64   `protobuf:"varint,1,opt,name=treeCacheSize,proto3" json:"treeCacheSize,omitempty"`
+	SyncWrites              bool     `protobuf:"varint,2,opt,name=syncWrites,proto3" json:"syncWrites,omitempty"`
+	Compression             string   `protobuf:"bytes,3,opt,name=compression,proto3" json:"compression,omitempty"`
+	CorruptionCheckInterval uint32   `protobuf:"varint,4,opt,name=corruptionCheckInterval,proto3" json:"corruptionCheckInterval,omitempty"`
+	ReadOnly                bool     `protobuf:"varint,5,opt,name=readOnly,proto3" json:"readOnly,omitempty"`
+	MaxEntries              uint64   `protobuf:"varint,6,opt,name=maxEntries,proto3" json:"maxEntries,omitempty"`
+	MaxBytes                uint64   `protobuf:"varint,7,opt,name=maxBytes,proto3" json:"maxBytes,omitempty"`
+	MaxWritesPerSecond      uint32   `protobuf:"varint,8,opt,name=maxWritesPerSecond,proto3" json:"maxWritesPerSecond,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
+	XXX_unrecognized        []byte   `json:"-"`
+	XXX_sizecache           int32    `json:"-"`
+}
+
+func (m *DatabaseSettings) Reset()         { *m = DatabaseSettings{} }
+func (m *DatabaseSettings) String() string { return proto.CompactTextString(m) }
+func (*DatabaseSettings) ProtoMessage()    {}
+func (*DatabaseSettings) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{58}
+}
+
+func (m *DatabaseSettings) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DatabaseSettings.Unmarshal(m, b)
+}
+func (m *DatabaseSettings) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DatabaseSettings.Marshal(b, m, deterministic)
+}
+func (m *DatabaseSettings) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DatabaseSettings.Merge(m, src)
+}
+func (m *DatabaseSettings) XXX_Size() int {
+	return xxx_messageInfo_DatabaseSettings.Size(m)
+}
+func (m *DatabaseSettings) XXX_DiscardUnknown() {
+	xxx_messageInfo_DatabaseSettings.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DatabaseSettings proto.InternalMessageInfo
+
+func (m *DatabaseSettings) GetTreeCacheSize() uint64 {
+	if m != nil {
+		return m.TreeCacheSize
+	}
+	return 0
+}
+
+func (m *DatabaseSettings) GetSyncWrites() bool {
+	if m != nil {
+		return m.SyncWrites
+	}
+	return false
+}
+
+func (m *DatabaseSettings) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
+func (m *DatabaseSettings) GetCorruptionCheckInterval() uint32 {
+	if m != nil {
+		return m.CorruptionCheckInterval
+	}
+	return 0
+}
+
+func (m *DatabaseSettings) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+func (m *DatabaseSettings) GetMaxEntries() uint64 {
+	if m != nil {
+		return m.MaxEntries
+	}
+	return 0
+}
+
+func (m *DatabaseSettings) GetMaxBytes() uint64 {
+	if m != nil {
+		return m.MaxBytes
+	}
+	return 0
+}
+
+func (m *DatabaseSettings) GetMaxWritesPerSecond() uint32 {
+	if m != nil {
+		return m.MaxWritesPerSecond
+	}
+	return 0
+}
+
+type UpdateDatabaseSettingsRequest struct {
+	Databasename         string            `protobuf:"bytes,1,opt,name=databasename,proto3" json:"databasename,omitempty"`
+	Settings             *DatabaseSettings `protobuf:"bytes,2,opt,name=settings,proto3" json:"settings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *UpdateDatabaseSettingsRequest) Reset()         { *m = UpdateDatabaseSettingsRequest{} }
+func (m *UpdateDatabaseSettingsRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateDatabaseSettingsRequest) ProtoMessage()    {}
+func (*UpdateDatabaseSettingsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{59}
+}
+
+func (m *UpdateDatabaseSettingsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateDatabaseSettingsRequest.Unmarshal(m, b)
+}
+func (m *UpdateDatabaseSettingsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateDatabaseSettingsRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateDatabaseSettingsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateDatabaseSettingsRequest.Merge(m, src)
+}
+func (m *UpdateDatabaseSettingsRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateDatabaseSettingsRequest.Size(m)
+}
+func (m *UpdateDatabaseSettingsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateDatabaseSettingsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateDatabaseSettingsRequest proto.InternalMessageInfo
+
+func (m *UpdateDatabaseSettingsRequest) GetDatabasename() string {
+	if m != nil {
+		return m.Databasename
+	}
+	return ""
+}
+
+func (m *UpdateDatabaseSettingsRequest) GetSettings() *DatabaseSettings {
+	if m != nil {
+		return m.Settings
+	}
+	return nil
+}
+
+type SetDatabaseReadOnlyRequest struct {
+	Databasename         string   `protobuf:"bytes,1,opt,name=databasename,proto3" json:"databasename,omitempty"`
+	ReadOnly             bool     `protobuf:"varint,2,opt,name=readOnly,proto3" json:"readOnly,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetDatabaseReadOnlyRequest) Reset()         { *m = SetDatabaseReadOnlyRequest{} }
+func (m *SetDatabaseReadOnlyRequest) String() string { return proto.CompactTextString(m) }
+func (*SetDatabaseReadOnlyRequest) ProtoMessage()    {}
+func (*SetDatabaseReadOnlyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{60}
+}
+
+func (m *SetDatabaseReadOnlyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetDatabaseReadOnlyRequest.Unmarshal(m, b)
+}
+func (m *SetDatabaseReadOnlyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetDatabaseReadOnlyRequest.Marshal(b, m, deterministic)
+}
+func (m *SetDatabaseReadOnlyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetDatabaseReadOnlyRequest.Merge(m, src)
+}
+func (m *SetDatabaseReadOnlyRequest) XXX_Size() int {
+	return xxx_messageInfo_SetDatabaseReadOnlyRequest.Size(m)
+}
+func (m *SetDatabaseReadOnlyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetDatabaseReadOnlyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetDatabaseReadOnlyRequest proto.InternalMessageInfo
+
+func (m *SetDatabaseReadOnlyRequest) GetDatabasename() string {
+	if m != nil {
+		return m.Databasename
+	}
+	return ""
+}
+
+func (m *SetDatabaseReadOnlyRequest) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+type ResolveTamperingRequest struct {
+	Databasename         string   `protobuf:"bytes,1,opt,name=databasename,proto3" json:"databasename,omitempty"`
+	Quarantine           bool     `protobuf:"varint,2,opt,name=quarantine,proto3" json:"quarantine,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResolveTamperingRequest) Reset()         { *m = ResolveTamperingRequest{} }
+func (m *ResolveTamperingRequest) String() string { return proto.CompactTextString(m) }
+func (*ResolveTamperingRequest) ProtoMessage()    {}
+func (*ResolveTamperingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{61}
+}
+
+func (m *ResolveTamperingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResolveTamperingRequest.Unmarshal(m, b)
+}
+func (m *ResolveTamperingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResolveTamperingRequest.Marshal(b, m, deterministic)
+}
+func (m *ResolveTamperingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolveTamperingRequest.Merge(m, src)
+}
+func (m *ResolveTamperingRequest) XXX_Size() int {
+	return xxx_messageInfo_ResolveTamperingRequest.Size(m)
+}
+func (m *ResolveTamperingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolveTamperingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResolveTamperingRequest proto.InternalMessageInfo
+
+func (m *ResolveTamperingRequest) GetDatabasename() string {
+	if m != nil {
+		return m.Databasename
+	}
+	return ""
+}
+
+func (m *ResolveTamperingRequest) GetQuarantine() bool {
+	if m != nil {
+		return m.Quarantine
+	}
+	return false
+}
+
+type CorruptionCheckerStatusRequest struct {
+	Databasename         string   `protobuf:"bytes,1,opt,name=databasename,proto3" json:"databasename,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CorruptionCheckerStatusRequest) Reset()         { *m = CorruptionCheckerStatusRequest{} }
+func (m *CorruptionCheckerStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*CorruptionCheckerStatusRequest) ProtoMessage()    {}
+func (*CorruptionCheckerStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{62}
+}
+
+func (m *CorruptionCheckerStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CorruptionCheckerStatusRequest.Unmarshal(m, b)
+}
+func (m *CorruptionCheckerStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CorruptionCheckerStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *CorruptionCheckerStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CorruptionCheckerStatusRequest.Merge(m, src)
+}
+func (m *CorruptionCheckerStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_CorruptionCheckerStatusRequest.Size(m)
+}
+func (m *CorruptionCheckerStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CorruptionCheckerStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CorruptionCheckerStatusRequest proto.InternalMessageInfo
+
+func (m *CorruptionCheckerStatusRequest) GetDatabasename() string {
+	if m != nil {
+		return m.Databasename
+	}
+	return ""
+}
+
+type CorruptionCheckerFailure struct {
+	Index                uint64   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Timestamp            int64    `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CorruptionCheckerFailure) Reset()         { *m = CorruptionCheckerFailure{} }
+func (m *CorruptionCheckerFailure) String() string { return proto.CompactTextString(m) }
+func (*CorruptionCheckerFailure) ProtoMessage()    {}
+func (*CorruptionCheckerFailure) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{63}
+}
+
+func (m *CorruptionCheckerFailure) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CorruptionCheckerFailure.Unmarshal(m, b)
+}
+func (m *CorruptionCheckerFailure) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CorruptionCheckerFailure.Marshal(b, m, deterministic)
+}
+func (m *CorruptionCheckerFailure) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CorruptionCheckerFailure.Merge(m, src)
+}
+func (m *CorruptionCheckerFailure) XXX_Size() int {
+	return xxx_messageInfo_CorruptionCheckerFailure.Size(m)
+}
+func (m *CorruptionCheckerFailure) XXX_DiscardUnknown() {
+	xxx_messageInfo_CorruptionCheckerFailure.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CorruptionCheckerFailure proto.InternalMessageInfo
+
+func (m *CorruptionCheckerFailure) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *CorruptionCheckerFailure) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *CorruptionCheckerFailure) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type DatabaseCorruptionCheckerStatus struct {
+	Databasename         string                      `protobuf:"bytes,1,opt,name=databasename,proto3" json:"databasename,omitempty"`
+	LastVerifiedRoot     *Root                       `protobuf:"bytes,2,opt,name=lastVerifiedRoot,proto3" json:"lastVerifiedRoot,omitempty"`
+	LastRun              int64                       `protobuf:"varint,3,opt,name=lastRun,proto3" json:"lastRun,omitempty"`
+	TotalEntries         uint64                      `protobuf:"varint,4,opt,name=totalEntries,proto3" json:"totalEntries,omitempty"`
+	ScannedEntries       uint64                      `protobuf:"varint,5,opt,name=scannedEntries,proto3" json:"scannedEntries,omitempty"`
+	CompletedScans       uint64                      `protobuf:"varint,6,opt,name=completedScans,proto3" json:"completedScans,omitempty"`
+	SampledEntries       uint64                      `protobuf:"varint,7,opt,name=sampledEntries,proto3" json:"sampledEntries,omitempty"`
+	Failures             []*CorruptionCheckerFailure `protobuf:"bytes,8,rep,name=failures,proto3" json:"failures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *DatabaseCorruptionCheckerStatus) Reset()         { *m = DatabaseCorruptionCheckerStatus{} }
+func (m *DatabaseCorruptionCheckerStatus) String() string { return proto.CompactTextString(m) }
+func (*DatabaseCorruptionCheckerStatus) ProtoMessage()    {}
+func (*DatabaseCorruptionCheckerStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{64}
+}
+
+func (m *DatabaseCorruptionCheckerStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DatabaseCorruptionCheckerStatus.Unmarshal(m, b)
+}
+func (m *DatabaseCorruptionCheckerStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DatabaseCorruptionCheckerStatus.Marshal(b, m, deterministic)
+}
+func (m *DatabaseCorruptionCheckerStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DatabaseCorruptionCheckerStatus.Merge(m, src)
+}
+func (m *DatabaseCorruptionCheckerStatus) XXX_Size() int {
+	return xxx_messageInfo_DatabaseCorruptionCheckerStatus.Size(m)
+}
+func (m *DatabaseCorruptionCheckerStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_DatabaseCorruptionCheckerStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DatabaseCorruptionCheckerStatus proto.InternalMessageInfo
+
+func (m *DatabaseCorruptionCheckerStatus) GetDatabasename() string {
+	if m != nil {
+		return m.Databasename
+	}
+	return ""
+}
+
+func (m *DatabaseCorruptionCheckerStatus) GetLastVerifiedRoot() *Root {
+	if m != nil {
+		return m.LastVerifiedRoot
+	}
+	return nil
+}
+
+func (m *DatabaseCorruptionCheckerStatus) GetLastRun() int64 {
+	if m != nil {
+		return m.LastRun
+	}
+	return 0
+}
+
+func (m *DatabaseCorruptionCheckerStatus) GetTotalEntries() uint64 {
+	if m != nil {
+		return m.TotalEntries
+	}
+	return 0
+}
+
+func (m *DatabaseCorruptionCheckerStatus) GetScannedEntries() uint64 {
+	if m != nil {
+		return m.ScannedEntries
+	}
+	return 0
+}
+
+func (m *DatabaseCorruptionCheckerStatus) GetCompletedScans() uint64 {
+	if m != nil {
+		return m.CompletedScans
+	}
+	return 0
+}
+
+func (m *DatabaseCorruptionCheckerStatus) GetSampledEntries() uint64 {
+	if m != nil {
+		return m.SampledEntries
+	}
+	return 0
+}
+
+func (m *DatabaseCorruptionCheckerStatus) GetFailures() []*CorruptionCheckerFailure {
+	if m != nil {
+		return m.Failures
+	}
+	return nil
+}
+
+type CorruptionCheckerStatusResponse struct {
+	Enabled              bool                               `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Mode                 string                             `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	Databases            []*DatabaseCorruptionCheckerStatus `protobuf:"bytes,3,rep,name=databases,proto3" json:"databases,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *CorruptionCheckerStatusResponse) Reset()         { *m = CorruptionCheckerStatusResponse{} }
+func (m *CorruptionCheckerStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*CorruptionCheckerStatusResponse) ProtoMessage()    {}
+func (*CorruptionCheckerStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{65}
+}
+
+func (m *CorruptionCheckerStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CorruptionCheckerStatusResponse.Unmarshal(m, b)
+}
+func (m *CorruptionCheckerStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CorruptionCheckerStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *CorruptionCheckerStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CorruptionCheckerStatusResponse.Merge(m, src)
+}
+func (m *CorruptionCheckerStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_CorruptionCheckerStatusResponse.Size(m)
+}
+func (m *CorruptionCheckerStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CorruptionCheckerStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CorruptionCheckerStatusResponse proto.InternalMessageInfo
+
+func (m *CorruptionCheckerStatusResponse) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *CorruptionCheckerStatusResponse) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
+func (m *CorruptionCheckerStatusResponse) GetDatabases() []*DatabaseCorruptionCheckerStatus {
+	if m != nil {
+		return m.Databases
+	}
+	return nil
+}
+
+type VerifyDatabaseRequest struct {
+	Databasename         string   `protobuf:"bytes,1,opt,name=databasename,proto3" json:"databasename,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VerifyDatabaseRequest) Reset()         { *m = VerifyDatabaseRequest{} }
+func (m *VerifyDatabaseRequest) String() string { return proto.CompactTextString(m) }
+func (*VerifyDatabaseRequest) ProtoMessage()    {}
+func (*VerifyDatabaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{66}
+}
+
+func (m *VerifyDatabaseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyDatabaseRequest.Unmarshal(m, b)
+}
+func (m *VerifyDatabaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyDatabaseRequest.Marshal(b, m, deterministic)
+}
+func (m *VerifyDatabaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyDatabaseRequest.Merge(m, src)
+}
+func (m *VerifyDatabaseRequest) XXX_Size() int {
+	return xxx_messageInfo_VerifyDatabaseRequest.Size(m)
+}
+func (m *VerifyDatabaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyDatabaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyDatabaseRequest proto.InternalMessageInfo
+
+func (m *VerifyDatabaseRequest) GetDatabasename() string {
+	if m != nil {
+		return m.Databasename
+	}
+	return ""
+}
+
+type TreeMismatch struct {
+	Layer                uint32   `protobuf:"varint,1,opt,name=layer,proto3" json:"layer,omitempty"`
+	Index                uint64   `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Expected             []byte   `protobuf:"bytes,3,opt,name=expected,proto3" json:"expected,omitempty"`
+	Actual               []byte   `protobuf:"bytes,4,opt,name=actual,proto3" json:"actual,omitempty"`
+	Error                string   `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TreeMismatch) Reset()         { *m = TreeMismatch{} }
+func (m *TreeMismatch) String() string { return proto.CompactTextString(m) }
+func (*TreeMismatch) ProtoMessage()    {}
+func (*TreeMismatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{67}
+}
+
+func (m *TreeMismatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TreeMismatch.Unmarshal(m, b)
+}
+func (m *TreeMismatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TreeMismatch.Marshal(b, m, deterministic)
+}
+func (m *TreeMismatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TreeMismatch.Merge(m, src)
+}
+func (m *TreeMismatch) XXX_Size() int {
+	return xxx_messageInfo_TreeMismatch.Size(m)
+}
+func (m *TreeMismatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_TreeMismatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TreeMismatch proto.InternalMessageInfo
+
+func (m *TreeMismatch) GetLayer() uint32 {
+	if m != nil {
+		return m.Layer
+	}
+	return 0
+}
+
+func (m *TreeMismatch) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *TreeMismatch) GetExpected() []byte {
+	if m != nil {
+		return m.Expected
+	}
+	return nil
+}
+
+func (m *TreeMismatch) GetActual() []byte {
+	if m != nil {
+		return m.Actual
+	}
+	return nil
+}
+
+func (m *TreeMismatch) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type VerifyDatabaseProgress struct {
+	Verified             uint64          `protobuf:"varint,1,opt,name=verified,proto3" json:"verified,omitempty"`
+	Total                uint64          `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Mismatches           []*TreeMismatch `protobuf:"bytes,3,rep,name=mismatches,proto3" json:"mismatches,omitempty"`
+	Done                 bool            `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`
+	Consistent           bool            `protobuf:"varint,5,opt,name=consistent,proto3" json:"consistent,omitempty"`
+	Root                 *Root           `protobuf:"bytes,6,opt,name=root,proto3" json:"root,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *VerifyDatabaseProgress) Reset()         { *m = VerifyDatabaseProgress{} }
+func (m *VerifyDatabaseProgress) String() string { return proto.CompactTextString(m) }
+func (*VerifyDatabaseProgress) ProtoMessage()    {}
+func (*VerifyDatabaseProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{68}
+}
+
+func (m *VerifyDatabaseProgress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyDatabaseProgress.Unmarshal(m, b)
+}
+func (m *VerifyDatabaseProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyDatabaseProgress.Marshal(b, m, deterministic)
+}
+func (m *VerifyDatabaseProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyDatabaseProgress.Merge(m, src)
+}
+func (m *VerifyDatabaseProgress) XXX_Size() int {
+	return xxx_messageInfo_VerifyDatabaseProgress.Size(m)
+}
+func (m *VerifyDatabaseProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyDatabaseProgress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyDatabaseProgress proto.InternalMessageInfo
+
+func (m *VerifyDatabaseProgress) GetVerified() uint64 {
+	if m != nil {
+		return m.Verified
+	}
+	return 0
+}
+
+func (m *VerifyDatabaseProgress) GetTotal() uint64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *VerifyDatabaseProgress) GetMismatches() []*TreeMismatch {
+	if m != nil {
+		return m.Mismatches
+	}
+	return nil
+}
+
+func (m *VerifyDatabaseProgress) GetDone() bool {
+	if m != nil {
+		return m.Done
+	}
+	return false
+}
+
+func (m *VerifyDatabaseProgress) GetConsistent() bool {
+	if m != nil {
+		return m.Consistent
+	}
+	return false
+}
+
+func (m *VerifyDatabaseProgress) GetRoot() *Root {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+type BackupRequest struct {
+	Databases            []string `protobuf:"bytes,1,rep,name=databases,proto3" json:"databases,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupRequest) Reset()         { *m = BackupRequest{} }
+func (m *BackupRequest) String() string { return proto.CompactTextString(m) }
+func (*BackupRequest) ProtoMessage()    {}
+func (*BackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{69}
+}
+
+func (m *BackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupRequest.Unmarshal(m, b)
+}
+func (m *BackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupRequest.Marshal(b, m, deterministic)
+}
+func (m *BackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupRequest.Merge(m, src)
+}
+func (m *BackupRequest) XXX_Size() int {
+	return xxx_messageInfo_BackupRequest.Size(m)
+}
+func (m *BackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupRequest proto.InternalMessageInfo
+
+func (m *BackupRequest) GetDatabases() []string {
+	if m != nil {
+		return m.Databases
+	}
+	return nil
+}
+
+type BackupChunk struct {
+	Content              []byte   `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupChunk) Reset()         { *m = BackupChunk{} }
+func (m *BackupChunk) String() string { return proto.CompactTextString(m) }
+func (*BackupChunk) ProtoMessage()    {}
+func (*BackupChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{70}
+}
+
+func (m *BackupChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupChunk.Unmarshal(m, b)
+}
+func (m *BackupChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupChunk.Marshal(b, m, deterministic)
+}
+func (m *BackupChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupChunk.Merge(m, src)
+}
+func (m *BackupChunk) XXX_Size() int {
+	return xxx_messageInfo_BackupChunk.Size(m)
+}
+func (m *BackupChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupChunk proto.InternalMessageInfo
+
+func (m *BackupChunk) GetContent() []byte {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+type Role struct {
+	Name                 string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Permissions          []*Permission `protobuf:"bytes,2,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	Createdby            string        `protobuf:"bytes,3,opt,name=createdby,proto3" json:"createdby,omitempty"`
+	Createdat            string        `protobuf:"bytes,4,opt,name=createdat,proto3" json:"createdat,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Role) Reset()         { *m = Role{} }
+func (m *Role) String() string { return proto.CompactTextString(m) }
+func (*Role) ProtoMessage()    {}
+func (*Role) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{71}
+}
+
+func (m *Role) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Role.Unmarshal(m, b)
+}
+func (m *Role) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Role.Marshal(b, m, deterministic)
+}
+func (m *Role) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Role.Merge(m, src)
+}
+func (m *Role) XXX_Size() int {
+	return xxx_messageInfo_Role.Size(m)
+}
+func (m *Role) XXX_DiscardUnknown() {
+	xxx_messageInfo_Role.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Role proto.InternalMessageInfo
+
+func (m *Role) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Role) GetPermissions() []*Permission {
+	if m != nil {
+		return m.Permissions
+	}
+	return nil
+}
+
+func (m *Role) GetCreatedby() string {
+	if m != nil {
+		return m.Createdby
+	}
+	return ""
+}
+
+func (m *Role) GetCreatedat() string {
+	if m != nil {
+		return m.Createdat
+	}
+	return ""
+}
+
+type RoleList struct {
+	Roles                []*Role  `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RoleList) Reset()         { *m = RoleList{} }
+func (m *RoleList) String() string { return proto.CompactTextString(m) }
+func (*RoleList) ProtoMessage()    {}
+func (*RoleList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{72}
+}
+
+func (m *RoleList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoleList.Unmarshal(m, b)
+}
+func (m *RoleList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoleList.Marshal(b, m, deterministic)
+}
+func (m *RoleList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoleList.Merge(m, src)
+}
+func (m *RoleList) XXX_Size() int {
+	return xxx_messageInfo_RoleList.Size(m)
+}
+func (m *RoleList) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoleList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoleList proto.InternalMessageInfo
+
+func (m *RoleList) GetRoles() []*Role {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+type CreateRoleRequest struct {
+	Name                 string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Permissions          []*Permission `protobuf:"bytes,2,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *CreateRoleRequest) Reset()         { *m = CreateRoleRequest{} }
+func (m *CreateRoleRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRoleRequest) ProtoMessage()    {}
+func (*CreateRoleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{73}
+}
+
+func (m *CreateRoleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRoleRequest.Unmarshal(m, b)
+}
+func (m *CreateRoleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRoleRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateRoleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRoleRequest.Merge(m, src)
+}
+func (m *CreateRoleRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRoleRequest.Size(m)
+}
+func (m *CreateRoleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRoleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRoleRequest proto.InternalMessageInfo
+
+func (m *CreateRoleRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateRoleRequest) GetPermissions() []*Permission {
+	if m != nil {
+		return m.Permissions
+	}
+	return nil
+}
+
+type UpdateRoleRequest struct {
+	Name                 string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Permissions          []*Permission `protobuf:"bytes,2,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *UpdateRoleRequest) Reset()         { *m = UpdateRoleRequest{} }
+func (m *UpdateRoleRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateRoleRequest) ProtoMessage()    {}
+func (*UpdateRoleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{74}
+}
+
+func (m *UpdateRoleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateRoleRequest.Unmarshal(m, b)
+}
+func (m *UpdateRoleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateRoleRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateRoleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRoleRequest.Merge(m, src)
+}
+func (m *UpdateRoleRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateRoleRequest.Size(m)
+}
+func (m *UpdateRoleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRoleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateRoleRequest proto.InternalMessageInfo
+
+func (m *UpdateRoleRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdateRoleRequest) GetPermissions() []*Permission {
+	if m != nil {
+		return m.Permissions
+	}
+	return nil
+}
+
+type DeleteRoleRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRoleRequest) Reset()         { *m = DeleteRoleRequest{} }
+func (m *DeleteRoleRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRoleRequest) ProtoMessage()    {}
+func (*DeleteRoleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{75}
+}
+
+func (m *DeleteRoleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRoleRequest.Unmarshal(m, b)
+}
+func (m *DeleteRoleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRoleRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteRoleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRoleRequest.Merge(m, src)
+}
+func (m *DeleteRoleRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteRoleRequest.Size(m)
+}
+func (m *DeleteRoleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRoleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRoleRequest proto.InternalMessageInfo
+
+func (m *DeleteRoleRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type RoleRequest struct {
+	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Role                 string   `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RoleRequest) Reset()         { *m = RoleRequest{} }
+func (m *RoleRequest) String() string { return proto.CompactTextString(m) }
+func (*RoleRequest) ProtoMessage()    {}
+func (*RoleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{76}
+}
+
+func (m *RoleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoleRequest.Unmarshal(m, b)
+}
+func (m *RoleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoleRequest.Marshal(b, m, deterministic)
+}
+func (m *RoleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoleRequest.Merge(m, src)
+}
+func (m *RoleRequest) XXX_Size() int {
+	return xxx_messageInfo_RoleRequest.Size(m)
+}
+func (m *RoleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoleRequest proto.InternalMessageInfo
+
+func (m *RoleRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *RoleRequest) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("immudb.schema.ErrorCodes", ErrorCodes_name, ErrorCodes_value)
+	proto.RegisterEnum("immudb.schema.PermissionAction", PermissionAction_name, PermissionAction_value)
+	proto.RegisterType((*Key)(nil), "immudb.schema.Key")
+	proto.RegisterType((*Permission)(nil), "immudb.schema.Permission")
+	proto.RegisterType((*User)(nil), "immudb.schema.User")
+	proto.RegisterType((*UserList)(nil), "immudb.schema.UserList")
+	proto.RegisterType((*CreateUserRequest)(nil), "immudb.schema.CreateUserRequest")
+	proto.RegisterType((*UserRequest)(nil), "immudb.schema.UserRequest")
+	proto.RegisterType((*UserResponse)(nil), "immudb.schema.UserResponse")
+	proto.RegisterType((*ChangePasswordRequest)(nil), "immudb.schema.ChangePasswordRequest")
+	proto.RegisterType((*LoginRequest)(nil), "immudb.schema.LoginRequest")
+	proto.RegisterType((*LoginResponse)(nil), "immudb.schema.LoginResponse")
+	proto.RegisterType((*AuthConfig)(nil), "immudb.schema.AuthConfig")
+	proto.RegisterType((*MTLSConfig)(nil), "immudb.schema.MTLSConfig")
+	proto.RegisterType((*Node)(nil), "immudb.schema.Node")
+	proto.RegisterType((*Layer)(nil), "immudb.schema.Layer")
+	proto.RegisterType((*Tree)(nil), "immudb.schema.Tree")
+	proto.RegisterType((*KeyValue)(nil), "immudb.schema.KeyValue")
+	proto.RegisterType((*StructuredKeyValue)(nil), "immudb.schema.StructuredKeyValue")
+	proto.RegisterType((*Content)(nil), "immudb.schema.Content")
+	proto.RegisterType((*Index)(nil), "immudb.schema.Index")
+	proto.RegisterType((*Item)(nil), "immudb.schema.Item")
+	proto.RegisterType((*StructuredItem)(nil), "immudb.schema.StructuredItem")
+	proto.RegisterType((*KVList)(nil), "immudb.schema.KVList")
+	proto.RegisterType((*SKVList)(nil), "immudb.schema.SKVList")
+	proto.RegisterType((*KeyList)(nil), "immudb.schema.KeyList")
+	proto.RegisterType((*ItemList)(nil), "immudb.schema.ItemList")
+	proto.RegisterType((*StructuredItemList)(nil), "immudb.schema.StructuredItemList")
+	proto.RegisterType((*Signature)(nil), "immudb.schema.Signature")
+	proto.RegisterType((*Root)(nil), "immudb.schema.Root")
+	proto.RegisterType((*ScanOptions)(nil), "immudb.schema.ScanOptions")
+	proto.RegisterType((*KeyPrefix)(nil), "immudb.schema.KeyPrefix")
+	proto.RegisterType((*ItemsCount)(nil), "immudb.schema.ItemsCount")
+	proto.RegisterType((*InclusionProof)(nil), "immudb.schema.InclusionProof")
+	proto.RegisterType((*ConsistencyProof)(nil), "immudb.schema.ConsistencyProof")
+	proto.RegisterType((*Proof)(nil), "immudb.schema.Proof")
+	proto.RegisterType((*SafeItem)(nil), "immudb.schema.SafeItem")
+	proto.RegisterType((*SafeStructuredItem)(nil), "immudb.schema.SafeStructuredItem")
+	proto.RegisterType((*SafeSetOptions)(nil), "immudb.schema.SafeSetOptions")
+	proto.RegisterType((*SafeSetSVOptions)(nil), "immudb.schema.SafeSetSVOptions")
+	proto.RegisterType((*SafeGetOptions)(nil), "immudb.schema.SafeGetOptions")
+	proto.RegisterType((*SafeReferenceOptions)(nil), "immudb.schema.SafeReferenceOptions")
+	proto.RegisterType((*HealthResponse)(nil), "immudb.schema.HealthResponse")
+	proto.RegisterType((*ReferenceOptions)(nil), "immudb.schema.ReferenceOptions")
+	proto.RegisterType((*ZAddOptions)(nil), "immudb.schema.ZAddOptions")
+	proto.RegisterType((*ZScanOptions)(nil), "immudb.schema.ZScanOptions")
+	proto.RegisterType((*IScanOptions)(nil), "immudb.schema.IScanOptions")
+	proto.RegisterType((*Page)(nil), "immudb.schema.Page")
+	proto.RegisterType((*SPage)(nil), "immudb.schema.SPage")
+	proto.RegisterType((*SafeZAddOptions)(nil), "immudb.schema.SafeZAddOptions")
+	proto.RegisterType((*SafeIndexOptions)(nil), "immudb.schema.SafeIndexOptions")
+	proto.RegisterType((*Error)(nil), "immudb.schema.Error")
+	proto.RegisterType((*Database)(nil), "immudb.schema.Database")
+	proto.RegisterType((*UseDatabaseReply)(nil), "immudb.schema.UseDatabaseReply")
+	proto.RegisterType((*CreateDatabaseReply)(nil), "immudb.schema.CreateDatabaseReply")
+	proto.RegisterType((*ChangePermissionRequest)(nil), "immudb.schema.ChangePermissionRequest")
+	proto.RegisterType((*SetActiveUserRequest)(nil), "immudb.schema.SetActiveUserRequest")
+	proto.RegisterType((*DatabaseListResponse)(nil), "immudb.schema.DatabaseListResponse")
+	proto.RegisterType((*RenameDatabaseRequest)(nil), "immudb.schema.RenameDatabaseRequest")
+	proto.RegisterType((*DropDatabaseReply)(nil), "immudb.schema.DropDatabaseReply")
+	proto.RegisterType((*DatabaseSettings)(nil), "immudb.schema.DatabaseSettings")
+	proto.RegisterType((*UpdateDatabaseSettingsRequest)(nil), "immudb.schema.UpdateDatabaseSettingsRequest")
+	proto.RegisterType((*SetDatabaseReadOnlyRequest)(nil), "immudb.schema.SetDatabaseReadOnlyRequest")
+	proto.RegisterType((*ResolveTamperingRequest)(nil), "immudb.schema.ResolveTamperingRequest")
+	proto.RegisterType((*CorruptionCheckerStatusRequest)(nil), "immudb.schema.CorruptionCheckerStatusRequest")
+	proto.RegisterType((*CorruptionCheckerFailure)(nil), "immudb.schema.CorruptionCheckerFailure")
+	proto.RegisterType((*DatabaseCorruptionCheckerStatus)(nil), "immudb.schema.DatabaseCorruptionCheckerStatus")
+	proto.RegisterType((*CorruptionCheckerStatusResponse)(nil), "immudb.schema.CorruptionCheckerStatusResponse")
+	proto.RegisterType((*VerifyDatabaseRequest)(nil), "immudb.schema.VerifyDatabaseRequest")
+	proto.RegisterType((*TreeMismatch)(nil), "immudb.schema.TreeMismatch")
+	proto.RegisterType((*VerifyDatabaseProgress)(nil), "immudb.schema.VerifyDatabaseProgress")
+	proto.RegisterType((*BackupRequest)(nil), "immudb.schema.BackupRequest")
+	proto.RegisterType((*BackupChunk)(nil), "immudb.schema.BackupChunk")
+	proto.RegisterType((*Role)(nil), "immudb.schema.Role")
+	proto.RegisterType((*RoleList)(nil), "immudb.schema.RoleList")
+	proto.RegisterType((*CreateRoleRequest)(nil), "immudb.schema.CreateRoleRequest")
+	proto.RegisterType((*UpdateRoleRequest)(nil), "immudb.schema.UpdateRoleRequest")
+	proto.RegisterType((*DeleteRoleRequest)(nil), "immudb.schema.DeleteRoleRequest")
+	proto.RegisterType((*RoleRequest)(nil), "immudb.schema.RoleRequest")
+}
+
+func init() { proto.RegisterFile("schema.proto", fileDescriptor_1c5fb4d8cc22d66a) }
+
+var fileDescriptor_1c5fb4d8cc22d66a = []byte{
+	// 4157 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5b, 0x4b, 0x73, 0x1b, 0x49,
+	0x72, 0x66, 0xe3, 0x41, 0x02, 0xc9, 0xc7, 0x70, 0x6a, 0x34, 0x22, 0x16, 0xa2, 0x44, 0xa8, 0xf4,
+	0xa2, 0x38, 0x12, 0x31, 0x23, 0xed, 0xec, 0x6e, 0x68, 0x68, 0xce, 0xf2, 0x35, 0x14, 0x96, 0x12,
+	0xc9, 0x68, 0x50, 0x1c, 0x5b, 0xde, 0x09, 0xba, 0xd1, 0x5d, 0x04, 0x7a, 0x01, 0x74, 0x63, 0xbb,
+	0x1b, 0xa4, 0x20, 0x85, 0x62, 0x3d, 0x0e, 0x47, 0xf8, 0xe0, 0xdb, 0xec, 0xd5, 0xfe, 0x01, 0xf6,
+	0x6f, 0xf0, 0xbf, 0xf0, 0x6d, 0x23, 0x7c, 0xdb, 0xb3, 0xef, 0xbe, 0x39, 0xea, 0xd5, 0xef, 0x06,
+	0x1f, 0x5e, 0x5f, 0xa4, 0xce, 0xea, 0xec, 0xfc, 0xb2, 0x32, 0xb3, 0xb2, 0xaa, 0x32, 0x41, 0x98,
+	0x71, 0xf5, 0x0e, 0xe9, 0x6b, 0xab, 0x03, 0xc7, 0xf6, 0x6c, 0x34, 0x6b, 0xf6, 0xfb, 0x43, 0xa3,
+	0xb5, 0xca, 0x07, 0xab, 0x8b, 0x6d, 0xdb, 0x6e, 0xf7, 0x48, 0x5d, 0x1b, 0x98, 0x75, 0xcd, 0xb2,
+	0x6c, 0x4f, 0xf3, 0x4c, 0xdb, 0x72, 0x39, 0x73, 0xf5, 0x96, 0x78, 0xcb, 0xa8, 0xd6, 0xf0, 0xb4,
+	0x4e, 0xfa, 0x03, 0x6f, 0x24, 0x5e, 0x3e, 0x61, 0xff, 0xe9, 0x4f, 0xdb, 0xc4, 0x7a, 0xea, 0x9e,
+	0x6b, 0xed, 0x36, 0x71, 0xea, 0xf6, 0x80, 0x7d, 0x9e, 0x22, 0x6a, 0x7a, 0xd0, 0xaa, 0x0f, 0x5a,
+	0x9c, 0xc0, 0x0b, 0x90, 0xdf, 0x23, 0x23, 0x34, 0x0f, 0xf9, 0x2e, 0x19, 0x55, 0x94, 0x9a, 0xb2,
+	0x3c, 0xa3, 0xd2, 0x47, 0xfc, 0x12, 0xe0, 0x90, 0x38, 0x7d, 0xd3, 0x75, 0x4d, 0xdb, 0x42, 0x55,
+	0x28, 0x19, 0x9a, 0xa7, 0xb5, 0x34, 0x97, 0x30, 0xa6, 0xb2, 0xea, 0xd3, 0xe8, 0x0e, 0xc0, 0xc0,
+	0xe7, 0xac, 0xe4, 0x6a, 0xca, 0xf2, 0xac, 0x1a, 0x1a, 0xc1, 0xff, 0xa3, 0x40, 0xe1, 0x8d, 0x4b,
+	0x1c, 0x84, 0xa0, 0x30, 0x74, 0x89, 0x23, 0x50, 0xd8, 0xf3, 0x45, 0x1f, 0xa3, 0x6f, 0x60, 0x3a,
+	0xa0, 0xdc, 0x4a, 0xbe, 0x96, 0x5f, 0x9e, 0x7e, 0xf6, 0xb3, 0xd5, 0x88, 0xe9, 0x56, 0x03, 0x45,
+	0xd5, 0x30, 0x37, 0x5a, 0x84, 0xb2, 0xee, 0x10, 0xcd, 0x23, 0x46, 0x6b, 0x54, 0x29, 0x30, 0xb5,
+	0x83, 0x81, 0xd0, 0x5b, 0xcd, 0xab, 0x14, 0x23, 0x6f, 0x35, 0x0f, 0xdd, 0x84, 0x49, 0x4d, 0xf7,
+	0xcc, 0x33, 0x52, 0x99, 0xac, 0x29, 0xcb, 0x25, 0x55, 0x50, 0xe8, 0x06, 0x14, 0x1d, 0xbb, 0x47,
+	0xdc, 0xca, 0x54, 0x2d, 0xbf, 0x5c, 0x56, 0x39, 0x41, 0xb9, 0x7b, 0xb6, 0xde, 0x25, 0x46, 0xa5,
+	0xc4, 0xb9, 0x39, 0x85, 0xbf, 0x86, 0x12, 0x9d, 0xfa, 0x2b, 0xd3, 0xf5, 0xd0, 0x63, 0x28, 0xd2,
+	0x29, 0xbb, 0x15, 0x85, 0x4d, 0xe2, 0xb3, 0xd8, 0x24, 0x28, 0x9f, 0xca, 0x39, 0xf0, 0x1f, 0xe0,
+	0xd3, 0x2d, 0xa6, 0x09, 0x1b, 0x24, 0xbf, 0x1f, 0x12, 0xd7, 0x4b, 0x35, 0x5f, 0x15, 0x4a, 0x03,
+	0xcd, 0x75, 0xcf, 0x6d, 0xc7, 0x60, 0xc6, 0x9b, 0x51, 0x7d, 0x3a, 0x66, 0xda, 0x7c, 0xc2, 0xb4,
+	0x61, 0x9f, 0x16, 0xa2, 0x3e, 0xc5, 0x77, 0x61, 0xfa, 0x02, 0x68, 0xbc, 0x09, 0x33, 0x9c, 0xc5,
+	0x1d, 0xd8, 0x96, 0x4b, 0xae, 0xe3, 0x5d, 0x6c, 0xc3, 0xe7, 0x5b, 0x1d, 0xcd, 0x6a, 0x93, 0x43,
+	0xa1, 0xf4, 0xb8, 0xb9, 0xd6, 0x60, 0xda, 0xee, 0x19, 0x87, 0xd1, 0xe9, 0x86, 0x87, 0x28, 0x87,
+	0x45, 0xce, 0x7d, 0x8e, 0x3c, 0xe7, 0x08, 0x0d, 0xe1, 0x75, 0x98, 0x79, 0x65, 0xb7, 0x4d, 0xeb,
+	0x9a, 0x36, 0xc5, 0xdf, 0xc2, 0xac, 0xf8, 0x5e, 0xcc, 0xfa, 0x06, 0x14, 0x3d, 0xbb, 0x4b, 0x2c,
+	0x21, 0x81, 0x13, 0xa8, 0x02, 0x53, 0xe7, 0x9a, 0x63, 0x99, 0x56, 0x5b, 0x48, 0x90, 0x24, 0xae,
+	0x01, 0x6c, 0x0c, 0xbd, 0xce, 0x96, 0x6d, 0x9d, 0x9a, 0x6d, 0x0a, 0xdf, 0x35, 0x2d, 0x83, 0x7d,
+	0x3c, 0xab, 0xb2, 0x67, 0xfc, 0x10, 0xe0, 0xf5, 0xd1, 0xab, 0xa6, 0xe0, 0xa8, 0xc0, 0x14, 0xb1,
+	0xb4, 0x56, 0x8f, 0x70, 0xa6, 0x92, 0x2a, 0x49, 0xec, 0x40, 0x61, 0xdf, 0x36, 0x08, 0x9a, 0x01,
+	0xc5, 0x14, 0xe8, 0x8a, 0x49, 0xa9, 0x8e, 0xc0, 0x54, 0x3a, 0x54, 0xbe, 0x43, 0x4e, 0xbb, 0xc2,
+	0x12, 0xec, 0x99, 0x2e, 0x75, 0x87, 0x9c, 0x32, 0x8f, 0x97, 0x54, 0xfa, 0x48, 0xe7, 0xa0, 0x6b,
+	0x7a, 0x87, 0xb0, 0x45, 0x50, 0x52, 0x39, 0xc1, 0xbe, 0xb5, 0x6d, 0x4f, 0x84, 0x3f, 0x7b, 0xc6,
+	0x2b, 0x50, 0x7c, 0xa5, 0x8d, 0x88, 0x83, 0xee, 0x82, 0xd2, 0xcb, 0x88, 0x63, 0xaa, 0x94, 0xaa,
+	0xf4, 0xf0, 0x0a, 0x14, 0x8e, 0x1c, 0x42, 0x10, 0x06, 0xc5, 0x13, 0xac, 0x37, 0x62, 0xac, 0x4c,
+	0x96, 0xaa, 0x78, 0xf8, 0x19, 0x94, 0xf6, 0xc8, 0xe8, 0x58, 0xeb, 0x0d, 0x49, 0x32, 0x15, 0x51,
+	0xfd, 0xce, 0xe8, 0x2b, 0x31, 0x2f, 0x4e, 0xe0, 0x23, 0x40, 0x4d, 0xcf, 0x19, 0xea, 0xde, 0xd0,
+	0x21, 0xc6, 0x98, 0xaf, 0x9f, 0x84, 0xbf, 0x9e, 0x7e, 0x76, 0x33, 0xa6, 0xc3, 0x96, 0x6d, 0x79,
+	0xc4, 0xf2, 0xa4, 0xd4, 0x0d, 0x98, 0x12, 0x23, 0x34, 0x3f, 0x78, 0x66, 0x9f, 0xb8, 0x9e, 0xd6,
+	0x1f, 0x30, 0x81, 0x05, 0x35, 0x18, 0xa0, 0x8e, 0x19, 0x68, 0xa3, 0x9e, 0xad, 0xc9, 0x20, 0x91,
+	0x24, 0xbe, 0x0d, 0xc5, 0x86, 0x65, 0x90, 0x77, 0x54, 0x6f, 0x93, 0x3e, 0x88, 0x8f, 0x39, 0x81,
+	0xb7, 0xa1, 0xd0, 0xf0, 0x48, 0xff, 0xb2, 0xf3, 0x0c, 0xa4, 0xe4, 0xc3, 0x52, 0x4e, 0x61, 0x2e,
+	0x98, 0x7d, 0x86, 0xbc, 0x2b, 0xcd, 0x3c, 0x03, 0xe7, 0x39, 0x4c, 0xee, 0x1d, 0x8b, 0xf4, 0x95,
+	0xdf, 0x3b, 0x96, 0xc9, 0x6b, 0x21, 0x26, 0x4b, 0xda, 0x5f, 0xa5, 0x3c, 0xf8, 0xd7, 0x30, 0xd5,
+	0x14, 0x5f, 0x7d, 0x0d, 0x85, 0x66, 0xf0, 0xd9, 0xdd, 0xd8, 0x67, 0x49, 0x07, 0xaa, 0x8c, 0x1d,
+	0x7f, 0x05, 0x53, 0x7b, 0x64, 0xc4, 0x24, 0x3c, 0x84, 0x42, 0x97, 0x8c, 0xa4, 0x04, 0x94, 0x04,
+	0x56, 0xd9, 0x7b, 0x9a, 0x6a, 0xa9, 0x1d, 0x64, 0xaa, 0x35, 0x3d, 0xd2, 0xcf, 0x4a, 0xb5, 0x94,
+	0x4f, 0xe5, 0x1c, 0xb8, 0x11, 0x0e, 0x23, 0x5f, 0xc0, 0xf3, 0xa8, 0x80, 0xdb, 0x99, 0x7a, 0x87,
+	0x45, 0xed, 0x42, 0xb9, 0x69, 0xb6, 0x2d, 0x8d, 0xbe, 0xa0, 0xd1, 0x33, 0x18, 0xb6, 0x7a, 0xa6,
+	0xbe, 0xe7, 0x3b, 0x25, 0x18, 0xa0, 0x6f, 0x5d, 0xc9, 0x2a, 0xdc, 0x1d, 0x0c, 0xe0, 0x0e, 0x14,
+	0x54, 0xdb, 0xf6, 0xd2, 0x03, 0xc8, 0x5f, 0x98, 0x39, 0xb1, 0xa8, 0x29, 0xe7, 0x2f, 0xc2, 0xf2,
+	0xf2, 0xcc, 0xdd, 0x95, 0xb8, 0xce, 0xf2, 0x7d, 0x18, 0xe9, 0x47, 0x05, 0xa6, 0x9b, 0xba, 0x66,
+	0x1d, 0xf0, 0xd3, 0x02, 0xdd, 0xc7, 0x06, 0x0e, 0x39, 0x35, 0xdf, 0x09, 0x95, 0x05, 0x45, 0xc7,
+	0xed, 0xd3, 0x53, 0x97, 0x48, 0x54, 0x41, 0x51, 0x0d, 0x7b, 0x66, 0xdf, 0xf4, 0x64, 0xd0, 0x30,
+	0x82, 0xae, 0x0d, 0x87, 0x9c, 0x11, 0x47, 0x6c, 0x2c, 0x25, 0x55, 0x92, 0x54, 0x77, 0x83, 0x90,
+	0x81, 0xc8, 0x34, 0xec, 0x19, 0xdf, 0x83, 0xf2, 0x1e, 0x19, 0x1d, 0xfa, 0x40, 0x69, 0x0a, 0x60,
+	0x0c, 0x40, 0x4d, 0xed, 0x6e, 0xd9, 0x43, 0x8b, 0xc1, 0xea, 0xf4, 0x41, 0x1a, 0x86, 0x11, 0xd8,
+	0x81, 0xb9, 0x86, 0xa5, 0xf7, 0x86, 0x74, 0x6b, 0x39, 0x74, 0x6c, 0xfb, 0x14, 0xcd, 0x41, 0x4e,
+	0x93, 0x4c, 0x39, 0x2d, 0x64, 0xd0, 0x5c, 0x9a, 0x41, 0xf3, 0x21, 0x83, 0x22, 0x28, 0xf4, 0x88,
+	0xc6, 0xd3, 0xe4, 0x8c, 0xca, 0x9e, 0xe9, 0xd8, 0x40, 0xf3, 0x3a, 0x95, 0x62, 0x2d, 0x4f, 0xc7,
+	0xe8, 0x33, 0xfe, 0x49, 0x81, 0xf9, 0x2d, 0xdb, 0x72, 0x4d, 0xd7, 0x23, 0x96, 0x3e, 0xe2, 0xb0,
+	0x37, 0xa0, 0x78, 0x6a, 0x3a, 0xae, 0xaf, 0x1e, 0x23, 0xe8, 0xd4, 0x5c, 0xa2, 0xdb, 0x96, 0x21,
+	0xd0, 0x05, 0x45, 0x63, 0x81, 0x31, 0xa8, 0x81, 0x0e, 0xc1, 0x00, 0xdd, 0x42, 0x39, 0x1f, 0x7b,
+	0xcd, 0xd5, 0x09, 0x8d, 0xa4, 0x2a, 0xf5, 0x5f, 0x0a, 0x14, 0xb9, 0x26, 0x72, 0x1a, 0x4a, 0x68,
+	0x1a, 0x97, 0x37, 0x02, 0x37, 0x5f, 0xc1, 0x37, 0xdf, 0x7d, 0x98, 0x35, 0x7d, 0x03, 0x07, 0xa0,
+	0xd1, 0x41, 0xb4, 0x0c, 0x9f, 0xe8, 0x21, 0x8b, 0x50, 0xbe, 0x49, 0xc6, 0x17, 0x1f, 0x8e, 0x46,
+	0xed, 0xd4, 0xe5, 0xa3, 0xf6, 0x04, 0x4a, 0x4d, 0xed, 0x94, 0xb0, 0xb4, 0xf7, 0x08, 0x0a, 0x74,
+	0xf5, 0xb1, 0x19, 0x66, 0xac, 0x74, 0xc6, 0x80, 0x56, 0xa0, 0x38, 0xa0, 0x36, 0x11, 0xd9, 0x30,
+	0xbe, 0x17, 0x31, 0x7b, 0xa9, 0x9c, 0x05, 0xbb, 0x80, 0x28, 0x40, 0x2c, 0xc3, 0x7e, 0x15, 0x81,
+	0xba, 0x20, 0x27, 0x5c, 0x1d, 0xb4, 0x0f, 0x73, 0x0c, 0x94, 0x78, 0x72, 0x35, 0x3e, 0x82, 0x5c,
+	0xf7, 0x4c, 0xc0, 0x65, 0x66, 0xdc, 0x5c, 0xf7, 0x0c, 0x3d, 0x83, 0x32, 0x75, 0x58, 0xc3, 0x77,
+	0x6b, 0x12, 0x8a, 0xbd, 0x53, 0x03, 0x36, 0xfc, 0x01, 0xe6, 0x05, 0x5c, 0xf3, 0x58, 0x02, 0x3e,
+	0x87, 0xbc, 0xeb, 0x23, 0x5e, 0x22, 0x59, 0xe7, 0xdd, 0x6b, 0x82, 0x1f, 0xf3, 0xb9, 0xee, 0x06,
+	0x73, 0x4d, 0x6e, 0x5f, 0xd7, 0x9b, 0xd4, 0x0d, 0x2a, 0x57, 0x25, 0xa7, 0xc4, 0x21, 0x96, 0x4e,
+	0xa4, 0xf4, 0x3a, 0xe4, 0x1c, 0x5b, 0xcc, 0x6b, 0x29, 0x26, 0x24, 0xce, 0xac, 0xe6, 0x1c, 0xfb,
+	0x5a, 0xe0, 0xe7, 0x30, 0xf7, 0x92, 0x68, 0x3d, 0xaf, 0xe3, 0x9f, 0x0e, 0xe9, 0x92, 0xf7, 0x34,
+	0x6f, 0xe8, 0x8a, 0xc3, 0x9b, 0xa0, 0x68, 0x82, 0xa4, 0xf9, 0x50, 0x1e, 0x8a, 0xcb, 0xaa, 0x24,
+	0xd1, 0x73, 0x28, 0xd1, 0xe3, 0x05, 0x71, 0x88, 0x21, 0x2e, 0x3b, 0x71, 0xc7, 0x6f, 0x8b, 0x33,
+	0xba, 0xea, 0x33, 0xe2, 0x4d, 0x98, 0x4f, 0xcc, 0x78, 0x11, 0xca, 0x8e, 0x1c, 0x93, 0xfb, 0x8f,
+	0x3f, 0x20, 0xad, 0x9d, 0x0b, 0xee, 0x7b, 0xbb, 0x30, 0xfd, 0x76, 0xc3, 0x30, 0x42, 0xee, 0xa0,
+	0xd9, 0x5e, 0xb8, 0x43, 0xa4, 0x7a, 0x57, 0xb7, 0xc5, 0x76, 0xa5, 0xa8, 0x9c, 0x90, 0x82, 0xf2,
+	0x81, 0xa0, 0x0e, 0xcc, 0xbc, 0x0d, 0x6f, 0x29, 0x49, 0x49, 0x7f, 0xa1, 0xcd, 0x04, 0xff, 0x06,
+	0x66, 0x1a, 0x61, 0x24, 0x76, 0x70, 0x6f, 0x93, 0xa6, 0xf9, 0x9e, 0x88, 0xcc, 0xeb, 0xd3, 0xec,
+	0x26, 0xa2, 0xb5, 0xc9, 0xfe, 0xb0, 0xdf, 0x22, 0x8e, 0xc8, 0x7c, 0xa1, 0x11, 0xbc, 0x03, 0x85,
+	0x43, 0xad, 0x4d, 0xae, 0x70, 0x72, 0xa0, 0x19, 0xb3, 0x6f, 0x8b, 0xed, 0xb6, 0xa4, 0xb2, 0x67,
+	0xfc, 0x3b, 0x28, 0x36, 0x99, 0x9c, 0xeb, 0x1c, 0x20, 0xf8, 0x99, 0x92, 0xa9, 0x24, 0x34, 0x94,
+	0x64, 0x2a, 0xd6, 0x39, 0x7c, 0x42, 0x63, 0x3d, 0xec, 0xb5, 0x2f, 0xa1, 0xf8, 0xde, 0x1e, 0x78,
+	0xae, 0x88, 0xf4, 0x6a, 0x0c, 0x35, 0xc4, 0xaa, 0x72, 0xc6, 0x6b, 0xc5, 0xf9, 0x6f, 0x79, 0xe6,
+	0x60, 0x84, 0x44, 0x4e, 0x3f, 0xaa, 0x5c, 0x47, 0xba, 0x01, 0xc5, 0x1d, 0xc7, 0xb1, 0x1d, 0xf4,
+	0x4b, 0x28, 0x13, 0xfa, 0xa0, 0xdb, 0x06, 0xf7, 0xe7, 0x5c, 0xe2, 0xe2, 0xcf, 0x18, 0xb7, 0x6c,
+	0x83, 0xb8, 0x6a, 0xc0, 0x8b, 0x30, 0xcc, 0x30, 0xa2, 0x4f, 0x5c, 0x57, 0x6b, 0x13, 0xb1, 0xc4,
+	0x22, 0x63, 0xf8, 0x9f, 0x72, 0x50, 0x92, 0x2b, 0x89, 0x7e, 0x20, 0x6f, 0xbe, 0x96, 0xd6, 0x97,
+	0x15, 0x8e, 0xc8, 0x18, 0x0d, 0x2e, 0x7f, 0x61, 0xe6, 0x98, 0x17, 0x7c, 0x9a, 0xee, 0x8b, 0xf2,
+	0xb9, 0x11, 0x3a, 0x42, 0x47, 0x07, 0xe9, 0xed, 0xf4, 0xf7, 0x43, 0xcd, 0xd1, 0x2c, 0xcf, 0xb4,
+	0x88, 0x21, 0x82, 0x39, 0x3c, 0x44, 0x31, 0x86, 0x16, 0xbd, 0x43, 0x10, 0x43, 0x9c, 0x90, 0x7c,
+	0x1a, 0x7d, 0x03, 0x25, 0x97, 0x78, 0x9e, 0x69, 0xb5, 0xdd, 0xca, 0x64, 0x6a, 0x1e, 0x93, 0xd3,
+	0x69, 0x0a, 0x36, 0xd5, 0xff, 0x80, 0x0a, 0x76, 0x88, 0x66, 0x1c, 0x58, 0xbd, 0x11, 0xdb, 0x67,
+	0x4b, 0xaa, 0x4f, 0xe3, 0x23, 0x98, 0x7f, 0xe3, 0x12, 0x3f, 0xab, 0x90, 0x41, 0x6f, 0x44, 0xb7,
+	0x2d, 0x66, 0xad, 0x8a, 0x92, 0xea, 0x33, 0x66, 0x76, 0x95, 0xb3, 0x04, 0x37, 0x60, 0x6e, 0x66,
+	0x4e, 0xe0, 0x0d, 0xf8, 0x8c, 0x57, 0x30, 0xae, 0x2d, 0x18, 0xff, 0xbb, 0x02, 0x0b, 0xa2, 0x3a,
+	0x10, 0xd4, 0x77, 0xc4, 0xbd, 0xfd, 0x97, 0xbc, 0x3a, 0x63, 0x5b, 0x22, 0x30, 0x96, 0x32, 0x2b,
+	0x42, 0x1b, 0x8c, 0x4d, 0x15, 0xec, 0xcc, 0xc4, 0x2e, 0x71, 0x98, 0x9b, 0xb9, 0xc2, 0x3e, 0x1d,
+	0x29, 0x88, 0xe4, 0xc7, 0x16, 0xb9, 0x0a, 0x89, 0x4a, 0xc6, 0x6f, 0xe0, 0x46, 0x93, 0x78, 0x1b,
+	0xac, 0x46, 0x14, 0xae, 0x9c, 0x04, 0x65, 0x24, 0x25, 0x52, 0x46, 0x1a, 0xa3, 0x07, 0x7e, 0x0d,
+	0x37, 0xa4, 0xd5, 0xe8, 0x65, 0xc4, 0xdf, 0x4d, 0xbe, 0x86, 0xb2, 0xd4, 0x27, 0xeb, 0x1e, 0xe6,
+	0x5b, 0x3b, 0xe0, 0xc4, 0x04, 0x3e, 0x57, 0x59, 0x0c, 0xfb, 0x2f, 0x85, 0x6e, 0x97, 0x09, 0xfb,
+	0x65, 0xf8, 0xc4, 0x22, 0xe7, 0xdb, 0x61, 0x36, 0xae, 0x6e, 0x7c, 0x18, 0x3f, 0x85, 0x4f, 0xb7,
+	0x1d, 0x7b, 0x10, 0xf5, 0x77, 0x05, 0xa6, 0x34, 0x47, 0xef, 0xc8, 0xf9, 0x97, 0x55, 0x49, 0xe2,
+	0xff, 0xc8, 0xc1, 0x7c, 0x3c, 0x62, 0xd9, 0x42, 0x72, 0x08, 0xd9, 0xa2, 0x05, 0x88, 0x50, 0x1a,
+	0x8f, 0x0e, 0xb2, 0x23, 0xf1, 0xc8, 0xd2, 0xbf, 0x77, 0x4c, 0x8f, 0xb8, 0x62, 0x31, 0x86, 0x46,
+	0xe8, 0x42, 0xd3, 0xed, 0xfe, 0xc0, 0x21, 0x41, 0xe5, 0xab, 0xac, 0x86, 0x87, 0xd0, 0xaf, 0x60,
+	0x41, 0xb7, 0x1d, 0x67, 0xc8, 0x92, 0xd7, 0x56, 0x87, 0xe8, 0xdd, 0x86, 0xe5, 0x11, 0xe7, 0x4c,
+	0xeb, 0x09, 0xd7, 0x66, 0xbd, 0x8e, 0xac, 0xa4, 0x62, 0x74, 0x25, 0x51, 0xbd, 0xfa, 0xda, 0xbb,
+	0x1d, 0xcb, 0x73, 0x4c, 0xc2, 0x17, 0x69, 0x41, 0x0d, 0x8d, 0xd0, 0x6f, 0xfb, 0xda, 0xbb, 0xcd,
+	0x91, 0xc7, 0xaa, 0x87, 0x6c, 0x7f, 0x92, 0x34, 0x5a, 0x05, 0xd4, 0xd7, 0xde, 0xf1, 0x09, 0x1c,
+	0x12, 0xa7, 0xc9, 0x2f, 0x0a, 0x25, 0xa6, 0x4c, 0xca, 0x1b, 0xfc, 0xf7, 0x0a, 0xdc, 0x7e, 0x33,
+	0x30, 0x42, 0x0b, 0xcc, 0x5f, 0xf6, 0x57, 0xf0, 0x6e, 0x38, 0xa9, 0xe4, 0xae, 0x98, 0x54, 0xf0,
+	0x6f, 0xa1, 0xda, 0x24, 0x5e, 0xe0, 0x6f, 0x6e, 0x85, 0xab, 0xc0, 0x87, 0x8d, 0x99, 0x8b, 0xa5,
+	0xa5, 0x1f, 0x60, 0x41, 0x25, 0xae, 0xdd, 0x3b, 0x23, 0x47, 0x2c, 0x8b, 0x9a, 0x56, 0xfb, 0x2a,
+	0xa2, 0xef, 0x00, 0x04, 0x99, 0x55, 0xc6, 0x48, 0x30, 0x82, 0xb7, 0xe1, 0xce, 0x56, 0xd4, 0xc5,
+	0xc4, 0x69, 0xb2, 0xc3, 0xd9, 0x15, 0x50, 0xb0, 0x01, 0x95, 0x84, 0x94, 0xef, 0x34, 0xb3, 0x47,
+	0x0b, 0x00, 0xe9, 0x3b, 0x62, 0xa4, 0xa8, 0x44, 0xd5, 0xca, 0x87, 0x8b, 0x4a, 0x37, 0x64, 0x7a,
+	0xe4, 0x31, 0xcb, 0x09, 0xfc, 0x63, 0x1e, 0x96, 0xa4, 0x99, 0x33, 0x94, 0xbe, 0x94, 0x4d, 0xbe,
+	0x85, 0xf9, 0x9e, 0xe6, 0x7a, 0xc7, 0xc4, 0x31, 0x4f, 0x4d, 0xc2, 0x2f, 0x94, 0xb9, 0xd4, 0x6b,
+	0x13, 0x7d, 0xa5, 0x26, 0x98, 0xe9, 0x6a, 0xa6, 0x63, 0xea, 0x90, 0x2f, 0xaa, 0xbc, 0x2a, 0x49,
+	0x0a, 0xef, 0xd9, 0x9e, 0xd6, 0x93, 0xc1, 0xcf, 0xef, 0x8c, 0x91, 0x31, 0xf4, 0x10, 0xe6, 0x5c,
+	0x5d, 0xb3, 0x2c, 0x62, 0x48, 0xae, 0x22, 0xe3, 0x8a, 0x8d, 0x52, 0x3e, 0xba, 0x56, 0x7b, 0xc4,
+	0x23, 0x06, 0x3d, 0xde, 0xc9, 0xa5, 0x14, 0x1b, 0x65, 0xf2, 0x34, 0x3a, 0xe2, 0xcb, 0x9b, 0x12,
+	0xf2, 0x22, 0xa3, 0x68, 0x0b, 0x4a, 0xa7, 0xdc, 0x27, 0x6e, 0xa5, 0xc4, 0xb2, 0xe6, 0xa3, 0x44,
+	0x25, 0x2c, 0xdd, 0x87, 0xaa, 0xff, 0x21, 0xfe, 0x57, 0x05, 0x96, 0x32, 0x03, 0x46, 0xe4, 0xe7,
+	0xcc, 0x5a, 0x2d, 0x3f, 0xbe, 0x19, 0x32, 0x75, 0xb2, 0x67, 0xf4, 0x2a, 0x9c, 0xcd, 0xf9, 0x51,
+	0x7f, 0x35, 0x63, 0xf1, 0x65, 0x01, 0x87, 0x92, 0xfc, 0x37, 0xf0, 0x39, 0x73, 0xd5, 0xe8, 0x1a,
+	0x49, 0x1e, 0xff, 0xa3, 0x02, 0x33, 0xb4, 0x56, 0xfb, 0xda, 0x74, 0xfb, 0x9a, 0xa7, 0x77, 0xd8,
+	0x49, 0x9c, 0xd6, 0x66, 0x45, 0x61, 0x9a, 0x13, 0x19, 0x85, 0x83, 0x2a, 0x94, 0xc8, 0xbb, 0x01,
+	0xd1, 0x3d, 0x22, 0x2b, 0xee, 0x3e, 0x2d, 0x76, 0xbf, 0xa1, 0x48, 0xab, 0x33, 0xaa, 0xa0, 0x82,
+	0x38, 0x2f, 0x86, 0xe3, 0xfc, 0xcf, 0x0a, 0xdc, 0x8c, 0x4e, 0xe2, 0xd0, 0xb1, 0xdb, 0x34, 0x67,
+	0x53, 0x90, 0x33, 0x11, 0x89, 0xf2, 0x68, 0x2f, 0x69, 0x7e, 0x00, 0xf1, 0xb4, 0x9e, 0x54, 0x8b,
+	0x11, 0xe8, 0x1b, 0x80, 0xbe, 0x98, 0x8e, 0x6f, 0xdf, 0x5b, 0x31, 0xfb, 0x86, 0xe7, 0xac, 0x86,
+	0xd8, 0x59, 0x99, 0xca, 0xb6, 0xe4, 0x85, 0x83, 0x3d, 0xd3, 0x8c, 0xe2, 0xd7, 0x2f, 0x3c, 0x91,
+	0xfb, 0x43, 0x23, 0xb4, 0x10, 0xe1, 0xd7, 0xcb, 0x33, 0x56, 0x14, 0x63, 0xc0, 0x4f, 0x61, 0x76,
+	0x53, 0xd3, 0xbb, 0xc3, 0x81, 0x74, 0xd1, 0x62, 0x7c, 0x5f, 0x2f, 0x87, 0x3d, 0xfb, 0x08, 0xa6,
+	0x39, 0xfb, 0x56, 0x67, 0x68, 0x75, 0x69, 0x90, 0xe9, 0xbc, 0x70, 0x2b, 0xae, 0x54, 0x92, 0xc4,
+	0x7f, 0x54, 0x68, 0xd9, 0xb0, 0xc7, 0x8a, 0x6c, 0x21, 0x57, 0x17, 0x44, 0xa6, 0x8f, 0xf4, 0xd1,
+	0x72, 0xd7, 0xef, 0xa3, 0xe5, 0xc7, 0xf6, 0xd1, 0xa2, 0x5d, 0x36, 0xcd, 0xa3, 0x65, 0x59, 0xaa,
+	0x94, 0x2c, 0xcb, 0xf2, 0xde, 0x59, 0xfa, 0xe5, 0x8a, 0xf2, 0x89, 0x86, 0x1a, 0x36, 0x64, 0x07,
+	0x8c, 0x0d, 0x06, 0xdd, 0x9a, 0xbf, 0xe8, 0xc4, 0x28, 0x0a, 0xdf, 0x44, 0xff, 0x5f, 0x51, 0x1e,
+	0xc1, 0xa7, 0xdb, 0xa4, 0x47, 0x2e, 0x44, 0xc1, 0x7f, 0x05, 0xd3, 0x61, 0x96, 0xf0, 0x19, 0x51,
+	0x89, 0x9d, 0x55, 0x59, 0xb9, 0xae, 0xe7, 0x67, 0x14, 0xfa, 0xbc, 0xf2, 0x2f, 0x0a, 0x40, 0x70,
+	0x23, 0x42, 0x93, 0x90, 0x3b, 0xe8, 0xce, 0x4f, 0xa0, 0x45, 0xa8, 0xec, 0xa8, 0xea, 0x81, 0x7a,
+	0xd2, 0xdc, 0x79, 0xb5, 0xb3, 0x75, 0xd4, 0xd8, 0xdf, 0x3d, 0xd9, 0xde, 0x38, 0xda, 0xd8, 0xdc,
+	0x68, 0xee, 0xcc, 0x2b, 0xe8, 0x31, 0x3c, 0xe0, 0x6f, 0xf7, 0x0f, 0x4e, 0x0e, 0x77, 0xd4, 0xd7,
+	0x8d, 0x66, 0xb3, 0x71, 0xb0, 0x7f, 0xf2, 0xdd, 0x81, 0x7a, 0x72, 0xf4, 0xb2, 0xd1, 0x0c, 0x58,
+	0x73, 0xa8, 0x06, 0x8b, 0x9c, 0xf5, 0x4d, 0x73, 0x47, 0x3d, 0x79, 0xb9, 0xd1, 0x3c, 0xd9, 0x3f,
+	0x38, 0x3a, 0x79, 0x75, 0xb0, 0xbb, 0xbb, 0xb3, 0x7d, 0xd2, 0xd8, 0x9f, 0xcf, 0xa3, 0x5b, 0xb0,
+	0xc0, 0x39, 0xb6, 0x37, 0x4f, 0xb6, 0x0f, 0x76, 0x38, 0xc3, 0xce, 0x5f, 0x37, 0x9a, 0x47, 0xf3,
+	0x85, 0x95, 0xc7, 0x30, 0x1f, 0x3f, 0x96, 0xa3, 0x32, 0x14, 0x77, 0xd5, 0x8d, 0xfd, 0xa3, 0xf9,
+	0x09, 0x04, 0x30, 0xa9, 0xee, 0x1c, 0x1f, 0xec, 0xed, 0xcc, 0x2b, 0xcf, 0xfe, 0xad, 0x0e, 0xd3,
+	0x8d, 0x7e, 0x7f, 0xd8, 0x24, 0xce, 0x99, 0xa9, 0x13, 0xa4, 0x41, 0x99, 0x06, 0x10, 0x3d, 0x58,
+	0xbb, 0xe8, 0xe6, 0x2a, 0xef, 0x85, 0xaf, 0xca, 0x5e, 0xf8, 0xea, 0x0e, 0xed, 0x85, 0x57, 0x17,
+	0x52, 0x1a, 0xaa, 0xf4, 0x2b, 0x7c, 0xef, 0x1f, 0xfe, 0xf3, 0xcf, 0x7f, 0xcc, 0xdd, 0x46, 0xb7,
+	0xea, 0x67, 0x5f, 0xd5, 0x29, 0x8f, 0x43, 0x5c, 0x6f, 0xe0, 0xd8, 0xef, 0x46, 0x75, 0x6a, 0xcf,
+	0x7a, 0x8f, 0xc6, 0xa6, 0x09, 0x53, 0xbb, 0x84, 0x21, 0xa0, 0x6a, 0x8a, 0x20, 0xe1, 0x93, 0xea,
+	0xad, 0xd4, 0x77, 0x7c, 0x03, 0xc0, 0x0f, 0x18, 0xd0, 0x12, 0xba, 0x9d, 0x01, 0xf4, 0x81, 0xfe,
+	0xfb, 0x11, 0x59, 0x00, 0x41, 0x77, 0x17, 0xd5, 0xe2, 0x9b, 0x51, 0xbc, 0xf1, 0x3b, 0x1e, 0xf3,
+	0x2e, 0xc3, 0xbc, 0x85, 0x6f, 0xa6, 0x63, 0xbe, 0x50, 0x56, 0xd0, 0x8f, 0x0a, 0xcc, 0x45, 0xdb,
+	0xac, 0xe8, 0x7e, 0x1c, 0x34, 0xad, 0x0b, 0x5b, 0xcd, 0xb0, 0x34, 0xfe, 0x8a, 0x61, 0x7e, 0x81,
+	0x1f, 0x66, 0xcc, 0x53, 0xb6, 0x4b, 0xeb, 0x3a, 0x13, 0x4b, 0x75, 0xb0, 0x60, 0xb6, 0x49, 0xbc,
+	0xc0, 0xff, 0x28, 0xad, 0xb2, 0x92, 0x09, 0xf8, 0x25, 0x03, 0x5c, 0xc1, 0x0f, 0xb2, 0x00, 0x7d,
+	0xb9, 0x75, 0x97, 0x78, 0x14, 0xcf, 0x81, 0xb9, 0x6d, 0xc2, 0xee, 0x5a, 0xd2, 0xce, 0xe3, 0xbc,
+	0x9a, 0x85, 0xfb, 0x84, 0xe1, 0x3e, 0xc4, 0x77, 0x33, 0x70, 0x0d, 0x1f, 0x82, 0x62, 0xee, 0xc2,
+	0x3c, 0xcf, 0x26, 0xa1, 0x0e, 0x6f, 0x3c, 0x47, 0x04, 0xaf, 0x32, 0x41, 0x27, 0x02, 0x41, 0xa1,
+	0x46, 0x70, 0x5c, 0x50, 0xf0, 0x6a, 0x8c, 0xa0, 0x17, 0x50, 0x3e, 0x74, 0x4c, 0xcb, 0x63, 0x8d,
+	0xd8, 0xac, 0x75, 0xf3, 0x59, 0xca, 0xae, 0x88, 0x27, 0x50, 0x17, 0x8a, 0xac, 0xd5, 0x8d, 0xe2,
+	0xe1, 0x17, 0x6e, 0xa0, 0x57, 0x17, 0xd3, 0x5f, 0x8a, 0xe0, 0x7c, 0xf4, 0xd3, 0x46, 0xae, 0x35,
+	0xc1, 0x8c, 0xb8, 0x88, 0x17, 0x92, 0x46, 0xec, 0x51, 0x6e, 0x6a, 0xba, 0x1f, 0x60, 0xf2, 0x95,
+	0xdd, 0xb6, 0x87, 0x5e, 0xa6, 0x96, 0x59, 0x93, 0x14, 0x8b, 0x1b, 0x57, 0x52, 0xa5, 0xdb, 0x43,
+	0x16, 0x0d, 0xdf, 0x43, 0xbe, 0x49, 0x3c, 0x94, 0x55, 0x43, 0xaf, 0xa6, 0x16, 0xa5, 0xc6, 0x2d,
+	0x2d, 0xd3, 0x23, 0x7d, 0x2a, 0x78, 0x13, 0x8a, 0xac, 0x80, 0x8e, 0x2e, 0x2e, 0x96, 0x67, 0x80,
+	0x4c, 0xa0, 0x53, 0x98, 0x12, 0x85, 0x78, 0x94, 0x28, 0x13, 0x46, 0xfa, 0x01, 0xd5, 0xd4, 0xf6,
+	0x01, 0x7e, 0xc8, 0xd4, 0xac, 0xe1, 0x5b, 0xe9, 0x6a, 0xd6, 0x5d, 0xed, 0x94, 0x85, 0xe7, 0x36,
+	0x94, 0xfd, 0x82, 0x3f, 0x5a, 0x4a, 0x47, 0x6a, 0x1e, 0x8f, 0xc7, 0x9a, 0x40, 0x47, 0x90, 0xdf,
+	0x25, 0x1e, 0x4a, 0xe9, 0xc3, 0x56, 0xd3, 0x96, 0x34, 0xbe, 0xcf, 0xb4, 0xbb, 0x83, 0x16, 0x33,
+	0xb4, 0xfb, 0xd0, 0x25, 0xa3, 0x8f, 0x68, 0x0d, 0x8a, 0xbb, 0x4c, 0xaf, 0x34, 0xb9, 0xe3, 0x8b,
+	0xa7, 0x78, 0x02, 0xf5, 0xb9, 0x05, 0x77, 0x33, 0x2c, 0x18, 0x74, 0x19, 0xaa, 0x0b, 0x29, 0xaf,
+	0x99, 0x90, 0x15, 0xa6, 0xe6, 0x7d, 0xbc, 0x34, 0xc6, 0x88, 0xf5, 0x36, 0xcf, 0x2d, 0x07, 0xdc,
+	0x90, 0x5c, 0xe1, 0x0b, 0x00, 0xef, 0xa6, 0xd9, 0x39, 0xae, 0x3f, 0xed, 0x67, 0x11, 0x6f, 0x93,
+	0x1d, 0xbd, 0x3f, 0x8f, 0x1b, 0x80, 0xf5, 0xd1, 0x33, 0x82, 0x67, 0x8c, 0xeb, 0x5b, 0x54, 0x9a,
+	0xcc, 0x86, 0x6b, 0x00, 0x12, 0xa0, 0x79, 0x8c, 0xe2, 0x3f, 0x04, 0x68, 0x8e, 0xc5, 0x98, 0x40,
+	0x3a, 0x94, 0x76, 0xa5, 0x7a, 0x37, 0x93, 0xfe, 0x61, 0xdf, 0x2e, 0xa4, 0xf8, 0x9e, 0xbe, 0xb8,
+	0x58, 0x45, 0x61, 0xd4, 0x06, 0xc0, 0x6e, 0xb6, 0x8a, 0x12, 0xe6, 0xee, 0xd8, 0x50, 0x60, 0x80,
+	0x54, 0xdf, 0x02, 0xbd, 0x21, 0x26, 0x32, 0x7e, 0xa8, 0x57, 0x70, 0x2d, 0x7d, 0x79, 0x20, 0xe8,
+	0x9a, 0xc5, 0xf5, 0x9d, 0xa4, 0xf2, 0x9a, 0xc7, 0x63, 0x61, 0x2e, 0xa5, 0x6f, 0x17, 0x8a, 0xbc,
+	0xad, 0x5d, 0x49, 0xce, 0x9a, 0xb7, 0xc5, 0xab, 0x3f, 0x4b, 0x51, 0x97, 0xf7, 0xc2, 0xf1, 0x53,
+	0xa6, 0xf0, 0x23, 0xf4, 0x20, 0x43, 0x61, 0xd6, 0x1b, 0xaf, 0x7f, 0xe0, 0x7d, 0xf4, 0x8f, 0xe8,
+	0x04, 0xa6, 0xb7, 0x86, 0x8e, 0x43, 0x2c, 0xde, 0x5e, 0xbe, 0xec, 0xa6, 0x40, 0x99, 0xf1, 0xbd,
+	0x20, 0x9d, 0x57, 0x50, 0x4a, 0x56, 0x64, 0x4d, 0x63, 0x07, 0xca, 0x7e, 0x17, 0x1e, 0xa5, 0x86,
+	0x54, 0x62, 0x41, 0x47, 0xbb, 0xf6, 0x72, 0xb7, 0x47, 0xcb, 0x29, 0x33, 0x92, 0x9c, 0xac, 0x65,
+	0x5a, 0xff, 0xc0, 0xae, 0xa0, 0x1f, 0xd1, 0x3b, 0x98, 0x0e, 0x35, 0xe1, 0x33, 0x50, 0x97, 0x92,
+	0xbf, 0x7f, 0x89, 0xb4, 0xed, 0xf1, 0x33, 0x86, 0xfb, 0x04, 0xad, 0x24, 0x71, 0x43, 0x9d, 0xeb,
+	0x28, 0x72, 0x0b, 0xa6, 0x36, 0x47, 0xe2, 0xe7, 0x3e, 0xa9, 0xa8, 0xa9, 0x49, 0x51, 0x9c, 0x2b,
+	0xd0, 0xfd, 0x0c, 0x9f, 0x31, 0xe1, 0x3e, 0xc6, 0x7b, 0x98, 0xde, 0x1c, 0xf9, 0x1d, 0x97, 0xd4,
+	0xd4, 0x1d, 0xee, 0xc5, 0x64, 0x27, 0x39, 0x71, 0x6e, 0x43, 0x8f, 0xc7, 0x25, 0xb9, 0x28, 0xf6,
+	0x26, 0x94, 0xc5, 0xfc, 0x9a, 0xc7, 0x97, 0xf4, 0x66, 0x4a, 0x7a, 0x9b, 0x7a, 0x69, 0xba, 0x9e,
+	0xed, 0x8c, 0x52, 0xd3, 0x7b, 0xe6, 0x52, 0x7c, 0xc4, 0xd4, 0xbd, 0x8b, 0x52, 0x72, 0x72, 0x87,
+	0xcb, 0x13, 0xbb, 0xc7, 0x36, 0x94, 0x05, 0x40, 0xc6, 0x0e, 0x72, 0xa9, 0x65, 0x68, 0xc1, 0x24,
+	0x6f, 0xdf, 0x66, 0x2e, 0x8a, 0xf8, 0x4c, 0xa3, 0xdd, 0x5e, 0xfc, 0x34, 0x58, 0x1e, 0x18, 0xd5,
+	0x52, 0x94, 0x66, 0xec, 0x8e, 0x60, 0x47, 0xbf, 0x83, 0xb2, 0xdf, 0xb5, 0x45, 0x17, 0x35, 0xa5,
+	0xaf, 0xbe, 0x01, 0xf8, 0xcd, 0x5e, 0x9a, 0xad, 0xce, 0x61, 0x36, 0xd2, 0x17, 0x47, 0xf7, 0x52,
+	0x62, 0xe4, 0x42, 0x4c, 0xbe, 0x4c, 0xbe, 0x60, 0x98, 0x0f, 0x70, 0xca, 0x0c, 0x59, 0x00, 0x45,
+	0x80, 0xff, 0x16, 0x0a, 0xb4, 0xeb, 0x88, 0xc6, 0xb4, 0x22, 0xaf, 0x7e, 0xfa, 0x7a, 0xaf, 0x19,
+	0x06, 0x15, 0xae, 0x41, 0x91, 0xb5, 0x9a, 0x13, 0x47, 0xd4, 0xb7, 0x97, 0x4a, 0xf5, 0x38, 0xfb,
+	0x60, 0xfa, 0x5e, 0xa6, 0xf9, 0x3d, 0x98, 0x7a, 0x2b, 0xf2, 0xfc, 0x58, 0x90, 0x4b, 0x45, 0x58,
+	0x87, 0xff, 0x6e, 0x85, 0x19, 0xe4, 0x4e, 0x8a, 0x03, 0xc6, 0x19, 0xe5, 0xc2, 0xb3, 0x1e, 0xb3,
+	0xbd, 0xb4, 0xcc, 0x0f, 0x50, 0x6c, 0xa4, 0x5a, 0x26, 0xdc, 0x30, 0x4f, 0xe4, 0x26, 0xda, 0xb9,
+	0x1e, 0x67, 0x15, 0x53, 0x5a, 0x65, 0x1d, 0xa6, 0x1a, 0x19, 0x56, 0x89, 0x00, 0xc4, 0x27, 0xc1,
+	0x7a, 0xe3, 0x78, 0x02, 0x1d, 0x40, 0x61, 0x7b, 0xd8, 0x1f, 0x64, 0x2e, 0x34, 0x58, 0x1d, 0xb4,
+	0xc4, 0xc9, 0x67, 0x5c, 0x1c, 0x18, 0xc3, 0xfe, 0xe0, 0x85, 0xb2, 0xf2, 0xa5, 0x82, 0xde, 0xc3,
+	0x5c, 0xb4, 0xdd, 0x88, 0xb2, 0x3a, 0x63, 0x55, 0x9c, 0x7a, 0xdf, 0x8e, 0xb4, 0xad, 0xc6, 0x85,
+	0xb8, 0x5f, 0xd9, 0x62, 0xec, 0xd4, 0x18, 0x1f, 0xd9, 0x6f, 0xa5, 0x2f, 0x06, 0x5e, 0x4a, 0x5e,
+	0x40, 0xa3, 0xa8, 0x3f, 0x67, 0xa8, 0xab, 0xe8, 0x49, 0xea, 0x6d, 0x53, 0x42, 0xd6, 0x3f, 0x84,
+	0x6b, 0xb7, 0x1f, 0xd1, 0x1f, 0x60, 0x3e, 0xde, 0x25, 0x45, 0x0f, 0xd3, 0xaf, 0xf7, 0xf1, 0x36,
+	0x6a, 0x35, 0xb5, 0xff, 0x2a, 0x4f, 0x14, 0x18, 0xa7, 0xcc, 0x9e, 0x09, 0x0a, 0xae, 0xdb, 0x7c,
+	0xfe, 0xb3, 0x91, 0xd6, 0x67, 0x32, 0xb7, 0xa4, 0x34, 0x46, 0x33, 0xef, 0x73, 0x75, 0x06, 0xfe,
+	0x18, 0xdf, 0xcf, 0xb8, 0x72, 0xbb, 0xc4, 0xd3, 0x7c, 0x61, 0x14, 0xfe, 0x03, 0xcc, 0x84, 0xbb,
+	0xa5, 0x99, 0x31, 0x75, 0x2f, 0xc3, 0x2f, 0xe1, 0x16, 0x2b, 0x5e, 0x65, 0xe8, 0xcb, 0xf8, 0x5e,
+	0x06, 0xba, 0x34, 0x3d, 0x2d, 0x19, 0x51, 0xf0, 0x97, 0xb2, 0x94, 0xc3, 0x0a, 0xaf, 0xe9, 0xa5,
+	0x9c, 0x50, 0x49, 0x6f, 0xcc, 0x55, 0x7d, 0x9d, 0x97, 0xb8, 0x54, 0xfe, 0xe7, 0x04, 0x97, 0x2c,
+	0x71, 0xc9, 0xca, 0x2a, 0x9e, 0x40, 0xdf, 0x42, 0x79, 0xd7, 0xd1, 0x2c, 0x26, 0x20, 0x91, 0x6d,
+	0xc3, 0x2a, 0xa4, 0xfb, 0x7c, 0x02, 0xfd, 0x1a, 0x40, 0x25, 0x67, 0x76, 0x97, 0x5c, 0x5b, 0xc2,
+	0x4b, 0x80, 0xa0, 0x9a, 0x9a, 0x30, 0x46, 0xa2, 0xd0, 0x3a, 0xc6, 0x18, 0x2f, 0x01, 0x82, 0x8a,
+	0x69, 0x42, 0x52, 0xa2, 0x98, 0x3a, 0x46, 0xd2, 0x11, 0xcc, 0xc7, 0xdb, 0x88, 0x89, 0xd5, 0x91,
+	0xd1, 0x67, 0x1c, 0x23, 0x75, 0x0b, 0xe6, 0xde, 0xb0, 0x1f, 0x66, 0x5c, 0xbc, 0xea, 0xb3, 0x85,
+	0x6c, 0xd0, 0xbf, 0x45, 0xf8, 0xbf, 0x89, 0x38, 0x84, 0xb9, 0x68, 0x6b, 0x3f, 0x51, 0xd8, 0x4b,
+	0xed, 0xfc, 0x8f, 0x91, 0xb8, 0x07, 0x33, 0xe1, 0x2e, 0x7e, 0xb6, 0x52, 0x09, 0xa7, 0xc4, 0x7b,
+	0xff, 0x78, 0x02, 0xfd, 0x1d, 0xdc, 0x4c, 0xef, 0x51, 0xa3, 0x27, 0xa9, 0xc1, 0x91, 0xd1, 0xca,
+	0x1e, 0xa3, 0xee, 0x5b, 0xf8, 0x2c, 0xa5, 0x07, 0x8d, 0x1e, 0x27, 0x33, 0x50, 0x46, 0x9f, 0x7a,
+	0x8c, 0xec, 0xf7, 0xb0, 0x90, 0xd5, 0x6d, 0x7d, 0x7a, 0x51, 0x03, 0x31, 0xd2, 0x4a, 0xae, 0xae,
+	0x5e, 0x96, 0x5d, 0x64, 0xa1, 0x09, 0xa4, 0xc1, 0x5c, 0xb4, 0x13, 0x96, 0x70, 0x6c, 0x6a, 0xb7,
+	0xaf, 0xfa, 0x60, 0x2c, 0x97, 0x6c, 0xa7, 0xe1, 0x89, 0x2f, 0x15, 0xf4, 0x1d, 0x4c, 0xf2, 0xbe,
+	0x12, 0x8a, 0xd7, 0xf0, 0x22, 0xdd, 0xa9, 0x6a, 0x35, 0xf5, 0x2d, 0x6b, 0x46, 0x51, 0x39, 0x9b,
+	0xff, 0x9c, 0xff, 0x69, 0xe3, 0x4f, 0x39, 0xf4, 0xdf, 0x0a, 0x7c, 0xc2, 0x19, 0x6b, 0xea, 0x4e,
+	0xf3, 0xa8, 0xb6, 0x71, 0xd8, 0x40, 0x7f, 0x52, 0xd6, 0x5a, 0xeb, 0x8d, 0xd7, 0x87, 0x07, 0xea,
+	0xd1, 0xc6, 0xfe, 0xd1, 0x5a, 0xbd, 0xb5, 0xfe, 0xa2, 0xb6, 0xd1, 0xeb, 0xd5, 0xd6, 0x74, 0xdb,
+	0x20, 0xeb, 0x6d, 0xe2, 0xad, 0xd5, 0xd9, 0x53, 0x4d, 0xb3, 0x0c, 0x31, 0x48, 0x4f, 0x31, 0xa1,
+	0x17, 0xa7, 0x43, 0x8b, 0x35, 0x0a, 0xdc, 0x9a, 0x43, 0xbc, 0xa1, 0x63, 0xd5, 0xd6, 0x86, 0xeb,
+	0x74, 0x26, 0xbf, 0xf8, 0xf9, 0x53, 0x62, 0x51, 0x16, 0x63, 0xad, 0x3e, 0x5c, 0xaf, 0xd1, 0x3f,
+	0x00, 0x60, 0x42, 0xd8, 0x9f, 0x32, 0xb8, 0x4f, 0x6a, 0xe7, 0x1d, 0xb3, 0x47, 0x6a, 0x9a, 0x8f,
+	0xe5, 0x66, 0x61, 0xb9, 0x69, 0x58, 0xbc, 0x7f, 0x99, 0x81, 0x65, 0x5a, 0x83, 0xa1, 0xe7, 0xae,
+	0xbe, 0xfd, 0x1b, 0xf8, 0x1e, 0x26, 0x5b, 0x44, 0x73, 0x88, 0x83, 0x5e, 0x97, 0x72, 0xe8, 0x57,
+	0xb4, 0xb4, 0x4b, 0x2c, 0xcf, 0xd4, 0xd9, 0x5f, 0xdb, 0xd5, 0xd8, 0xef, 0xa0, 0x9e, 0xd4, 0xf8,
+	0xbd, 0x9b, 0x18, 0xb5, 0xd6, 0xa8, 0xb6, 0xc9, 0xb8, 0x5f, 0x88, 0xff, 0x6b, 0x6b, 0x8c, 0x65,
+	0xbd, 0x3a, 0x4b, 0xbf, 0xb4, 0x1d, 0xf3, 0x3d, 0xff, 0x30, 0xd7, 0x9a, 0x01, 0xf0, 0x45, 0x4f,
+	0xbc, 0xfd, 0xa2, 0x6d, 0x7a, 0x9d, 0x61, 0x6b, 0x55, 0xb7, 0xfb, 0x4c, 0x53, 0xcb, 0xf6, 0x34,
+	0x67, 0x54, 0xe7, 0xc6, 0xae, 0x0f, 0xba, 0x6d, 0xf6, 0x27, 0x83, 0xdc, 0x3b, 0xad, 0x49, 0x16,
+	0xc6, 0xcf, 0xff, 0x77, 0x00, 0xa6, 0x64, 0x8c, 0xe1, 0x6b, 0x38, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ImmuServiceClient is the client API for ImmuService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ImmuServiceClient interface {
+	ListUsers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*UserList, error)
+	GetUser(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*UserResponse, error)
+	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*UserResponse, error)
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	SetPermission(ctx context.Context, in *Item, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeactivateUser(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	UpdateAuthConfig(ctx context.Context, in *AuthConfig, opts ...grpc.CallOption) (*empty.Empty, error)
+	UpdateMTLSConfig(ctx context.Context, in *MTLSConfig, opts ...grpc.CallOption) (*empty.Empty, error)
+	PrintTree(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Tree, error)
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	Logout(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
 	Set(ctx context.Context, in *KeyValue, opts ...grpc.CallOption) (*Index, error)
 	SetSV(ctx context.Context, in *StructuredKeyValue, opts ...grpc.CallOption) (*Index, error)
 	SafeSet(ctx context.Context, in *SafeSetOptions, opts ...grpc.CallOption) (*Proof, error)
@@ -3114,6 +4454,22 @@ type ImmuServiceClient interface {
 	ChangePermission(ctx context.Context, in *ChangePermissionRequest, opts ...grpc.CallOption) (*Error, error)
 	SetActiveUser(ctx context.Context, in *SetActiveUserRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	DatabaseList(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*DatabaseListResponse, error)
+	CreateRole(ctx context.Context, in *CreateRoleRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListRoles(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RoleList, error)
+	GrantRole(ctx context.Context, in *RoleRequest, opts ...grpc.CallOption) (*Error, error)
+	RevokeRole(ctx context.Context, in *RoleRequest, opts ...grpc.CallOption) (*Error, error)
+	UpdateRole(ctx context.Context, in *UpdateRoleRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeleteRole(ctx context.Context, in *DeleteRoleRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ResolveTampering(ctx context.Context, in *ResolveTamperingRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	UnloadDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*empty.Empty, error)
+	LoadDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*empty.Empty, error)
+	RenameDatabase(ctx context.Context, in *RenameDatabaseRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DropDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*DropDatabaseReply, error)
+	UpdateDatabaseSettings(ctx context.Context, in *UpdateDatabaseSettingsRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	SetDatabaseReadOnly(ctx context.Context, in *SetDatabaseReadOnlyRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	CorruptionCheckerStatus(ctx context.Context, in *CorruptionCheckerStatusRequest, opts ...grpc.CallOption) (*CorruptionCheckerStatusResponse, error)
+	VerifyDatabase(ctx context.Context, in *VerifyDatabaseRequest, opts ...grpc.CallOption) (ImmuService_VerifyDatabaseClient, error)
+	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (ImmuService_BackupClient, error)
 }
 
 type immuServiceClient struct {
@@ -3588,6 +4944,196 @@ func (c *immuServiceClient) DatabaseList(ctx context.Context, in *empty.Empty, o
 	return out, nil
 }
 
+func (c *immuServiceClient) CreateRole(ctx context.Context, in *CreateRoleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/CreateRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) ListRoles(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RoleList, error) {
+	out := new(RoleList)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ListRoles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) GrantRole(ctx context.Context, in *RoleRequest, opts ...grpc.CallOption) (*Error, error) {
+	out := new(Error)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/GrantRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) RevokeRole(ctx context.Context, in *RoleRequest, opts ...grpc.CallOption) (*Error, error) {
+	out := new(Error)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/RevokeRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) UpdateRole(ctx context.Context, in *UpdateRoleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/UpdateRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) DeleteRole(ctx context.Context, in *DeleteRoleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/DeleteRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) ResolveTampering(ctx context.Context, in *ResolveTamperingRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ResolveTampering", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) UnloadDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/UnloadDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) LoadDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/LoadDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) RenameDatabase(ctx context.Context, in *RenameDatabaseRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/RenameDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) DropDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*DropDatabaseReply, error) {
+	out := new(DropDatabaseReply)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/DropDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) UpdateDatabaseSettings(ctx context.Context, in *UpdateDatabaseSettingsRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/UpdateDatabaseSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) SetDatabaseReadOnly(ctx context.Context, in *SetDatabaseReadOnlyRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/SetDatabaseReadOnly", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) CorruptionCheckerStatus(ctx context.Context, in *CorruptionCheckerStatusRequest, opts ...grpc.CallOption) (*CorruptionCheckerStatusResponse, error) {
+	out := new(CorruptionCheckerStatusResponse)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/CorruptionCheckerStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) VerifyDatabase(ctx context.Context, in *VerifyDatabaseRequest, opts ...grpc.CallOption) (ImmuService_VerifyDatabaseClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[1], "/immudb.schema.ImmuService/VerifyDatabase", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &immuServiceVerifyDatabaseClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ImmuService_VerifyDatabaseClient interface {
+	Recv() (*VerifyDatabaseProgress, error)
+	grpc.ClientStream
+}
+
+type immuServiceVerifyDatabaseClient struct {
+	grpc.ClientStream
+}
+
+func (x *immuServiceVerifyDatabaseClient) Recv() (*VerifyDatabaseProgress, error) {
+	m := new(VerifyDatabaseProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *immuServiceClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (ImmuService_BackupClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[2], "/immudb.schema.ImmuService/Backup", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &immuServiceBackupClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ImmuService_BackupClient interface {
+	Recv() (*BackupChunk, error)
+	grpc.ClientStream
+}
+
+type immuServiceBackupClient struct {
+	grpc.ClientStream
+}
+
+func (x *immuServiceBackupClient) Recv() (*BackupChunk, error) {
+	m := new(BackupChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ImmuServiceServer is the server API for ImmuService service.
 type ImmuServiceServer interface {
 	ListUsers(context.Context, *empty.Empty) (*UserList, error)
@@ -3646,6 +5192,22 @@ type ImmuServiceServer interface {
 	ChangePermission(context.Context, *ChangePermissionRequest) (*Error, error)
 	SetActiveUser(context.Context, *SetActiveUserRequest) (*empty.Empty, error)
 	DatabaseList(context.Context, *empty.Empty) (*DatabaseListResponse, error)
+	CreateRole(context.Context, *CreateRoleRequest) (*empty.Empty, error)
+	ListRoles(context.Context, *empty.Empty) (*RoleList, error)
+	GrantRole(context.Context, *RoleRequest) (*Error, error)
+	RevokeRole(context.Context, *RoleRequest) (*Error, error)
+	UpdateRole(context.Context, *UpdateRoleRequest) (*empty.Empty, error)
+	DeleteRole(context.Context, *DeleteRoleRequest) (*empty.Empty, error)
+	ResolveTampering(context.Context, *ResolveTamperingRequest) (*empty.Empty, error)
+	UnloadDatabase(context.Context, *Database) (*empty.Empty, error)
+	LoadDatabase(context.Context, *Database) (*empty.Empty, error)
+	RenameDatabase(context.Context, *RenameDatabaseRequest) (*empty.Empty, error)
+	DropDatabase(context.Context, *Database) (*DropDatabaseReply, error)
+	UpdateDatabaseSettings(context.Context, *UpdateDatabaseSettingsRequest) (*empty.Empty, error)
+	SetDatabaseReadOnly(context.Context, *SetDatabaseReadOnlyRequest) (*empty.Empty, error)
+	CorruptionCheckerStatus(context.Context, *CorruptionCheckerStatusRequest) (*CorruptionCheckerStatusResponse, error)
+	VerifyDatabase(*VerifyDatabaseRequest, ImmuService_VerifyDatabaseServer) error
+	Backup(*BackupRequest, ImmuService_BackupServer) error
 }
 
 // UnimplementedImmuServiceServer can be embedded to have forward compatible implementations.
@@ -3787,17 +5349,65 @@ func (*UnimplementedImmuServiceServer) Dump(req *empty.Empty, srv ImmuService_Du
 func (*UnimplementedImmuServiceServer) CreateDatabase(ctx context.Context, req *Database) (*CreateDatabaseReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateDatabase not implemented")
 }
-func (*UnimplementedImmuServiceServer) UseDatabase(ctx context.Context, req *Database) (*UseDatabaseReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UseDatabase not implemented")
+func (*UnimplementedImmuServiceServer) UseDatabase(ctx context.Context, req *Database) (*UseDatabaseReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UseDatabase not implemented")
+}
+func (*UnimplementedImmuServiceServer) ChangePermission(ctx context.Context, req *ChangePermissionRequest) (*Error, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePermission not implemented")
+}
+func (*UnimplementedImmuServiceServer) SetActiveUser(ctx context.Context, req *SetActiveUserRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetActiveUser not implemented")
+}
+func (*UnimplementedImmuServiceServer) DatabaseList(ctx context.Context, req *empty.Empty) (*DatabaseListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DatabaseList not implemented")
+}
+func (*UnimplementedImmuServiceServer) CreateRole(ctx context.Context, req *CreateRoleRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRole not implemented")
+}
+func (*UnimplementedImmuServiceServer) ListRoles(ctx context.Context, req *empty.Empty) (*RoleList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoles not implemented")
+}
+func (*UnimplementedImmuServiceServer) GrantRole(ctx context.Context, req *RoleRequest) (*Error, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantRole not implemented")
+}
+func (*UnimplementedImmuServiceServer) RevokeRole(ctx context.Context, req *RoleRequest) (*Error, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeRole not implemented")
+}
+func (*UnimplementedImmuServiceServer) UpdateRole(ctx context.Context, req *UpdateRoleRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRole not implemented")
+}
+func (*UnimplementedImmuServiceServer) DeleteRole(ctx context.Context, req *DeleteRoleRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRole not implemented")
+}
+func (*UnimplementedImmuServiceServer) ResolveTampering(ctx context.Context, req *ResolveTamperingRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveTampering not implemented")
+}
+func (*UnimplementedImmuServiceServer) UnloadDatabase(ctx context.Context, req *Database) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnloadDatabase not implemented")
+}
+func (*UnimplementedImmuServiceServer) LoadDatabase(ctx context.Context, req *Database) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadDatabase not implemented")
+}
+func (*UnimplementedImmuServiceServer) RenameDatabase(ctx context.Context, req *RenameDatabaseRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameDatabase not implemented")
+}
+func (*UnimplementedImmuServiceServer) DropDatabase(ctx context.Context, req *Database) (*DropDatabaseReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DropDatabase not implemented")
+}
+func (*UnimplementedImmuServiceServer) UpdateDatabaseSettings(ctx context.Context, req *UpdateDatabaseSettingsRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDatabaseSettings not implemented")
+}
+func (*UnimplementedImmuServiceServer) SetDatabaseReadOnly(ctx context.Context, req *SetDatabaseReadOnlyRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDatabaseReadOnly not implemented")
 }
-func (*UnimplementedImmuServiceServer) ChangePermission(ctx context.Context, req *ChangePermissionRequest) (*Error, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ChangePermission not implemented")
+func (*UnimplementedImmuServiceServer) CorruptionCheckerStatus(ctx context.Context, req *CorruptionCheckerStatusRequest) (*CorruptionCheckerStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CorruptionCheckerStatus not implemented")
 }
-func (*UnimplementedImmuServiceServer) SetActiveUser(ctx context.Context, req *SetActiveUserRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetActiveUser not implemented")
+func (*UnimplementedImmuServiceServer) VerifyDatabase(req *VerifyDatabaseRequest, srv ImmuService_VerifyDatabaseServer) error {
+	return status.Errorf(codes.Unimplemented, "method VerifyDatabase not implemented")
 }
-func (*UnimplementedImmuServiceServer) DatabaseList(ctx context.Context, req *empty.Empty) (*DatabaseListResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DatabaseList not implemented")
+func (*UnimplementedImmuServiceServer) Backup(req *BackupRequest, srv ImmuService_BackupServer) error {
+	return status.Errorf(codes.Unimplemented, "method Backup not implemented")
 }
 
 func RegisterImmuServiceServer(s *grpc.Server, srv ImmuServiceServer) {
@@ -4689,6 +6299,300 @@ func _ImmuService_DatabaseList_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ImmuService_CreateRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).CreateRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/CreateRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).CreateRole(ctx, req.(*CreateRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImmuService_ListRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).ListRoles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/ListRoles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).ListRoles(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImmuService_GrantRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).GrantRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/GrantRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).GrantRole(ctx, req.(*RoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImmuService_RevokeRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).RevokeRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/RevokeRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).RevokeRole(ctx, req.(*RoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImmuService_UpdateRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).UpdateRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/UpdateRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).UpdateRole(ctx, req.(*UpdateRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImmuService_DeleteRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).DeleteRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/DeleteRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).DeleteRole(ctx, req.(*DeleteRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImmuService_ResolveTampering_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveTamperingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).ResolveTampering(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/ResolveTampering",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).ResolveTampering(ctx, req.(*ResolveTamperingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImmuService_UnloadDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Database)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).UnloadDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/UnloadDatabase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).UnloadDatabase(ctx, req.(*Database))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImmuService_LoadDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Database)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).LoadDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/LoadDatabase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).LoadDatabase(ctx, req.(*Database))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImmuService_RenameDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameDatabaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).RenameDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/RenameDatabase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).RenameDatabase(ctx, req.(*RenameDatabaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImmuService_DropDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Database)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).DropDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/DropDatabase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).DropDatabase(ctx, req.(*Database))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImmuService_UpdateDatabaseSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDatabaseSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).UpdateDatabaseSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/UpdateDatabaseSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).UpdateDatabaseSettings(ctx, req.(*UpdateDatabaseSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImmuService_SetDatabaseReadOnly_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDatabaseReadOnlyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).SetDatabaseReadOnly(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/SetDatabaseReadOnly",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).SetDatabaseReadOnly(ctx, req.(*SetDatabaseReadOnlyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImmuService_CorruptionCheckerStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CorruptionCheckerStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).CorruptionCheckerStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/CorruptionCheckerStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).CorruptionCheckerStatus(ctx, req.(*CorruptionCheckerStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImmuService_VerifyDatabase_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(VerifyDatabaseRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ImmuServiceServer).VerifyDatabase(m, &immuServiceVerifyDatabaseServer{stream})
+}
+
+type ImmuService_VerifyDatabaseServer interface {
+	Send(*VerifyDatabaseProgress) error
+	grpc.ServerStream
+}
+
+type immuServiceVerifyDatabaseServer struct {
+	grpc.ServerStream
+}
+
+func (x *immuServiceVerifyDatabaseServer) Send(m *VerifyDatabaseProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ImmuService_Backup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BackupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ImmuServiceServer).Backup(m, &immuServiceBackupServer{stream})
+}
+
+type ImmuService_BackupServer interface {
+	Send(*BackupChunk) error
+	grpc.ServerStream
+}
+
+type immuServiceBackupServer struct {
+	grpc.ServerStream
+}
+
+func (x *immuServiceBackupServer) Send(m *BackupChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _ImmuService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "immudb.schema.ImmuService",
 	HandlerType: (*ImmuServiceServer)(nil),
@@ -4885,6 +6789,62 @@ var _ImmuService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DatabaseList",
 			Handler:    _ImmuService_DatabaseList_Handler,
 		},
+		{
+			MethodName: "CreateRole",
+			Handler:    _ImmuService_CreateRole_Handler,
+		},
+		{
+			MethodName: "ListRoles",
+			Handler:    _ImmuService_ListRoles_Handler,
+		},
+		{
+			MethodName: "GrantRole",
+			Handler:    _ImmuService_GrantRole_Handler,
+		},
+		{
+			MethodName: "RevokeRole",
+			Handler:    _ImmuService_RevokeRole_Handler,
+		},
+		{
+			MethodName: "UpdateRole",
+			Handler:    _ImmuService_UpdateRole_Handler,
+		},
+		{
+			MethodName: "DeleteRole",
+			Handler:    _ImmuService_DeleteRole_Handler,
+		},
+		{
+			MethodName: "ResolveTampering",
+			Handler:    _ImmuService_ResolveTampering_Handler,
+		},
+		{
+			MethodName: "UnloadDatabase",
+			Handler:    _ImmuService_UnloadDatabase_Handler,
+		},
+		{
+			MethodName: "LoadDatabase",
+			Handler:    _ImmuService_LoadDatabase_Handler,
+		},
+		{
+			MethodName: "RenameDatabase",
+			Handler:    _ImmuService_RenameDatabase_Handler,
+		},
+		{
+			MethodName: "DropDatabase",
+			Handler:    _ImmuService_DropDatabase_Handler,
+		},
+		{
+			MethodName: "UpdateDatabaseSettings",
+			Handler:    _ImmuService_UpdateDatabaseSettings_Handler,
+		},
+		{
+			MethodName: "SetDatabaseReadOnly",
+			Handler:    _ImmuService_SetDatabaseReadOnly_Handler,
+		},
+		{
+			MethodName: "CorruptionCheckerStatus",
+			Handler:    _ImmuService_CorruptionCheckerStatus_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -4892,6 +6852,16 @@ var _ImmuService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _ImmuService_Dump_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "VerifyDatabase",
+			Handler:       _ImmuService_VerifyDatabase_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Backup",
+			Handler:       _ImmuService_Backup_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "schema.proto",
 }
@@ -628,6 +628,10 @@ func request_ImmuService_CreateDatabase_0(ctx context.Context, marshaler runtime
 
 }
 
+var (
+	filter_ImmuService_UseDatabase_0 = &utilities.DoubleArray{Encoding: map[string]int{"databasename": 0}, Base: []int{1, 1, 0}, Check: []int{0, 1, 2}}
+)
+
 func request_ImmuService_UseDatabase_0(ctx context.Context, marshaler runtime.Marshaler, client ImmuServiceClient, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
 	var protoReq Database
 	var metadata runtime.ServerMetadata
@@ -650,6 +654,10 @@ func request_ImmuService_UseDatabase_0(ctx context.Context, marshaler runtime.Ma
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "databasename", err)
 	}
 
+	if err := runtime.PopulateQueryParameters(&protoReq, req.URL.Query(), filter_ImmuService_UseDatabase_0); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
 	msg, err := client.UseDatabase(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 
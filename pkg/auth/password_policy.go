@@ -0,0 +1,179 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Character classes which can be required by a PasswordPolicy
+const (
+	CharClassUpper   = "upper"
+	CharClassLower   = "lower"
+	CharClassDigit   = "digit"
+	CharClassSpecial = "special"
+)
+
+var charClassDescriptions = map[string]string{
+	CharClassUpper:   "1 uppercase letter",
+	CharClassLower:   "1 lowercase letter",
+	CharClassDigit:   "1 digit",
+	CharClassSpecial: "1 special character",
+}
+
+// ErrPasswordReused returned when a new password matches one of the recently used ones
+var ErrPasswordReused = errors.New("password has been used recently: please choose a different one")
+
+// WarnPasswordExpired warning user message for the case when the password is past its maximum age
+var WarnPasswordExpired = "password has expired: please change it, any other command will be refused until then"
+
+// PasswordPolicy strength and rotation requirements for user passwords
+type PasswordPolicy struct {
+	MinLength     int           // minimum number of characters
+	MaxLength     int           // maximum number of characters
+	CharClasses   []string      // character classes which must appear at least once
+	HistoryDepth  int           // number of most recent passwords which can not be reused, 0 allows reuse
+	MaxAge        time.Duration // how long a password stays valid, 0 never expires
+	ExpiryWarning time.Duration // how long before the expiry Login starts warning the user
+}
+
+// DefaultPasswordPolicy returns the policy enforced when nothing else is configured
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:     minPasswordLen,
+		MaxLength:     maxPasswordLen,
+		CharClasses:   []string{CharClassUpper, CharClassDigit, CharClassSpecial},
+		HistoryDepth:  0,
+		MaxAge:        0,
+		ExpiryWarning: 7 * 24 * time.Hour,
+	}
+}
+
+// WithMinLength sets the minimum password length
+func (p PasswordPolicy) WithMinLength(minLength int) PasswordPolicy {
+	p.MinLength = minLength
+	return p
+}
+
+// WithMaxLength sets the maximum password length
+func (p PasswordPolicy) WithMaxLength(maxLength int) PasswordPolicy {
+	p.MaxLength = maxLength
+	return p
+}
+
+// WithCharClasses sets the character classes which must appear in a password
+func (p PasswordPolicy) WithCharClasses(charClasses []string) PasswordPolicy {
+	p.CharClasses = charClasses
+	return p
+}
+
+// WithHistoryDepth sets how many recent passwords can not be reused
+func (p PasswordPolicy) WithHistoryDepth(historyDepth int) PasswordPolicy {
+	p.HistoryDepth = historyDepth
+	return p
+}
+
+// WithMaxAge sets how long a password stays valid
+func (p PasswordPolicy) WithMaxAge(maxAge time.Duration) PasswordPolicy {
+	p.MaxAge = maxAge
+	return p
+}
+
+// WithExpiryWarning sets how long before the expiry users are warned at login
+func (p PasswordPolicy) WithExpiryWarning(expiryWarning time.Duration) PasswordPolicy {
+	p.ExpiryWarning = expiryWarning
+	return p
+}
+
+// Validate checks that the policy settings are consistent
+func (p PasswordPolicy) Validate() error {
+	if p.MinLength < 1 {
+		return fmt.Errorf("password minimum length must be at least 1")
+	}
+	if p.MaxLength < p.MinLength {
+		return fmt.Errorf("password maximum length %d is lower than the minimum length %d", p.MaxLength, p.MinLength)
+	}
+	if p.MaxLength > maxHashedPasswordLen {
+		return fmt.Errorf("password maximum length can not exceed %d", maxHashedPasswordLen)
+	}
+	for _, class := range p.CharClasses {
+		if _, ok := charClassDescriptions[class]; !ok {
+			return fmt.Errorf("unknown password character class %s, allowed classes are %s, %s, %s and %s",
+				class, CharClassUpper, CharClassLower, CharClassDigit, CharClassSpecial)
+		}
+	}
+	if p.HistoryDepth < 0 {
+		return fmt.Errorf("password history depth can not be negative")
+	}
+	if p.MaxAge < 0 || p.ExpiryWarning < 0 {
+		return fmt.Errorf("password maximum age and expiry warning can not be negative")
+	}
+	return nil
+}
+
+// Message describes the policy requirements to the user
+func (p PasswordPolicy) Message() string {
+	msg := fmt.Sprintf(
+		"password must have between %d and %d letters, digits and special characters",
+		p.MinLength,
+		p.MaxLength)
+	var classes []string
+	for _, class := range p.CharClasses {
+		classes = append(classes, charClassDescriptions[class])
+	}
+	switch len(classes) {
+	case 0:
+		return msg
+	case 1:
+		return msg + " of which at least " + classes[0]
+	}
+	return msg + " of which at least " +
+		strings.Join(classes[:len(classes)-1], ", ") + " and " + classes[len(classes)-1]
+}
+
+// Check verifies that the password meets the policy length and character requirements
+func (p PasswordPolicy) Check(password string) error {
+	err := errors.New(p.Message())
+	if len(password) < p.MinLength || len(password) > p.MaxLength {
+		return err
+	}
+	found := make(map[string]bool)
+	for _, ch := range password {
+		switch {
+		case unicode.IsUpper(ch):
+			found[CharClassUpper] = true
+		case unicode.IsLower(ch):
+			found[CharClassLower] = true
+		case unicode.IsDigit(ch):
+			found[CharClassDigit] = true
+		case unicode.IsPunct(ch) || unicode.IsSymbol(ch):
+			found[CharClassSpecial] = true
+		default:
+			return err
+		}
+	}
+	for _, class := range p.CharClasses {
+		if !found[class] {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+	p := DefaultPasswordPolicy()
+	if err := p.Validate(); err != nil {
+		t.Errorf("default password policy is not valid: %v", err)
+	}
+	if p.Message() != "password must have between 8 and 32 letters, digits and special characters "+
+		"of which at least 1 uppercase letter, 1 digit and 1 special character" {
+		t.Errorf("default password policy message mismatch: %s", p.Message())
+	}
+
+	p = p.WithMinLength(12).
+		WithMaxLength(64).
+		WithCharClasses([]string{CharClassLower, CharClassDigit}).
+		WithHistoryDepth(3).
+		WithMaxAge(time.Hour).
+		WithExpiryWarning(time.Minute)
+	if err := p.Validate(); err != nil {
+		t.Errorf("password policy is not valid: %v", err)
+	}
+	if err := p.Check("abcdefghij1"); err == nil {
+		t.Errorf("password shorter than the minimum length accepted")
+	}
+	if err := p.Check("abcdefghijkl"); err == nil {
+		t.Errorf("password without digits accepted")
+	}
+	if err := p.Check("abcdefghijk1"); err != nil {
+		t.Errorf("valid password refused: %v", err)
+	}
+
+	if err := p.WithCharClasses([]string{"emoji"}).Validate(); err == nil {
+		t.Errorf("unknown character class accepted")
+	}
+	if err := p.WithMaxLength(4).Validate(); err == nil {
+		t.Errorf("maximum length lower than minimum length accepted")
+	}
+	if err := p.WithMaxLength(73).Validate(); err == nil {
+		t.Errorf("maximum length longer than the hashed bytes accepted")
+	}
+	if err := p.WithHistoryDepth(-1).Validate(); err == nil {
+		t.Errorf("negative history depth accepted")
+	}
+}
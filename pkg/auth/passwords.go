@@ -18,10 +18,8 @@ package auth
 
 import (
 	"encoding/base64"
-	"errors"
 	"fmt"
 	"strings"
-	"unicode"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -43,40 +41,15 @@ func ComparePasswords(hashedPassword []byte, plainPassword []byte) error {
 const minPasswordLen = 8
 const maxPasswordLen = 32
 
+// maxHashedPasswordLen is the number of bytes bcrypt hashes, the following ones are ignored
+const maxHashedPasswordLen = 72
+
 // PasswordRequirementsMsg message used to inform the user about password strength requirements
-var PasswordRequirementsMsg = fmt.Sprintf(
-	"password must have between %d and %d letters, digits and special characters "+
-		"of which at least 1 uppercase letter, 1 digit and 1 special character",
-	minPasswordLen,
-	maxPasswordLen,
-)
+var PasswordRequirementsMsg = DefaultPasswordPolicy().Message()
 
-// IsStrongPassword checks if the provided password meets the strength requirements
+// IsStrongPassword checks if the provided password meets the default strength requirements
 func IsStrongPassword(password string) error {
-	err := errors.New(PasswordRequirementsMsg)
-	if len(password) < minPasswordLen || len(password) > maxPasswordLen {
-		return err
-	}
-	var hasUpper bool
-	var hasDigit bool
-	var hasSpecial bool
-	for _, ch := range password {
-		switch {
-		case unicode.IsUpper(ch):
-			hasUpper = true
-		case unicode.IsLower(ch):
-		case unicode.IsDigit(ch):
-			hasDigit = true
-		case unicode.IsPunct(ch) || unicode.IsSymbol(ch):
-			hasSpecial = true
-		default:
-			return err
-		}
-	}
-	if !hasUpper || !hasDigit || !hasSpecial {
-		return err
-	}
-	return nil
+	return DefaultPasswordPolicy().Check(password)
 }
 
 // DecodeBase64Password decodes the provided base64-encoded password if it has the
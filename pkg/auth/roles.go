@@ -0,0 +1,43 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"regexp"
+	"time"
+)
+
+// Role a named group of database permissions that can be assigned to users
+type Role struct {
+	Name        string       `json:"name"`
+	Permissions []Permission `json:"permissions"`
+	CreatedBy   string       `json:"createdBy"`         //user which created this role
+	CreatedAt   time.Time    `json:"createdat"`         //time in which this role is created
+	Deleted     bool         `json:"deleted,omitempty"` //roles can not be removed from the system database, only marked as deleted
+}
+
+// IsValidRoleName is a regexp function used to check role name requirements
+var IsValidRoleName = regexp.MustCompile(`^[a-zA-Z0-9_]+$`).MatchString
+
+// Databases returns the databases on which the role grants permissions
+func (r *Role) Databases() []string {
+	databases := make([]string, 0, len(r.Permissions))
+	for _, val := range r.Permissions {
+		databases = append(databases, val.Database)
+	}
+	return databases
+}
@@ -50,10 +50,6 @@ func ServerStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.S
 	if UpdateMetrics != nil {
 		UpdateMetrics(ctx)
 	}
-	if IsTampered {
-		return status.Errorf(
-			codes.DataLoss, "the database should be checked manually as we detected possible tampering")
-	}
 	if !AuthEnabled {
 		if !DevMode {
 			if !isLocalClient(ctx) {
@@ -71,10 +67,6 @@ func ServerUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.Una
 	if UpdateMetrics != nil {
 		UpdateMetrics(ctx)
 	}
-	if IsTampered {
-		return nil, status.Errorf(
-			codes.DataLoss, "the database should be checked manually as we detected possible tampering")
-	}
 	if !AuthEnabled {
 		if !DevMode {
 			if !isLocalClient(ctx) {
@@ -30,13 +30,18 @@ type Permission struct {
 
 // User ...
 type User struct {
-	Username       string       `json:"username"`
-	HashedPassword []byte       `json:"hashedpassword"`
-	Permissions    []Permission `json:"permissions"`
-	Active         bool         `json:"active"`
-	IsSysAdmin     bool         `json:"-"`         //for the sysadmin we'll use this instead of adding all db and permissions to Permissions, to save some cpu cycles
-	CreatedBy      string       `json:"createdBy"` //user which created this user
-	CreatedAt      time.Time    `json:"createdat"` //time in which this user is created/updated
+	Username          string       `json:"username"`
+	HashedPassword    []byte       `json:"hashedpassword"`
+	PasswordHistory   [][]byte     `json:"passwordhistory"`   //hashes of the previous passwords, most recent first
+	PasswordChangedAt time.Time    `json:"passwordchangedat"` //time in which the current password was set
+	Permissions       []Permission `json:"permissions"`
+	Roles             []string     `json:"roles"`
+	Active            bool         `json:"active"`
+	IsSysAdmin        bool         `json:"-"`         //for the sysadmin we'll use this instead of adding all db and permissions to Permissions, to save some cpu cycles
+	CreatedBy         string       `json:"createdBy"` //user which created this user
+	CreatedAt         time.Time    `json:"createdat"` //time in which this user is created/updated
+	RolePermissions   []Permission `json:"-"`         //permissions inherited from Roles, resolved when the user is loaded
+	PasswordExpired   bool         `json:"-"`         //set at login when the password is past its maximum age
 }
 
 // SysAdminUsername the system admin username
@@ -55,9 +60,49 @@ func (u *User) SetPassword(plainPassword []byte) ([]byte, error) {
 		return nil, err
 	}
 	u.HashedPassword = hashedPassword
+	u.PasswordChangedAt = time.Now()
 	return plainPassword, nil
 }
 
+//ChangePassword sets a new password refusing the historyDepth most recent ones
+func (u *User) ChangePassword(plainPassword []byte, historyDepth int) error {
+	var recent [][]byte
+	if len(u.HashedPassword) > 0 {
+		recent = append(recent, u.HashedPassword)
+	}
+	recent = append(recent, u.PasswordHistory...)
+	for i, hashedPassword := range recent {
+		if i >= historyDepth {
+			break
+		}
+		if ComparePasswords(hashedPassword, plainPassword) == nil {
+			return ErrPasswordReused
+		}
+	}
+	if _, err := u.SetPassword(plainPassword); err != nil {
+		return err
+	}
+	if historyDepth > 1 {
+		if len(recent) > historyDepth-1 {
+			recent = recent[:historyDepth-1]
+		}
+		u.PasswordHistory = recent
+	} else {
+		u.PasswordHistory = nil
+	}
+	return nil
+}
+
+//PasswordExpiresAt returns when the password exceeds maxAge. Users created before
+//the password change time was tracked fall back to their last update time
+func (u *User) PasswordExpiresAt(maxAge time.Duration) time.Time {
+	changedAt := u.PasswordChangedAt
+	if changedAt.IsZero() {
+		changedAt = u.CreatedAt
+	}
+	return changedAt.Add(maxAge)
+}
+
 // ComparePasswords ...
 func (u *User) ComparePasswords(plainPassword []byte) error {
 	return ComparePasswords(u.HashedPassword, plainPassword)
@@ -66,9 +111,33 @@ func (u *User) ComparePasswords(plainPassword []byte) error {
 // IsValidUsername is a regexp function used to check username requirements
 var IsValidUsername = regexp.MustCompile(`^[a-zA-Z0-9_]+$`).MatchString
 
+//EffectivePermissions returns the direct grants merged with the ones inherited from roles.
+//When several grants exist for the same database the strongest one wins
+func (u *User) EffectivePermissions() []Permission {
+	if len(u.RolePermissions) == 0 {
+		return u.Permissions
+	}
+	var permissions []Permission
+	indexes := make(map[string]int)
+	for _, list := range [][]Permission{u.Permissions, u.RolePermissions} {
+		for _, val := range list {
+			i, ok := indexes[val.Database]
+			if !ok {
+				indexes[val.Database] = len(permissions)
+				permissions = append(permissions, val)
+				continue
+			}
+			if val.Permission > permissions[i].Permission {
+				permissions[i].Permission = val.Permission
+			}
+		}
+	}
+	return permissions
+}
+
 //HasPermission checks if user has such permission for this database
 func (u *User) HasPermission(database string, permission uint32) bool {
-	for _, val := range u.Permissions {
+	for _, val := range u.EffectivePermissions() {
 		if (val.Database == database) &&
 			(val.Permission == permission) {
 			return true
@@ -79,7 +148,7 @@ func (u *User) HasPermission(database string, permission uint32) bool {
 
 //HasAtLeastOnePermission checks if user has this permission for at least one database
 func (u *User) HasAtLeastOnePermission(permission uint32) bool {
-	for _, val := range u.Permissions {
+	for _, val := range u.EffectivePermissions() {
 		if val.Permission == permission {
 			return true
 		}
@@ -92,7 +161,7 @@ func (u *User) WhichPermission(database string) uint32 {
 	if u.IsSysAdmin {
 		return PermissionSysAdmin
 	}
-	for _, val := range u.Permissions {
+	for _, val := range u.EffectivePermissions() {
 		if val.Database == database {
 			return val.Permission
 		}
@@ -121,3 +190,33 @@ func (u *User) GrantPermission(database string, permission uint32) bool {
 	u.Permissions = append(u.Permissions, perm)
 	return true
 }
+
+//HasRole checks if the role is assigned to the user
+func (u *User) HasRole(role string) bool {
+	for _, val := range u.Roles {
+		if val == role {
+			return true
+		}
+	}
+	return false
+}
+
+//GrantRole assigns the role to the user
+func (u *User) GrantRole(role string) bool {
+	if u.HasRole(role) {
+		return false
+	}
+	u.Roles = append(u.Roles, role)
+	return true
+}
+
+//RevokeRole removes the role from the user
+func (u *User) RevokeRole(role string) bool {
+	for i, val := range u.Roles {
+		if val == role {
+			u.Roles = append(u.Roles[:i], u.Roles[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -19,6 +19,7 @@ package auth
 import (
 	"bytes"
 	"testing"
+	"time"
 )
 
 func TestUser(t *testing.T) {
@@ -72,3 +73,76 @@ func TestUser(t *testing.T) {
 		t.Errorf("WhichPermission sysadmin fail")
 	}
 }
+
+func TestUserRoles(t *testing.T) {
+	u := User{}
+	u.GrantPermission("db1", PermissionR)
+	if !u.GrantRole("auditors") {
+		t.Errorf("GrantRole fail")
+	}
+	if u.GrantRole("auditors") {
+		t.Errorf("GrantRole should not assign the same role twice")
+	}
+	if !u.HasRole("auditors") {
+		t.Errorf("HasRole fail")
+	}
+	u.RolePermissions = []Permission{
+		{Database: "db1", Permission: PermissionAdmin},
+		{Database: "db2", Permission: PermissionRW},
+	}
+	if perm := u.WhichPermission("db1"); perm != PermissionAdmin {
+		t.Errorf("WhichPermission should return the strongest permission, got %d", perm)
+	}
+	if !u.HasPermission("db2", PermissionRW) {
+		t.Errorf("HasPermission should include role permissions")
+	}
+	if !u.HasAtLeastOnePermission(PermissionAdmin) {
+		t.Errorf("HasAtLeastOnePermission should include role permissions")
+	}
+	if len(u.EffectivePermissions()) != 2 {
+		t.Errorf("EffectivePermissions expected 2 entries, got %d", len(u.EffectivePermissions()))
+	}
+	if len(u.Permissions) != 1 {
+		t.Errorf("EffectivePermissions must not change direct grants")
+	}
+	if !u.RevokeRole("auditors") || u.HasRole("auditors") {
+		t.Errorf("RevokeRole fail")
+	}
+}
+
+func TestUserChangePassword(t *testing.T) {
+	u := User{}
+	if _, err := u.SetPassword([]byte("First1!pwd")); err != nil {
+		t.Fatalf("Error setting password %s", err)
+	}
+	if u.PasswordChangedAt.IsZero() {
+		t.Errorf("SetPassword did not record the change time")
+	}
+	if err := u.ChangePassword([]byte("First1!pwd"), 2); err != ErrPasswordReused {
+		t.Errorf("ChangePassword expected ErrPasswordReused, got %v", err)
+	}
+	if err := u.ChangePassword([]byte("Second2!pwd"), 2); err != nil {
+		t.Errorf("ChangePassword error %v", err)
+	}
+	if err := u.ChangePassword([]byte("First1!pwd"), 2); err != ErrPasswordReused {
+		t.Errorf("ChangePassword expected ErrPasswordReused, got %v", err)
+	}
+	if err := u.ChangePassword([]byte("Third3!pwd"), 2); err != nil {
+		t.Errorf("ChangePassword error %v", err)
+	}
+	if len(u.PasswordHistory) != 1 {
+		t.Errorf("ChangePassword expected 1 password in history, got %d", len(u.PasswordHistory))
+	}
+	if err := u.ChangePassword([]byte("First1!pwd"), 2); err != nil {
+		t.Errorf("ChangePassword refused a password older than the history depth: %v", err)
+	}
+	if err := u.ChangePassword([]byte("First1!pwd"), 0); err != nil {
+		t.Errorf("ChangePassword without history refused the current password: %v", err)
+	}
+
+	u.CreatedAt = time.Now().Add(-time.Hour)
+	u.PasswordChangedAt = time.Time{}
+	if !u.PasswordExpiresAt(time.Minute).Before(time.Now()) {
+		t.Errorf("PasswordExpiresAt expected to fall back to the creation time")
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+// AlertType identifies the event that raised an alert
+type AlertType string
+
+const (
+	// AlertTampering is raised when the consistency proof between the local and the remote root fails,
+	// when the remote root is not signed by the trusted server key or when a peer auditor observed a forked history
+	AlertTampering AlertType = "tampering"
+	// AlertEmptyDatabase is raised when the server reports an empty database while a local root exists
+	AlertEmptyDatabase AlertType = "empty_database"
+	// AlertConnectionFailure is raised after a number of consecutive audits failed to reach the server
+	AlertConnectionFailure AlertType = "connection_failure"
+)
+
+const (
+	// DefaultAlertMaxConnectionFailures is the default number of consecutive connection failures raising an alert
+	DefaultAlertMaxConnectionFailures = 3
+	alertTimeout                      = 10 * time.Second
+)
+
+// Alert describes an anomaly detected by the auditor
+type Alert struct {
+	Type          AlertType    `json:"type"`
+	Time          time.Time    `json:"time"`
+	Audit         uint64       `json:"audit"`
+	ServerID      string       `json:"serverId"`
+	ServerAddress string       `json:"serverAddress"`
+	Database      string       `json:"database,omitempty"`
+	Peer          string       `json:"peer,omitempty"`
+	Message       string       `json:"message"`
+	LocalRoot     *schema.Root `json:"localRoot,omitempty"`
+	RemoteRoot    *schema.Root `json:"remoteRoot,omitempty"`
+}
+
+// AlertSink delivers alerts raised by the auditor
+type AlertSink interface {
+	Send(alert Alert) error
+}
+
+// AlertOptions configures the alert sinks of the auditor
+type AlertOptions struct {
+	// WebhookURL receives a POST with the JSON encoded alert
+	WebhookURL string
+	// Command is executed with the JSON encoded alert on its standard input
+	Command string
+	// Syslog sends alerts to the local syslog daemon
+	Syslog bool
+	// MaxConnectionFailures is the number of consecutive connection failures after which an alert is raised
+	MaxConnectionFailures int
+}
+
+// DefaultAlertOptions ...
+func DefaultAlertOptions() AlertOptions {
+	return AlertOptions{
+		MaxConnectionFailures: DefaultAlertMaxConnectionFailures,
+	}
+}
+
+// WithWebhookURL sets the URL alerts are posted to
+func (o AlertOptions) WithWebhookURL(webhookURL string) AlertOptions {
+	o.WebhookURL = webhookURL
+	return o
+}
+
+// WithCommand sets the command executed on every alert
+func (o AlertOptions) WithCommand(command string) AlertOptions {
+	o.Command = command
+	return o
+}
+
+// WithSyslog enables sending alerts to syslog
+func (o AlertOptions) WithSyslog(syslog bool) AlertOptions {
+	o.Syslog = syslog
+	return o
+}
+
+// WithMaxConnectionFailures sets the number of consecutive connection failures after which an alert is raised
+func (o AlertOptions) WithMaxConnectionFailures(maxConnectionFailures int) AlertOptions {
+	o.MaxConnectionFailures = maxConnectionFailures
+	return o
+}
+
+// Sinks creates the alert sinks enabled by the options
+func (o AlertOptions) Sinks() ([]AlertSink, error) {
+	var sinks []AlertSink
+	if o.WebhookURL != "" {
+		sink, err := NewWebhookAlertSink(o.WebhookURL)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if o.Command != "" {
+		sink, err := NewCommandAlertSink(o.Command)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if o.Syslog {
+		sink, err := NewSyslogAlertSink()
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
+
+type webhookAlertSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookAlertSink returns a sink posting JSON encoded alerts to the given URL
+func NewWebhookAlertSink(webhookURL string) (AlertSink, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alert webhook URL %s: %v", webhookURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid alert webhook URL %s: scheme must be http or https", webhookURL)
+	}
+	return &webhookAlertSink{
+		url:    webhookURL,
+		client: &http.Client{Timeout: alertTimeout},
+	}, nil
+}
+
+func (s *webhookAlertSink) Send(alert Alert) error {
+	payload, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("alert webhook %s responded with %s", s.url, resp.Status)
+	}
+	return nil
+}
+
+type commandAlertSink struct {
+	name string
+	args []string
+}
+
+// NewCommandAlertSink returns a sink executing command on every alert. The command receives the JSON encoded alert
+// on its standard input and the main alert fields in the IMMUDB_ALERT_* environment variables.
+func NewCommandAlertSink(command string) (AlertSink, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty alert command")
+	}
+	return &commandAlertSink{name: fields[0], args: fields[1:]}, nil
+}
+
+func (s *commandAlertSink) Send(alert Alert) error {
+	payload, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), alertTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, s.name, s.args...)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(),
+		"IMMUDB_ALERT_TYPE="+string(alert.Type),
+		"IMMUDB_ALERT_SERVER_ID="+alert.ServerID,
+		"IMMUDB_ALERT_SERVER_ADDRESS="+alert.ServerAddress,
+		"IMMUDB_ALERT_DATABASE="+alert.Database,
+		"IMMUDB_ALERT_AUDIT="+strconv.FormatUint(alert.Audit, 10),
+		"IMMUDB_ALERT_MESSAGE="+alert.Message)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("alert command %s failed: %v: %s", s.name, err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+// alertSyslogMessage formats an alert as a single syslog line
+func alertSyslogMessage(alert Alert) string {
+	msg := fmt.Sprintf("immudb audit alert %s: %s (server %s @ %s", alert.Type, alert.Message, alert.ServerID, alert.ServerAddress)
+	if alert.Database != "" {
+		msg += ", database " + alert.Database
+	}
+	return msg + ")"
+}
@@ -0,0 +1,40 @@
+// +build !windows,!plan9
+
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditor
+
+import (
+	"log/syslog"
+)
+
+type syslogAlertSink struct {
+	writer *syslog.Writer
+}
+
+// NewSyslogAlertSink returns a sink writing alerts to the local syslog daemon
+func NewSyslogAlertSink() (AlertSink, error) {
+	w, err := syslog.New(syslog.LOG_ALERT|syslog.LOG_DAEMON, "immudb-auditor")
+	if err != nil {
+		return nil, err
+	}
+	return &syslogAlertSink{writer: w}, nil
+}
+
+func (s *syslogAlertSink) Send(alert Alert) error {
+	return s.writer.Alert(alertSyslogMessage(alert))
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditor
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingAlertSink struct {
+	alerts []Alert
+}
+
+func (s *recordingAlertSink) Send(alert Alert) error {
+	s.alerts = append(s.alerts, alert)
+	return nil
+}
+
+func TestWebhookAlertSink(t *testing.T) {
+	var received Alert
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer srv.Close()
+
+	sink, err := NewWebhookAlertSink(srv.URL)
+	assert.Nil(t, err)
+	assert.Nil(t, sink.Send(Alert{Type: AlertTampering, ServerID: "srv", Database: "defaultdb", Message: "boom"}))
+	assert.Equal(t, AlertTampering, received.Type)
+	assert.Equal(t, "srv", received.ServerID)
+	assert.Equal(t, "defaultdb", received.Database)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	sink, err = NewWebhookAlertSink(failing.URL)
+	assert.Nil(t, err)
+	assert.Error(t, sink.Send(Alert{Type: AlertTampering}))
+
+	_, err = NewWebhookAlertSink("ftp://localhost")
+	assert.Error(t, err)
+}
+
+func TestCommandAlertSink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script sink not available on windows")
+	}
+	dir, err := ioutil.TempDir("", "immudb_alert")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	out := filepath.Join(dir, "alert.json")
+	script := filepath.Join(dir, "alert.sh")
+	assert.Nil(t, ioutil.WriteFile(script, []byte("#!/bin/sh\n[ \"$IMMUDB_ALERT_TYPE\" = \"empty_database\" ] || exit 1\ncat > "+out+"\n"), 0700))
+
+	sink, err := NewCommandAlertSink(script)
+	assert.Nil(t, err)
+	assert.Nil(t, sink.Send(Alert{Type: AlertEmptyDatabase, Database: "defaultdb"}))
+	content, err := ioutil.ReadFile(out)
+	assert.Nil(t, err)
+	var alert Alert
+	assert.Nil(t, json.Unmarshal(content, &alert))
+	assert.Equal(t, "defaultdb", alert.Database)
+
+	assert.Error(t, sink.Send(Alert{Type: AlertTampering}))
+
+	_, err = NewCommandAlertSink("  ")
+	assert.Error(t, err)
+}
+
+func TestAlertOptionsSinks(t *testing.T) {
+	sinks, err := DefaultAlertOptions().Sinks()
+	assert.Nil(t, err)
+	assert.Empty(t, sinks)
+
+	sinks, err = DefaultAlertOptions().WithWebhookURL("http://localhost:1234").WithCommand("true").Sinks()
+	assert.Nil(t, err)
+	assert.Len(t, sinks, 2)
+
+	_, err = DefaultAlertOptions().WithWebhookURL("localhost").Sinks()
+	assert.Error(t, err)
+}
+
+func TestAuditorConnectionFailureAlert(t *testing.T) {
+	sink := &recordingAlertSink{}
+	a := &defaultAuditor{
+		logger:                logger.NewSimpleLogger("auditor", ioutil.Discard),
+		serverAddress:         "127.0.0.1:3322",
+		alertSinks:            []AlertSink{sink},
+		maxConnectionFailures: 2,
+	}
+	a.connectionFailed(errors.New("unreachable"))
+	assert.Empty(t, sink.alerts)
+	a.connectionFailed(errors.New("unreachable"))
+	assert.Len(t, sink.alerts, 1)
+	assert.Equal(t, AlertConnectionFailure, sink.alerts[0].Type)
+	assert.Equal(t, "127.0.0.1:3322", sink.alerts[0].ServerAddress)
+	a.connectionFailed(errors.New("unreachable"))
+	assert.Len(t, sink.alerts, 1)
+
+	a.connectionFailures = 0
+	a.maxConnectionFailures = 0
+	a.connectionFailed(errors.New("unreachable"))
+	assert.Len(t, sink.alerts, 1)
+}
@@ -17,8 +17,12 @@ limitations under the License.
 package auditor
 
 import (
+	"bytes"
 	"context"
+	"crypto"
+	"fmt"
 	"io"
+	"math/rand"
 	"os"
 	"regexp"
 	"strings"
@@ -52,7 +56,18 @@ type defaultAuditor struct {
 	databases     []string
 	password      []byte
 	slugifyRegExp *regexp.Regexp
-	updateMetrics func(string, string, bool, bool, bool, *schema.Root, *schema.Root)
+	updateMetrics func(string, string, bool, bool, bool, *schema.Root, *schema.Root, *SampleResult)
+	// serverSigningPubKey, if not nil, is the pinned key the roots returned by the server must be signed with
+	serverSigningPubKey crypto.PublicKey
+	// alertSinks are notified of tampering, empty databases and repeated connection failures
+	alertSinks            []AlertSink
+	maxConnectionFailures int
+	connectionFailures    int
+	// gossip, if not nil, publishes the verified roots and cross-checks them with the ones of peer auditors
+	gossip *gossip
+	// sampleSize is the number of random entries verified on every audit
+	sampleSize int
+	rnd        *rand.Rand
 }
 
 // DefaultAuditor creates initializes a default auditor implementation
@@ -63,8 +78,9 @@ func DefaultAuditor(
 	username string,
 	passwordBase64 string,
 	history cache.HistoryCache,
-	updateMetrics func(string, string, bool, bool, bool, *schema.Root, *schema.Root),
-	logoutput io.Writer) (Auditor, error) {
+	updateMetrics func(string, string, bool, bool, bool, *schema.Root, *schema.Root, *SampleResult),
+	logoutput io.Writer,
+	opts Options) (Auditor, error) {
 
 	password, err := auth.DecodeBase64Password(passwordBase64)
 	if err != nil {
@@ -82,6 +98,14 @@ func DefaultAuditor(
 	if err != nil {
 		logr.Warningf("error compiling regex for slugifier: %v", err)
 	}
+	alertSinks, err := opts.Alerts.Sinks()
+	if err != nil {
+		return nil, err
+	}
+	g, err := newGossip(opts.Gossip, logr)
+	if err != nil {
+		return nil, err
+	}
 	return &defaultAuditor{
 		0,
 		0,
@@ -95,6 +119,13 @@ func DefaultAuditor(
 		[]byte(password),
 		slugifyRegExp,
 		updateMetrics,
+		opts.ServerSigningPubKey,
+		alertSinks,
+		opts.Alerts.MaxConnectionFailures,
+		0,
+		g,
+		opts.SampleSize,
+		rand.New(rand.NewSource(time.Now().UnixNano())),
 	}, nil
 }
 
@@ -106,6 +137,12 @@ func (a *defaultAuditor) Run(
 ) (err error) {
 	defer func() { donec <- struct{}{} }()
 	a.logger.Infof("starting auditor with a %s interval ...", interval)
+	if a.gossip != nil {
+		if err = a.gossip.start(); err != nil {
+			return err
+		}
+		defer a.gossip.stop()
+	}
 	if singleRun {
 		err = a.audit()
 	} else {
@@ -129,9 +166,10 @@ func (a *defaultAuditor) audit() error {
 	serverID := "unknown"
 	var prevRoot *schema.Root
 	var root *schema.Root
+	var sample *SampleResult
 	defer func() {
 		a.updateMetrics(
-			serverID, a.serverAddress, checked, withError, verified, prevRoot, root)
+			serverID, a.serverAddress, checked, withError, verified, prevRoot, root, sample)
 	}()
 
 	// returning an error would completely stop the auditor process
@@ -141,6 +179,7 @@ func (a *defaultAuditor) audit() error {
 	conn, err := a.connect(ctx)
 	if err != nil {
 		withError = true
+		a.connectionFailed(err)
 		return noErr
 	}
 	defer a.closeConnection(conn)
@@ -174,6 +213,7 @@ func (a *defaultAuditor) audit() error {
 	if err != nil {
 		a.logger.Errorf("", err)
 		withError = true
+		a.connectionFailed(err)
 		return noErr
 	}
 	serviceClient = schema.NewImmuServiceClient(conn)
@@ -187,10 +227,27 @@ func (a *defaultAuditor) audit() error {
 		withError = true
 		return noErr
 	}
+	a.connectionFailures = 0
 
 	isEmptyDB := len(root.GetRoot()) == 0 && root.GetIndex() == 0
 
 	serverID = a.getServerID(ctx, serviceClient)
+	if a.serverSigningPubKey != nil {
+		if err := client.VerifyRootSignature(a.serverSigningPubKey, serverID, dbName, root); err != nil {
+			a.logger.Errorf(
+				"audit #%d detected an untrusted root at index %d of database %s on server %s @ %s: %v",
+				a.index, root.GetIndex(), dbName, serverID, a.serverAddress, err)
+			verified = false
+			a.alert(Alert{
+				Type:       AlertTampering,
+				ServerID:   serverID,
+				Database:   dbName,
+				Message:    fmt.Sprintf("untrusted root at index %d: %v", root.GetIndex(), err),
+				RemoteRoot: root,
+			})
+			return noErr
+		}
+	}
 	prevRoot, err = a.history.Get(serverID, dbName)
 	if err != nil {
 		a.logger.Errorf(err.Error())
@@ -204,6 +261,15 @@ func (a *defaultAuditor) audit() error {
 					"but locally a previous root exists with hash %x at index %d",
 				a.index, serverID, a.serverAddress, prevRoot.Root, prevRoot.Index)
 			withError = true
+			a.alert(Alert{
+				Type:     AlertEmptyDatabase,
+				ServerID: serverID,
+				Database: dbName,
+				Message: fmt.Sprintf("database is empty but locally a previous root exists with hash %x at index %d",
+					prevRoot.Root, prevRoot.Index),
+				LocalRoot:  prevRoot,
+				RemoteRoot: root,
+			})
 			return noErr
 		}
 		proof, err := serviceClient.Consistency(ctx, &schema.Index{
@@ -226,7 +292,10 @@ func (a *defaultAuditor) audit() error {
 		a.logger.Infof("audit #%d result:\n  consistent:	%t\n"+
 			"  firstRoot:	%x at index: %d\n  secondRoot:	%x at index: %d",
 			a.index, verified, firstRoot, proof.First, proof.SecondRoot, proof.Second)
-		root = &schema.Root{Index: proof.Second, Root: proof.SecondRoot}
+		// keep the signed root if the proof refers to it
+		if proof.Second != root.GetIndex() || !bytes.Equal(proof.SecondRoot, root.GetRoot()) {
+			root = &schema.Root{Index: proof.Second, Root: proof.SecondRoot}
+		}
 		checked = true
 	} else if isEmptyDB {
 		a.logger.Warningf("audit #%d canceled: database is empty on server %s @ %s",
@@ -234,23 +303,114 @@ func (a *defaultAuditor) audit() error {
 		return noErr
 	}
 
+	// forks observed by peer auditors and tampered sampled entries are reported by crossCheck and verifySample
+	reported := false
+	if verified && a.gossip != nil {
+		verified = a.crossCheck(ctx, serviceClient, serverID, dbName, root)
+		reported = !verified
+	}
+	if verified && a.sampleSize > 0 {
+		sample = a.verifySample(ctx, serviceClient, serverID, dbName, root)
+		verified = sample.Failed == 0
+		reported = !verified
+	}
+
 	if !verified {
 		a.logger.Warningf(
 			"audit #%d detected possible tampering of remote root (at index %d) "+
 				"so it will not overwrite the previous local root (at index %d)",
 			a.index, root.GetIndex(), prevRoot.GetIndex())
+		if !reported {
+			a.alert(Alert{
+				Type:     AlertTampering,
+				ServerID: serverID,
+				Database: dbName,
+				Message: fmt.Sprintf("consistency proof between local root at index %d and remote root at index %d failed",
+					prevRoot.GetIndex(), root.GetIndex()),
+				LocalRoot:  prevRoot,
+				RemoteRoot: root,
+			})
+		}
 	} else if prevRoot == nil || root.GetIndex() != prevRoot.GetIndex() {
 		if err := a.history.Set(root, serverID, dbName); err != nil {
 			a.logger.Errorf(err.Error())
 			return noErr
 		}
 	}
+	if verified && a.gossip != nil {
+		a.gossip.observe(serverID, dbName, root)
+	}
 	a.logger.Infof("audit #%d finished in %s @ %s",
 		a.index, time.Since(start), time.Now().Format(time.RFC3339Nano))
 
 	return noErr
 }
 
+// crossCheck verifies that the roots observed by the peer auditors for the same database belong to the history of root
+func (a *defaultAuditor) crossCheck(
+	ctx context.Context,
+	serviceClient schema.ImmuServiceClient,
+	serverID string,
+	dbName string,
+	root *schema.Root,
+) bool {
+	consistent := true
+	for peer, observations := range a.gossip.peerObservations(serverID, dbName) {
+		for _, o := range observations {
+			peerRoot := &schema.Root{Index: o.Index, Root: o.Root, Signature: o.ServerSignature}
+			ok, err := client.VerifyRootsConsistency(ctx, serviceClient, root, peerRoot)
+			if err != nil {
+				a.logger.Errorf(
+					"error checking root at index %d observed by auditor %s: %v", o.Index, peer, err)
+				continue
+			}
+			if ok {
+				continue
+			}
+			consistent = false
+			a.logger.Errorf(
+				"audit #%d detected a fork of database %s on server %s @ %s: "+
+					"root %x at index %d is not consistent with root %x at index %d observed by auditor %s",
+				a.index, dbName, serverID, a.serverAddress, root.GetRoot(), root.GetIndex(), o.Root, o.Index, peer)
+			a.alert(Alert{
+				Type:     AlertTampering,
+				ServerID: serverID,
+				Database: dbName,
+				Peer:     peer,
+				Message: fmt.Sprintf("fork detected: root at index %d is not consistent with root at index %d observed by auditor %s",
+					root.GetIndex(), o.Index, peer),
+				LocalRoot:  root,
+				RemoteRoot: peerRoot,
+			})
+		}
+	}
+	return consistent
+}
+
+// connectionFailed raises an alert once the server could not be reached for maxConnectionFailures consecutive audits
+func (a *defaultAuditor) connectionFailed(err error) {
+	a.connectionFailures++
+	if a.maxConnectionFailures <= 0 || a.connectionFailures != a.maxConnectionFailures {
+		return
+	}
+	a.alert(Alert{
+		Type:    AlertConnectionFailure,
+		Message: fmt.Sprintf("%d consecutive audits failed to reach the server: %v", a.connectionFailures, err),
+	})
+}
+
+// alert fills in the common fields of the alert and sends it to all sinks
+func (a *defaultAuditor) alert(alert Alert) {
+	alert.Time = time.Now()
+	alert.Audit = a.index
+	alert.ServerAddress = a.serverAddress
+	for _, sink := range a.alertSinks {
+		if err := sink.Send(alert); err != nil {
+			a.logger.Errorf("error sending %s alert: %v", alert.Type, err)
+		}
+	}
+}
+
 func (a *defaultAuditor) connect(ctx context.Context) (*grpc.ClientConn, error) {
 	conn, err := grpc.Dial(a.serverAddress, a.dialOptions...)
 	if err != nil {
@@ -95,8 +95,9 @@ func makeAuditor() (Auditor, error) {
 		"immudb",
 		"immudb",
 		cache.NewHistoryFileCache(dirname),
-		func(string, string, bool, bool, bool, *schema.Root, *schema.Root) {},
-		nil)
+		func(string, string, bool, bool, bool, *schema.Root, *schema.Root, *SampleResult) {},
+		nil,
+		DefaultOptions())
 	return da, err
 }
 
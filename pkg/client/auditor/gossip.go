@@ -0,0 +1,311 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/codenotary/immudb/pkg/signer"
+)
+
+// GossipPath is the HTTP path auditors publish their observed roots on
+const GossipPath = "/observations"
+
+const (
+	gossipTimeout          = 10 * time.Second
+	gossipSignaturePrefix  = "immudb-observations-v1\n"
+	gossipMaxDocumentBytes = 8 << 20
+)
+
+// ErrUntrustedAuditor is returned when peer observations are not signed by a trusted auditor key
+var ErrUntrustedAuditor = errors.New("observations are not signed by a trusted auditor")
+
+// GossipOptions configures the exchange of observed roots among auditors
+type GossipOptions struct {
+	// Address the observed roots are published on, e.g. ":9478"; publishing is disabled if empty
+	Address string
+	// SigningKey is the path of the PEM encoded private key the published observations are signed with
+	SigningKey string
+	// Peers are the base URLs of the auditors whose observations are cross-checked
+	Peers []string
+	// TrustedKeys are the paths of the PEM encoded public keys of the peers; any peer key is accepted if empty
+	TrustedKeys []string
+}
+
+// WithAddress sets the address the observed roots are published on
+func (o GossipOptions) WithAddress(address string) GossipOptions {
+	o.Address = address
+	return o
+}
+
+// WithSigningKey sets the path of the private key the published observations are signed with
+func (o GossipOptions) WithSigningKey(signingKey string) GossipOptions {
+	o.SigningKey = signingKey
+	return o
+}
+
+// WithPeers sets the base URLs of the peer auditors
+func (o GossipOptions) WithPeers(peers []string) GossipOptions {
+	o.Peers = peers
+	return o
+}
+
+// WithTrustedKeys sets the paths of the public keys the peer observations must be signed with
+func (o GossipOptions) WithTrustedKeys(trustedKeys []string) GossipOptions {
+	o.TrustedKeys = trustedKeys
+	return o
+}
+
+// Enabled returns true if observations are either published or fetched from peers
+func (o GossipOptions) Enabled() bool {
+	return o.Address != "" || len(o.Peers) > 0
+}
+
+// Observation is a root an auditor verified for a database of a server
+type Observation struct {
+	ServerID        string            `json:"serverId"`
+	Database        string            `json:"database"`
+	Index           uint64            `json:"index"`
+	Root            []byte            `json:"root"`
+	ServerSignature *schema.Signature `json:"serverSignature,omitempty"`
+	Observed        time.Time         `json:"observed"`
+}
+
+// ObservationsDocument is the signed list of observations published by an auditor
+type ObservationsDocument struct {
+	PublicKey []byte `json:"publicKey"`
+	Payload   []byte `json:"payload"`
+	Signature []byte `json:"signature"`
+}
+
+type gossip struct {
+	sync.RWMutex
+	address      string
+	signer       signer.Signer
+	publicKey    []byte
+	peers        []string
+	trustedKeys  [][]byte
+	observations map[string]Observation
+	client       *http.Client
+	server       *http.Server
+	logger       logger.Logger
+}
+
+func newGossip(opts GossipOptions, log logger.Logger) (*gossip, error) {
+	if !opts.Enabled() {
+		return nil, nil
+	}
+	g := &gossip{
+		address:      opts.Address,
+		peers:        opts.Peers,
+		observations: map[string]Observation{},
+		client:       &http.Client{Timeout: gossipTimeout},
+		logger:       log,
+	}
+	if opts.Address != "" {
+		if opts.SigningKey == "" {
+			return nil, errors.New("a signing key is required to publish observed roots")
+		}
+		s, err := signer.NewSigner(opts.SigningKey)
+		if err != nil {
+			return nil, err
+		}
+		if g.publicKey, err = signer.MarshalPublicKey(s.PublicKey()); err != nil {
+			return nil, err
+		}
+		g.signer = s
+	}
+	for _, path := range opts.TrustedKeys {
+		key, err := signer.LoadPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+		der, err := signer.MarshalPublicKey(key)
+		if err != nil {
+			return nil, err
+		}
+		g.trustedKeys = append(g.trustedKeys, der)
+	}
+	return g, nil
+}
+
+func observationKey(serverID string, database string) string {
+	return serverID + "/" + database
+}
+
+// observe records the latest root verified for a database of a server
+func (g *gossip) observe(serverID string, database string, root *schema.Root) {
+	g.Lock()
+	defer g.Unlock()
+	g.observations[observationKey(serverID, database)] = Observation{
+		ServerID:        serverID,
+		Database:        database,
+		Index:           root.GetIndex(),
+		Root:            root.GetRoot(),
+		ServerSignature: root.GetSignature(),
+		Observed:        time.Now().UTC(),
+	}
+}
+
+// document returns the signed list of the current observations
+func (g *gossip) document() (*ObservationsDocument, error) {
+	g.RLock()
+	observations := make([]Observation, 0, len(g.observations))
+	for _, o := range g.observations {
+		observations = append(observations, o)
+	}
+	g.RUnlock()
+	payload, err := json.Marshal(observations)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := g.signer.Sign(append([]byte(gossipSignaturePrefix), payload...))
+	if err != nil {
+		return nil, err
+	}
+	return &ObservationsDocument{
+		PublicKey: g.publicKey,
+		Payload:   payload,
+		Signature: signature,
+	}, nil
+}
+
+func (g *gossip) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(GossipPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		doc, err := g.document()
+		if err != nil {
+			g.logger.Errorf("error signing observed roots: %v", err)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(doc); err != nil {
+			g.logger.Errorf("error publishing observed roots: %v", err)
+		}
+	})
+	return mux
+}
+
+// start publishes the observed roots, if an address is configured
+func (g *gossip) start() error {
+	if g.address == "" {
+		return nil
+	}
+	l, err := net.Listen("tcp", g.address)
+	if err != nil {
+		return err
+	}
+	g.server = &http.Server{Handler: g.handler()}
+	go func() {
+		if err := g.server.Serve(l); err != nil && err != http.ErrServerClosed {
+			g.logger.Errorf("error publishing observed roots: %v", err)
+		}
+	}()
+	g.logger.Infof("publishing observed roots on %s%s", l.Addr(), GossipPath)
+	return nil
+}
+
+func (g *gossip) stop() {
+	if g.server == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), gossipTimeout)
+	defer cancel()
+	if err := g.server.Shutdown(ctx); err != nil {
+		g.logger.Errorf("error stopping the publication of observed roots: %v", err)
+	}
+}
+
+// fetch downloads and verifies the observations published by a peer
+func (g *gossip) fetch(peer string) ([]Observation, error) {
+	resp, err := g.client.Get(strings.TrimSuffix(peer, "/") + GossipPath)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("peer responded with %s", resp.Status)
+	}
+	var doc ObservationsDocument
+	if err := json.NewDecoder(io.LimitReader(resp.Body, gossipMaxDocumentBytes)).Decode(&doc); err != nil {
+		return nil, err
+	}
+	return g.verify(&doc)
+}
+
+// verify checks the signature of a peer document and returns its observations
+func (g *gossip) verify(doc *ObservationsDocument) ([]Observation, error) {
+	if len(g.trustedKeys) > 0 {
+		trusted := false
+		for _, k := range g.trustedKeys {
+			if bytes.Equal(k, doc.PublicKey) {
+				trusted = true
+				break
+			}
+		}
+		if !trusted {
+			return nil, ErrUntrustedAuditor
+		}
+	}
+	key, err := signer.ParsePublicKey(doc.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := signer.Verify(key, append([]byte(gossipSignaturePrefix), doc.Payload...), doc.Signature); err != nil {
+		return nil, err
+	}
+	var observations []Observation
+	if err := json.Unmarshal(doc.Payload, &observations); err != nil {
+		return nil, err
+	}
+	return observations, nil
+}
+
+// peerObservations returns, for each peer, the observations of the given database of a server
+func (g *gossip) peerObservations(serverID string, database string) map[string][]Observation {
+	result := map[string][]Observation{}
+	for _, peer := range g.peers {
+		observations, err := g.fetch(peer)
+		if err != nil {
+			g.logger.Warningf("error fetching observed roots from auditor %s: %v", peer, err)
+			continue
+		}
+		for _, o := range observations {
+			if o.ServerID == serverID && o.Database == database {
+				result[peer] = append(result[peer], o)
+			}
+		}
+	}
+	return result
+}
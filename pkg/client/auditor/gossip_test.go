@@ -0,0 +1,134 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditor
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/client/timestamp"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestKeyPair(t *testing.T, dir string, name string) (string, string) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.Nil(t, err)
+	pubDer, err := x509.MarshalPKIXPublicKey(pub)
+	assert.Nil(t, err)
+	keyPath := filepath.Join(dir, name+".key.pem")
+	pubPath := filepath.Join(dir, name+".pub.pem")
+	assert.Nil(t, ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}), 0600))
+	assert.Nil(t, ioutil.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDer}), 0644))
+	return keyPath, pubPath
+}
+
+func TestGossipObservations(t *testing.T) {
+	dir, err := ioutil.TempDir("", "immudb_gossip")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	key1, pub1 := writeTestKeyPair(t, dir, "auditor1")
+	_, pub2 := writeTestKeyPair(t, dir, "auditor2")
+	log := logger.NewSimpleLogger("gossip", ioutil.Discard)
+
+	g, err := newGossip(GossipOptions{}, log)
+	assert.Nil(t, err)
+	assert.Nil(t, g)
+	_, err = newGossip(GossipOptions{}.WithAddress(":0"), log)
+	assert.Error(t, err)
+
+	g1, err := newGossip(GossipOptions{}.WithAddress(":0").WithSigningKey(key1), log)
+	assert.Nil(t, err)
+	g1.observe("server1", "defaultdb", &schema.Root{Index: 3, Root: []byte("root3")})
+	g1.observe("server1", "otherdb", &schema.Root{Index: 5, Root: []byte("root5")})
+	srv := httptest.NewServer(g1.handler())
+	defer srv.Close()
+
+	g2, err := newGossip(GossipOptions{}.WithPeers([]string{srv.URL + "/"}).WithTrustedKeys([]string{pub1}), log)
+	assert.Nil(t, err)
+	observations := g2.peerObservations("server1", "defaultdb")
+	assert.Len(t, observations[srv.URL+"/"], 1)
+	assert.Equal(t, uint64(3), observations[srv.URL+"/"][0].Index)
+	assert.Equal(t, []byte("root3"), observations[srv.URL+"/"][0].Root)
+	assert.Empty(t, g2.peerObservations("server2", "defaultdb"))
+
+	g3, err := newGossip(GossipOptions{}.WithPeers([]string{srv.URL}).WithTrustedKeys([]string{pub2}), log)
+	assert.Nil(t, err)
+	assert.Empty(t, g3.peerObservations("server1", "defaultdb"))
+	_, err = g3.fetch(srv.URL)
+	assert.Equal(t, ErrUntrustedAuditor, err)
+
+	doc, err := g1.document()
+	assert.Nil(t, err)
+	doc.Payload = []byte(`[{"serverId":"server1","database":"defaultdb","index":3,"root":"Zm9yZ2Vk"}]`)
+	_, err = g2.verify(doc)
+	assert.Error(t, err)
+}
+
+func TestVerifyRootsConsistency(t *testing.T) {
+	immuServer := newServer()
+	immuServer.Start()
+	defer os.RemoveAll(dirname)
+	nm, _ := timestamp.NewTdefault()
+	tss := client.NewTimestampService(nm)
+	cli := newClient(true, login()).WithTimestampService(tss)
+	resp, err := cli.UseDatabase(context.Background(), &schema.Database{
+		Databasename: immuServer.Options.GetDefaultDbName(),
+	})
+	assert.Nil(t, err)
+	cli = newClient(true, resp.Token).WithTimestampService(tss)
+	serviceClient := *cli.GetServiceClient()
+
+	ctx := context.Background()
+	_, err = cli.Set(ctx, []byte("key1"), []byte("val1"))
+	assert.Nil(t, err)
+	root1, err := cli.CurrentRoot(ctx)
+	assert.Nil(t, err)
+	_, err = cli.Set(ctx, []byte("key2"), []byte("val2"))
+	assert.Nil(t, err)
+	_, err = cli.Set(ctx, []byte("key3"), []byte("val3"))
+	assert.Nil(t, err)
+	root3, err := cli.CurrentRoot(ctx)
+	assert.Nil(t, err)
+
+	ok, err := client.VerifyRootsConsistency(ctx, serviceClient, root1, root3)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	ok, err = client.VerifyRootsConsistency(ctx, serviceClient, root3, root1)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	forked := &schema.Root{Index: root1.GetIndex(), Root: make([]byte, len(root1.GetRoot()))}
+	ok, err = client.VerifyRootsConsistency(ctx, serviceClient, forked, root3)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	ok, err = client.VerifyRootsConsistency(ctx, serviceClient, &schema.Root{Index: root3.GetIndex(), Root: forked.Root}, root3)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditor
+
+import "crypto"
+
+// Options are the optional settings of the default auditor
+type Options struct {
+	// ServerSigningPubKey, if not nil, is the pinned key the roots returned by the server must be signed with
+	ServerSigningPubKey crypto.PublicKey
+	// Alerts configures where tampering, empty databases and repeated connection failures are notified
+	Alerts AlertOptions
+	// Gossip configures publishing the verified roots and cross-checking them with the ones of peer auditors
+	Gossip GossipOptions
+	// SampleSize is the number of random entries verified on every audit
+	SampleSize int
+}
+
+// DefaultOptions ...
+func DefaultOptions() Options {
+	return Options{
+		Alerts: DefaultAlertOptions(),
+	}
+}
+
+// WithServerSigningPubKey sets the key the roots returned by the server must be signed with
+func (o Options) WithServerSigningPubKey(serverSigningPubKey crypto.PublicKey) Options {
+	o.ServerSigningPubKey = serverSigningPubKey
+	return o
+}
+
+// WithAlerts sets the alert options
+func (o Options) WithAlerts(alerts AlertOptions) Options {
+	o.Alerts = alerts
+	return o
+}
+
+// WithGossip sets the gossip options
+func (o Options) WithGossip(gossip GossipOptions) Options {
+	o.Gossip = gossip
+	return o
+}
+
+// WithSampleSize sets the number of random entries verified on every audit
+func (o Options) WithSampleSize(sampleSize int) Options {
+	o.SampleSize = sampleSize
+	return o
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditor
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+// SampleResult is the outcome of the verification of a random sample of entries
+type SampleResult struct {
+	// Size is the number of entries sampled
+	Size int
+	// Verified is the number of entries whose inclusion proof and leaf hash have been verified
+	Verified int
+	// Failed is the number of entries whose inclusion proof or leaf hash did not verify
+	Failed int
+	// Errors is the number of entries that could not be fetched
+	Errors int
+	// FailedIndexes are the indexes of the entries that did not verify
+	FailedIndexes []uint64
+}
+
+// sampleIndexes returns up to n distinct random indexes in [0, last]
+func sampleIndexes(rnd *rand.Rand, n int, last uint64) []uint64 {
+	if n <= 0 {
+		return nil
+	}
+	if uint64(n) > last {
+		indexes := make([]uint64, 0, last+1)
+		for i := uint64(0); i <= last; i++ {
+			indexes = append(indexes, i)
+		}
+		return indexes
+	}
+	picked := make(map[uint64]struct{}, n)
+	indexes := make([]uint64, 0, n)
+	for len(indexes) < n {
+		i := uint64(rnd.Int63n(int64(last + 1)))
+		if _, ok := picked[i]; ok {
+			continue
+		}
+		picked[i] = struct{}{}
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+// verifySample fetches a random sample of entries and checks that each of them is included in the history of root
+// and that its leaf matches the returned key and value
+func (a *defaultAuditor) verifySample(
+	ctx context.Context,
+	serviceClient schema.ImmuServiceClient,
+	serverID string,
+	dbName string,
+	root *schema.Root,
+) *SampleResult {
+	result := &SampleResult{}
+	for _, index := range sampleIndexes(a.rnd, a.sampleSize, root.GetIndex()) {
+		result.Size++
+		safeItem, err := serviceClient.BySafeIndex(ctx, &schema.SafeIndexOptions{
+			Index:     index,
+			RootIndex: &schema.Index{Index: root.GetIndex()},
+		})
+		if err != nil {
+			a.logger.Errorf("error fetching sampled entry at index %d: %v", index, err)
+			result.Errors++
+			continue
+		}
+		if err := verifySampledItem(index, safeItem, root); err != nil {
+			a.logger.Errorf(
+				"audit #%d detected a tampered entry at index %d of database %s on server %s @ %s: %v",
+				a.index, index, dbName, serverID, a.serverAddress, err)
+			result.Failed++
+			result.FailedIndexes = append(result.FailedIndexes, index)
+			continue
+		}
+		result.Verified++
+	}
+	a.logger.Infof("audit #%d sample result:\n  sampled:	%d\n  verified:	%d\n  failed:	%d\n  errors:	%d",
+		a.index, result.Size, result.Verified, result.Failed, result.Errors)
+	if result.Failed > 0 {
+		a.alert(Alert{
+			Type:       AlertTampering,
+			ServerID:   serverID,
+			Database:   dbName,
+			Message:    fmt.Sprintf("%d sampled entries did not verify at indexes %v", result.Failed, result.FailedIndexes),
+			RemoteRoot: root,
+		})
+	}
+	return result
+}
+
+func verifySampledItem(index uint64, safeItem *schema.SafeItem, root *schema.Root) error {
+	if safeItem.GetItem() == nil || safeItem.GetProof() == nil {
+		return fmt.Errorf("missing item or proof")
+	}
+	if safeItem.GetItem().GetIndex() != index || safeItem.GetProof().GetIndex() != index {
+		return fmt.Errorf("server returned the entry at index %d", safeItem.GetItem().GetIndex())
+	}
+	leaf, err := safeItem.Hash()
+	if err != nil {
+		return err
+	}
+	if !safeItem.GetProof().Verify(leaf, schema.Root{Index: root.GetIndex(), Root: root.GetRoot()}) {
+		return fmt.Errorf("inclusion proof or leaf hash does not verify against root %x at index %d",
+			root.GetRoot(), root.GetIndex())
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditor
+
+import (
+	"context"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/client/timestamp"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampleIndexes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	assert.Empty(t, sampleIndexes(rnd, 0, 10))
+	assert.Equal(t, []uint64{0, 1, 2}, sampleIndexes(rnd, 5, 2))
+
+	indexes := sampleIndexes(rnd, 10, 100)
+	assert.Len(t, indexes, 10)
+	seen := map[uint64]bool{}
+	for _, i := range indexes {
+		assert.True(t, i <= 100)
+		assert.False(t, seen[i])
+		seen[i] = true
+	}
+}
+
+func TestVerifySample(t *testing.T) {
+	immuServer := newServer()
+	immuServer.Start()
+	defer os.RemoveAll(dirname)
+	nm, _ := timestamp.NewTdefault()
+	tss := client.NewTimestampService(nm)
+	cli := newClient(true, login()).WithTimestampService(tss)
+	resp, err := cli.UseDatabase(context.Background(), &schema.Database{
+		Databasename: immuServer.Options.GetDefaultDbName(),
+	})
+	assert.Nil(t, err)
+	cli = newClient(true, resp.Token).WithTimestampService(tss)
+	serviceClient := *cli.GetServiceClient()
+
+	ctx := context.Background()
+	for _, k := range []string{"key1", "key2", "key3", "key4"} {
+		_, err = cli.Set(ctx, []byte(k), []byte("val-"+k))
+		assert.Nil(t, err)
+	}
+	root, err := cli.CurrentRoot(ctx)
+	assert.Nil(t, err)
+
+	sink := &recordingAlertSink{}
+	a := &defaultAuditor{
+		logger:     logger.NewSimpleLogger("auditor", ioutil.Discard),
+		alertSinks: []AlertSink{sink},
+		sampleSize: 3,
+		rnd:        rand.New(rand.NewSource(1)),
+	}
+	result := a.verifySample(ctx, serviceClient, "server1", "defaultdb", root)
+	assert.Equal(t, 3, result.Size)
+	assert.Equal(t, 3, result.Verified)
+	assert.Equal(t, 0, result.Failed)
+	assert.Empty(t, sink.alerts)
+
+	safeItem, err := serviceClient.BySafeIndex(ctx, &schema.SafeIndexOptions{
+		Index:     1,
+		RootIndex: &schema.Index{Index: root.GetIndex()},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, verifySampledItem(1, safeItem, root))
+	assert.Error(t, verifySampledItem(2, safeItem, root))
+	assert.Error(t, verifySampledItem(1, safeItem, &schema.Root{Index: root.GetIndex(), Root: make([]byte, 32)}))
+	safeItem.Item.Value = []byte("tampered")
+	assert.Error(t, verifySampledItem(1, safeItem, root))
+}
@@ -24,6 +24,13 @@ type Cache interface {
 	Set(root *schema.Root, serverUuid string, databasename string) error
 }
 
+// ConsistentCache a cache which never moves a root backwards, unless the consistency of the
+// older root with the cached one is proven
+type ConsistentCache interface {
+	Cache
+	SetWithProof(root *schema.Root, proof *schema.ConsistencyProof, serverUuid string, databasename string) error
+}
+
 // HistoryCache the history cache interface
 type HistoryCache interface {
 	Cache
@@ -17,9 +17,12 @@ limitations under the License.
 package cache
 
 import (
+	"bytes"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -30,73 +33,179 @@ import (
 // ROOT_FN ...
 const ROOT_FN = ".root-"
 
+// ErrCorruptedRootFile is returned when the cached root file can not be parsed
+var ErrCorruptedRootFile = errors.New("cached root file is corrupted")
+
+// ErrStaleRoot is returned when a root with a lower index than the cached one is stored without a consistency proof
+var ErrStaleRoot = errors.New("root index is lower than the one of the cached root")
+
 type fileCache struct {
 	Dir string
 }
 
-// NewFileCache returns a new file cache
-func NewFileCache(dir string) Cache {
+// NewFileCache returns a new file cache. Roots are written atomically while holding an advisory lock on the root
+// file, so that several processes can share the same directory, and they never move backwards.
+func NewFileCache(dir string) ConsistentCache {
 	return &fileCache{Dir: dir}
 }
 
+func (w *fileCache) rootFilePath(serverUUID string) string {
+	return filepath.Join(w.Dir, string(getRootFileName([]byte(ROOT_FN), []byte(serverUUID))))
+}
+
 func (w *fileCache) Get(serverUUID string, databasename string) (*schema.Root, error) {
-	fn := filepath.Join(w.Dir, string(getRootFileName([]byte(ROOT_FN), []byte(serverUUID))))
+	fn := w.rootFilePath(serverUUID)
+	unlock, err := lockFile(fn, false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 
 	raw, err := ioutil.ReadFile(fn)
 	if err != nil {
 		return nil, err
 	}
-	root := new(schema.Root)
-	lines := strings.Split(string(raw), "\n")
-	for _, line := range lines {
-		if strings.Contains(line, databasename+":") {
-			r := strings.Split(line, ":")
-			if len(r) != 2 {
-				return nil, fmt.Errorf("could not find previous root")
-			}
-			oldRoot, err := base64.StdEncoding.DecodeString(r[1])
-			if err != nil {
-				return nil, fmt.Errorf("could not find previous root")
-			}
-			root := new(schema.Root)
-			if err = proto.Unmarshal(oldRoot, root); err != nil {
-				return nil, err
-			}
-			return root, nil
+	roots, err := parseRootFile(fn, raw)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range roots {
+		if r.databasename == databasename {
+			return r.root, nil
 		}
 	}
-	return root, nil
+	return new(schema.Root), nil
 }
 
 func (w *fileCache) Set(root *schema.Root, serverUUID string, databasename string) error {
+	return w.set(root, nil, serverUUID, databasename)
+}
+
+func (w *fileCache) SetWithProof(root *schema.Root, proof *schema.ConsistencyProof, serverUUID string, databasename string) error {
+	return w.set(root, proof, serverUUID, databasename)
+}
+
+func (w *fileCache) set(root *schema.Root, proof *schema.ConsistencyProof, serverUUID string, databasename string) error {
 	raw, err := proto.Marshal(root)
 	if err != nil {
 		return err
 	}
-	fn := filepath.Join(w.Dir, string(getRootFileName([]byte(ROOT_FN), []byte(serverUUID))))
+	fn := w.rootFilePath(serverUUID)
+	unlock, err := lockFile(fn, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
-	input, _ := ioutil.ReadFile(fn)
-	lines := strings.Split(string(input), "\n")
+	//at run first the file does not exist
+	input, err := ioutil.ReadFile(fn)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	roots, err := parseRootFile(fn, input)
+	if err != nil {
+		return err
+	}
 
-	newRoot := databasename + ":" + base64.StdEncoding.EncodeToString(raw) + "\n"
 	var exists bool
-	for i, line := range lines {
-		if strings.Contains(line, databasename+":") {
-			exists = true
-			lines[i] = newRoot
+	for _, r := range roots {
+		if r.databasename != databasename {
+			continue
+		}
+		if err := checkRootAdvance(r.root, root, proof); err != nil {
+			return err
 		}
+		exists = true
+		r.raw = raw
 	}
 	if !exists {
-		lines = append(lines, newRoot)
+		roots = append(roots, &rootLine{databasename: databasename, raw: raw})
 	}
-	output := strings.Join(lines, "\n")
 
-	if err = ioutil.WriteFile(fn, []byte(output), 0644); err != nil {
-		return err
+	var output strings.Builder
+	for _, r := range roots {
+		output.WriteString(r.databasename + ":" + base64.StdEncoding.EncodeToString(r.raw) + "\n")
+	}
+	return writeFileAtomically(fn, []byte(output.String()), 0644)
+}
+
+// checkRootAdvance returns an error if root does not advance the cached root: a root with a lower index is
+// accepted only if proof shows that the cached root is a consistent extension of it.
+// The root of an empty database has no hash and can be replaced by any root.
+func checkRootAdvance(cached *schema.Root, root *schema.Root, proof *schema.ConsistencyProof) error {
+	switch {
+	case len(cached.GetRoot()) == 0 || root.GetIndex() > cached.GetIndex():
+		return nil
+	case root.GetIndex() == cached.GetIndex():
+		if !bytes.Equal(root.GetRoot(), cached.GetRoot()) {
+			return ErrInconsistentRoot
+		}
+		return nil
+	case proof == nil:
+		return ErrStaleRoot
+	}
+	if proof.GetSecond() != cached.GetIndex() || !bytes.Equal(proof.GetSecondRoot(), cached.GetRoot()) ||
+		!proof.Verify(schema.Root{Index: root.GetIndex(), Root: root.GetRoot()}) {
+		return ErrInconsistentRoot
 	}
 	return nil
 }
 
+type rootLine struct {
+	databasename string
+	raw          []byte
+	root         *schema.Root
+}
+
+// parseRootFile parses the lines of a root file, one "databasename:base64 encoded root" per database
+func parseRootFile(fn string, raw []byte) ([]*rootLine, error) {
+	var roots []*rootLine
+	for i, line := range strings.Split(string(raw), "\n") {
+		if line == "" {
+			continue
+		}
+		sep := strings.LastIndex(line, ":")
+		if sep < 0 {
+			return nil, fmt.Errorf("%w: %s line %d has no database name", ErrCorruptedRootFile, fn, i+1)
+		}
+		r := &rootLine{databasename: line[:sep], root: new(schema.Root)}
+		var err error
+		if r.raw, err = base64.StdEncoding.DecodeString(line[sep+1:]); err != nil {
+			return nil, fmt.Errorf("%w: %s line %d: %v", ErrCorruptedRootFile, fn, i+1, err)
+		}
+		if err = proto.Unmarshal(r.raw, r.root); err != nil {
+			return nil, fmt.Errorf("%w: %s line %d: %v", ErrCorruptedRootFile, fn, i+1, err)
+		}
+		roots = append(roots, r)
+	}
+	return roots, nil
+}
+
+// writeFileAtomically writes data to a temporary file in the same directory and renames it to fn, so that
+// readers never see a partially written file
+func writeFileAtomically(fn string, data []byte, perm os.FileMode) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(fn), filepath.Base(fn)+".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), fn)
+}
+
 func getRootFileName(prefix []byte, serverUUID []byte) []byte {
 	l1 := len(prefix)
 	l2 := len(serverUUID)
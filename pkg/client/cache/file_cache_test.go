@@ -1,10 +1,17 @@
 package cache
 
 import (
-	"github.com/codenotary/immudb/pkg/api/schema"
-	"github.com/stretchr/testify/assert"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/merkletree"
+	"github.com/stretchr/testify/assert"
 )
 
 var dirname = "./test"
@@ -41,3 +48,87 @@ func TestFileCacheGetFail(t *testing.T) {
 	assert.Error(t, err)
 	os.RemoveAll(dirname)
 }
+
+func TestFileCacheMonotonic(t *testing.T) {
+	os.Mkdir(dirname, os.ModePerm)
+	defer os.RemoveAll(dirname)
+	fc := NewFileCache(dirname)
+
+	tree := merkletree.NewMemStore()
+	roots := map[uint64]*schema.Root{}
+	for i := uint64(0); i < 8; i++ {
+		merkletree.Append(tree, []byte{byte(i)})
+		r := merkletree.Root(tree)
+		roots[i] = &schema.Root{Index: i, Root: r[:]}
+	}
+
+	assert.Nil(t, fc.Set(roots[3], "uuid", "db1"))
+	assert.Nil(t, fc.Set(roots[5], "uuid", "db2"))
+	assert.Nil(t, fc.Set(roots[7], "uuid", "db1"))
+	assert.Nil(t, fc.Set(roots[7], "uuid", "db1"))
+	assert.Equal(t, ErrStaleRoot, fc.Set(roots[3], "uuid", "db1"))
+	assert.Equal(t, ErrInconsistentRoot, fc.Set(&schema.Root{Index: 7, Root: []byte("fork")}, "uuid", "db1"))
+	root, err := fc.Get("uuid", "db1")
+	assert.Nil(t, err)
+	assert.Equal(t, roots[7].GetRoot(), root.GetRoot())
+	root, err = fc.Get("uuid", "db2")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5), root.GetIndex())
+
+	proof := &schema.ConsistencyProof{
+		First:      3,
+		Second:     7,
+		SecondRoot: roots[7].GetRoot(),
+		Path:       merkletree.ConsistencyProof(tree, 7, 3).ToSlice(),
+	}
+	assert.Equal(t, ErrInconsistentRoot, fc.SetWithProof(roots[2], proof, "uuid", "db1"))
+	assert.Equal(t, ErrInconsistentRoot, fc.SetWithProof(&schema.Root{Index: 3, Root: []byte("fork")}, proof, "uuid", "db1"))
+	assert.Nil(t, fc.SetWithProof(roots[3], proof, "uuid", "db1"))
+	root, err = fc.Get("uuid", "db1")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), root.GetIndex())
+}
+
+func TestFileCacheCorrupted(t *testing.T) {
+	os.Mkdir(dirname, os.ModePerm)
+	defer os.RemoveAll(dirname)
+	fc := NewFileCache(dirname)
+	fn := filepath.Join(dirname, ROOT_FN+"uuid")
+
+	assert.Nil(t, ioutil.WriteFile(fn, []byte("dbName:not base64!\n"), 0644))
+	_, err := fc.Get("uuid", "dbName")
+	assert.True(t, errors.Is(err, ErrCorruptedRootFile))
+	assert.True(t, errors.Is(fc.Set(&schema.Root{}, "uuid", "dbName"), ErrCorruptedRootFile))
+
+	assert.Nil(t, ioutil.WriteFile(fn, []byte("no separator\n"), 0644))
+	_, err = fc.Get("uuid", "dbName")
+	assert.True(t, errors.Is(err, ErrCorruptedRootFile))
+}
+
+func TestFileCacheConcurrentSet(t *testing.T) {
+	os.Mkdir(dirname, os.ModePerm)
+	defer os.RemoveAll(dirname)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(db string) {
+			defer wg.Done()
+			fc := NewFileCache(dirname)
+			for j := uint64(1); j <= 20; j++ {
+				assert.Nil(t, fc.Set(&schema.Root{Index: j, Root: []byte{byte(j)}}, "uuid", db))
+			}
+		}(fmt.Sprintf("db%d", i))
+	}
+	wg.Wait()
+
+	fc := NewFileCache(dirname)
+	for i := 0; i < 10; i++ {
+		root, err := fc.Get("uuid", fmt.Sprintf("db%d", i))
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(20), root.GetIndex())
+	}
+	files, err := ioutil.ReadDir(dirname)
+	assert.Nil(t, err)
+	assert.Len(t, files, 2)
+}
@@ -0,0 +1,34 @@
+// +build windows plan9
+
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import "sync"
+
+var fileLock sync.RWMutex
+
+// lockFile serializes the access to the root files within the process only, since advisory
+// file locks are not available on this platform
+func lockFile(fn string, exclusive bool) (func(), error) {
+	if exclusive {
+		fileLock.Lock()
+		return fileLock.Unlock, nil
+	}
+	fileLock.RLock()
+	return fileLock.RUnlock, nil
+}
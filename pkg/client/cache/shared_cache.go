@@ -0,0 +1,176 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/golang/protobuf/proto"
+)
+
+// ErrInconsistentRoot is returned when a root does not belong to the same history of the shared trusted root
+var ErrInconsistentRoot = errors.New("root is not consistent with the shared trusted root")
+
+// ErrTooManyConflicts is returned when a root could not be stored because of concurrent updates
+var ErrTooManyConflicts = errors.New("too many concurrent updates of the shared trusted root")
+
+const (
+	sharedCacheMaxAttempts = 10
+	// sharedRootVersion prefixes the encoded roots, so that even an empty root is stored as a non empty value
+	sharedRootVersion = 1
+)
+
+// KVStore is a key-value store with compare-and-swap semantics the roots of several clients can be shared with
+type KVStore interface {
+	// Get returns the value stored for key, or nil if the key does not exist
+	Get(key []byte) ([]byte, error)
+	// CompareAndSwap sets key to value only if its current value is old; a nil old value means the key must not exist
+	CompareAndSwap(key []byte, old []byte, value []byte) (bool, error)
+}
+
+// RootVerifier returns an error if the two roots do not belong to the same history
+type RootVerifier func(a *schema.Root, b *schema.Root) error
+
+type sharedCache struct {
+	kv     KVStore
+	verify RootVerifier
+}
+
+// NewSharedCache returns a cache sharing the trusted roots among the clients using the same kv store.
+// A root is stored only if verify proves it is a consistent advance of the shared one, so that clients
+// being served different histories detect it.
+func NewSharedCache(kv KVStore, verify RootVerifier) Cache {
+	return &sharedCache{kv: kv, verify: verify}
+}
+
+func sharedRootKey(serverUUID string, databasename string) []byte {
+	return []byte(fmt.Sprintf("immudb-root/%s/%s", serverUUID, databasename))
+}
+
+func encodeSharedRoot(root *schema.Root) ([]byte, error) {
+	raw, err := proto.Marshal(root)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{sharedRootVersion}, raw...), nil
+}
+
+func decodeSharedRoot(raw []byte) (*schema.Root, error) {
+	if len(raw) == 0 || raw[0] != sharedRootVersion {
+		return nil, errors.New("invalid shared root encoding")
+	}
+	root := new(schema.Root)
+	if err := proto.Unmarshal(raw[1:], root); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+func (sc *sharedCache) Get(serverUUID string, databasename string) (*schema.Root, error) {
+	raw, err := sc.kv.Get(sharedRootKey(serverUUID, databasename))
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, fmt.Errorf(
+			"no root found for server %s and database %s", serverUUID, databasename)
+	}
+	return decodeSharedRoot(raw)
+}
+
+func (sc *sharedCache) Set(root *schema.Root, serverUUID string, databasename string) error {
+	key := sharedRootKey(serverUUID, databasename)
+	raw, err := encodeSharedRoot(root)
+	if err != nil {
+		return err
+	}
+	for attempt := 0; attempt < sharedCacheMaxAttempts; attempt++ {
+		old, err := sc.kv.Get(key)
+		if err != nil {
+			return err
+		}
+		if old != nil {
+			prev, err := decodeSharedRoot(old)
+			if err != nil {
+				return err
+			}
+			if err := sc.check(prev, root); err != nil {
+				return err
+			}
+			// an older root is not stored, the shared one already proves it
+			if root.GetIndex() <= prev.GetIndex() {
+				return nil
+			}
+		}
+		swapped, err := sc.kv.CompareAndSwap(key, old, raw)
+		if err != nil {
+			return err
+		}
+		if swapped {
+			return nil
+		}
+	}
+	return ErrTooManyConflicts
+}
+
+func (sc *sharedCache) check(prev *schema.Root, root *schema.Root) error {
+	if prev.GetIndex() == root.GetIndex() {
+		if !bytes.Equal(prev.GetRoot(), root.GetRoot()) {
+			return ErrInconsistentRoot
+		}
+		return nil
+	}
+	if err := sc.verify(prev, root); err != nil {
+		if err == ErrInconsistentRoot {
+			return err
+		}
+		return fmt.Errorf("error verifying root at index %d against the shared root at index %d: %v",
+			root.GetIndex(), prev.GetIndex(), err)
+	}
+	return nil
+}
+
+type inMemoryKVStore struct {
+	values map[string][]byte
+	lock   sync.Mutex
+}
+
+// NewInMemoryKVStore returns a kv store shared by the clients of the same process
+func NewInMemoryKVStore() KVStore {
+	return &inMemoryKVStore{values: map[string][]byte{}}
+}
+
+func (s *inMemoryKVStore) Get(key []byte) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.values[string(key)], nil
+}
+
+func (s *inMemoryKVStore) CompareAndSwap(key []byte, old []byte, value []byte) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	current, ok := s.values[string(key)]
+	if (old == nil && ok) || (old != nil && !bytes.Equal(current, old)) {
+		return false, nil
+	}
+	s.values[string(key)] = value
+	return true, nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type conflictingKVStore struct {
+	KVStore
+}
+
+func (s *conflictingKVStore) CompareAndSwap(key []byte, old []byte, value []byte) (bool, error) {
+	return false, nil
+}
+
+func TestSharedCache(t *testing.T) {
+	consistent := true
+	verifications := 0
+	verify := func(a *schema.Root, b *schema.Root) error {
+		verifications++
+		if !consistent {
+			return ErrInconsistentRoot
+		}
+		return nil
+	}
+	kv := NewInMemoryKVStore()
+	c1 := NewSharedCache(kv, verify)
+	c2 := NewSharedCache(kv, verify)
+
+	_, err := c1.Get("uuid", "db")
+	assert.Error(t, err)
+
+	assert.Nil(t, c1.Set(&schema.Root{}, "uuid", "db"))
+	root, err := c2.Get("uuid", "db")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), root.GetIndex())
+
+	assert.Nil(t, c1.Set(&schema.Root{Index: 5, Root: []byte("root5")}, "uuid", "db"))
+	assert.Equal(t, 1, verifications)
+	root, err = c2.Get("uuid", "db")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5), root.GetIndex())
+
+	assert.Nil(t, c2.Set(&schema.Root{Index: 5, Root: []byte("root5")}, "uuid", "db"))
+	assert.Equal(t, ErrInconsistentRoot, c2.Set(&schema.Root{Index: 5, Root: []byte("fork5")}, "uuid", "db"))
+
+	// an older consistent root does not replace the shared one
+	assert.Nil(t, c2.Set(&schema.Root{Index: 3, Root: []byte("root3")}, "uuid", "db"))
+	root, err = c1.Get("uuid", "db")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5), root.GetIndex())
+
+	consistent = false
+	assert.Equal(t, ErrInconsistentRoot, c2.Set(&schema.Root{Index: 7, Root: []byte("fork7")}, "uuid", "db"))
+	root, err = c1.Get("uuid", "db")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("root5"), root.GetRoot())
+
+	_, err = c1.Get("uuid", "otherdb")
+	assert.Error(t, err)
+
+	failing := NewSharedCache(kv, func(a *schema.Root, b *schema.Root) error { return errors.New("unreachable") })
+	assert.Error(t, failing.Set(&schema.Root{Index: 9, Root: []byte("root9")}, "uuid", "db"))
+
+	conflicting := NewSharedCache(&conflictingKVStore{NewInMemoryKVStore()}, verify)
+	assert.Equal(t, ErrTooManyConflicts, conflicting.Set(&schema.Root{Index: 1}, "uuid", "db"))
+}
+
+func TestInMemoryKVStore(t *testing.T) {
+	kv := NewInMemoryKVStore()
+	v, err := kv.Get([]byte("k"))
+	assert.Nil(t, err)
+	assert.Nil(t, v)
+
+	ok, err := kv.CompareAndSwap([]byte("k"), []byte("x"), []byte("v1"))
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	ok, err = kv.CompareAndSwap([]byte("k"), nil, []byte("v1"))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	ok, err = kv.CompareAndSwap([]byte("k"), nil, []byte("v2"))
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	ok, err = kv.CompareAndSwap([]byte("k"), []byte("v1"), []byte("v2"))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	v, err = kv.Get([]byte("k"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("v2"), v)
+}
@@ -19,6 +19,7 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/binary"
@@ -34,6 +35,7 @@ import (
 	"github.com/codenotary/immudb/pkg/client/cache"
 	"github.com/codenotary/immudb/pkg/client/timestamp"
 	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/codenotary/immudb/pkg/signer"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/grpclog"
 
@@ -51,6 +53,23 @@ type ImmuClient interface {
 	Disconnect() error
 	IsConnected() bool
 	WaitForHealthCheck(ctx context.Context) (err error)
+	Reconnect(ctx context.Context) error
+	Endpoints() []EndpointStatus
+
+	SetJSON(ctx context.Context, key []byte, v interface{}) (*schema.Index, error)
+	SafeSetJSON(ctx context.Context, key []byte, v interface{}) (*VerifiedIndex, error)
+	GetJSON(ctx context.Context, key []byte, v interface{}) (*schema.StructuredItem, error)
+	SafeGetJSON(ctx context.Context, key []byte, v interface{}) (*VerifiedItem, error)
+	SetBatchJSON(ctx context.Context, keys [][]byte, values []interface{}) (*schema.Index, error)
+	HistoryJSON(ctx context.Context, key []byte, out interface{}) (*schema.StructuredItemList, error)
+	ScanJSON(ctx context.Context, prefix []byte, out interface{}) (*schema.StructuredItemList, error)
+	SetProto(ctx context.Context, key []byte, m proto.Message) (*schema.Index, error)
+	SafeSetProto(ctx context.Context, key []byte, m proto.Message) (*VerifiedIndex, error)
+	GetProto(ctx context.Context, key []byte, m proto.Message) (*schema.StructuredItem, error)
+	SafeGetProto(ctx context.Context, key []byte, m proto.Message) (*VerifiedItem, error)
+	SetBatchProto(ctx context.Context, keys [][]byte, messages []proto.Message) (*schema.Index, error)
+	HistoryProto(ctx context.Context, key []byte, out interface{}) (*schema.StructuredItemList, error)
+	ScanProto(ctx context.Context, prefix []byte, out interface{}) (*schema.StructuredItemList, error)
 	Connect(ctx context.Context) (clientConn *grpc.ClientConn, err error)
 	Login(ctx context.Context, user []byte, pass []byte) (*schema.LoginResponse, error)
 	Logout(ctx context.Context) error
@@ -96,6 +115,7 @@ type ImmuClient interface {
 	WithClientConn(clientConn *grpc.ClientConn) *immuClient
 	WithServiceClient(serviceClient schema.ImmuServiceClient) *immuClient
 	WithHomedirService(homedirService HomedirService) *immuClient
+	WithVerifiedItemCache(itemCache *VerifiedItemCache) *immuClient
 
 	GetServiceClient() *schema.ImmuServiceClient
 	GetOptions() *Options
@@ -105,6 +125,23 @@ type ImmuClient interface {
 	ChangePermission(ctx context.Context, d *schema.ChangePermissionRequest) (*schema.Error, error)
 	SetActiveUser(ctx context.Context, u *schema.SetActiveUserRequest) (*empty.Empty, error)
 	DatabaseList(ctx context.Context, d *empty.Empty) (*schema.DatabaseListResponse, error)
+	CreateRole(ctx context.Context, r *schema.CreateRoleRequest) error
+	ListRoles(ctx context.Context) (*schema.RoleList, error)
+	GrantRole(ctx context.Context, r *schema.RoleRequest) (*schema.Error, error)
+	RevokeRole(ctx context.Context, r *schema.RoleRequest) (*schema.Error, error)
+	UpdateRole(ctx context.Context, r *schema.UpdateRoleRequest) error
+	DeleteRole(ctx context.Context, r *schema.DeleteRoleRequest) error
+	ResolveTampering(ctx context.Context, databasename string, quarantine bool) error
+	UnloadDatabase(ctx context.Context, databasename string) error
+	LoadDatabase(ctx context.Context, databasename string) error
+	RenameDatabase(ctx context.Context, databasename string, newDatabasename string) error
+	DropDatabase(ctx context.Context, databasename string) (string, error)
+	UpdateDatabaseSettings(ctx context.Context, databasename string, settings *schema.DatabaseSettings) error
+	SetDatabaseReadOnly(ctx context.Context, databasename string, readOnly bool) error
+	CorruptionCheckerStatus(ctx context.Context, databasename string) (*schema.CorruptionCheckerStatusResponse, error)
+	VerifyDatabase(ctx context.Context, databasename string, onProgress func(*schema.VerifyDatabaseProgress)) (*schema.VerifyDatabaseProgress, error)
+	Backup(ctx context.Context, databases []string, w io.Writer) (int64, error)
+	VerifiedItemCacheStats() VerifiedItemCacheStats
 }
 
 type immuClient struct {
@@ -116,6 +153,9 @@ type immuClient struct {
 	Rootservice   RootService
 	ts            TimestampService
 	hds           HomedirService
+	itemCache     *VerifiedItemCache
+	session       session
+	endpoints     *endpointPool
 	sync.RWMutex
 }
 
@@ -157,14 +197,26 @@ func NewImmuClient(options *Options) (c ImmuClient, err error) {
 		return nil, err
 	}
 
-	rootService := NewRootService(serviceClient, cache.NewFileCache(options.Dir), l)
+	var serverSigningPubKey crypto.PublicKey
+	if options.ServerSigningPubKey != "" {
+		if serverSigningPubKey, err = signer.LoadPublicKey(options.ServerSigningPubKey); err != nil {
+			l.Errorf("unable to load server signing public key: %s", err)
+			return nil, err
+		}
+	}
+	rootCache := cache.NewFileCache(options.Dir)
+	rootService := NewVerifyingRootService(serviceClient, rootCache, l, serverSigningPubKey)
 	dt, err := timestamp.NewTdefault()
 	if err != nil {
 		return nil, err
 	}
 	ts := NewTimestampService(dt)
 	c.WithTimestampService(ts).
-		WithRootService(rootService)
+		WithRootService(rootService).
+		withEndpointRootServices(rootCache, l, serverSigningPubKey)
+	if options.VerifiedItemCacheSize > 0 {
+		c.WithVerifiedItemCache(NewVerifiedItemCache(options.VerifiedItemCacheSize))
+	}
 
 	return c, nil
 }
@@ -234,6 +286,8 @@ func (c *immuClient) SetupDialOptions(options *Options) *[]grpc.DialOption {
 			opts = append(opts, grpc.WithStreamInterceptor(auth.ClientStreamInterceptor(token)))
 		}
 	}
+	opts = append(opts, c.reconnectDialOptions(options)...)
+	opts = append(opts, c.endpointsDialOptions(options)...)
 	return &opts
 }
 
@@ -242,6 +296,11 @@ func (c *immuClient) Connect(ctx context.Context) (clientConn *grpc.ClientConn,
 		c.Logger.Debugf("dialed %v", c.Options)
 		return nil, err
 	}
+	if len(c.Options.Endpoints) > 0 {
+		if err = c.connectEndpoints(); err != nil {
+			return nil, err
+		}
+	}
 	return c.clientConn, nil
 }
 
@@ -250,6 +309,7 @@ func (c *immuClient) Disconnect() error {
 	if !c.IsConnected() {
 		return ErrNotConnected
 	}
+	c.closeEndpoints()
 	if err := c.clientConn.Close(); err != nil {
 		return err
 	}
@@ -465,21 +525,34 @@ func (c *immuClient) SafeGet(ctx context.Context, key []byte, opts ...grpc.CallO
 		return nil, ErrNotConnected
 	}
 
-	root, err := c.Rootservice.GetRoot(ctx, c.Options.CurrentDatabase)
+	var cached *VerifiedItem
+	var safeItem *schema.SafeItem
+	ctx, root, err := c.pinnedRead(ctx, func(ctx context.Context, root *schema.Root) (err error) {
+		if c.itemCache != nil {
+			if cached, err = c.itemCache.revalidate(
+				ctx, c.ServiceClient, c.rootService(ctx), c.Options.CurrentDatabase, key, root); cached != nil || err != nil {
+				return err
+			}
+		}
+		sgOpts := &schema.SafeGetOptions{
+			Key: key,
+			RootIndex: &schema.Index{
+				Index: root.Index,
+			},
+		}
+		safeItem, err = c.ServiceClient.SafeGet(ctx, sgOpts, opts...)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	sgOpts := &schema.SafeGetOptions{
-		Key: key,
-		RootIndex: &schema.Index{
-			Index: root.Index,
-		},
-	}
-
-	safeItem, err := c.ServiceClient.SafeGet(ctx, sgOpts, opts...)
-	if err != nil {
-		return nil, err
+	if c.itemCache != nil {
+		c.itemCache.count(cached != nil)
+		if cached != nil {
+			c.Logger.Debugf("safeget served from the verified item cache in %s", time.Since(start))
+			return cached, nil
+		}
 	}
 
 	h, err := safeItem.Hash()
@@ -492,7 +565,8 @@ func (c *immuClient) SafeGet(ctx context.Context, key []byte, opts ...grpc.CallO
 		tocache := new(schema.Root)
 		tocache.Index = safeItem.Proof.At
 		tocache.Root = safeItem.Proof.Root
-		err = c.Rootservice.SetRoot(tocache, c.Options.CurrentDatabase)
+		tocache.Signature = safeItem.Proof.Signature
+		err = c.rootService(ctx).SetRoot(tocache, c.Options.CurrentDatabase)
 		if err != nil {
 			return nil, err
 		}
@@ -503,14 +577,28 @@ func (c *immuClient) SafeGet(ctx context.Context, key []byte, opts ...grpc.CallO
 	if err != nil {
 		return nil, err
 	}
-	return &VerifiedItem{
-			Key:      sitem.Item.GetKey(),
-			Value:    sitem.Item.Value.Payload,
-			Index:    sitem.Item.GetIndex(),
-			Time:     sitem.Item.Value.Timestamp,
-			Verified: verified,
-		},
-		nil
+	vi = &VerifiedItem{
+		Key:      sitem.Item.GetKey(),
+		Value:    sitem.Item.Value.Payload,
+		Index:    sitem.Item.GetIndex(),
+		Time:     sitem.Item.Value.Timestamp,
+		Verified: verified,
+	}
+	if c.itemCache != nil && verified {
+		c.itemCache.set(c.Options.CurrentDatabase, vi, h, &schema.Root{
+			Index: safeItem.Proof.At,
+			Root:  safeItem.Proof.Root,
+		})
+	}
+	return vi, nil
+}
+
+// VerifiedItemCacheStats returns the counters of the verified item cache, if enabled
+func (c *immuClient) VerifiedItemCacheStats() VerifiedItemCacheStats {
+	if c.itemCache == nil {
+		return VerifiedItemCacheStats{}
+	}
+	return c.itemCache.Stats()
 }
 
 // RawSafeGet ...
@@ -524,19 +612,17 @@ func (c *immuClient) RawSafeGet(ctx context.Context, key []byte, opts ...grpc.Ca
 		return nil, ErrNotConnected
 	}
 
-	root, err := c.Rootservice.GetRoot(ctx, c.Options.CurrentDatabase)
-	if err != nil {
-		return nil, err
-	}
-
-	sgOpts := &schema.SafeGetOptions{
-		Key: key,
-		RootIndex: &schema.Index{
-			Index: root.Index,
-		},
-	}
-
-	safeItem, err := c.ServiceClient.SafeGet(ctx, sgOpts, opts...)
+	var safeItem *schema.SafeItem
+	ctx, root, err := c.pinnedRead(ctx, func(ctx context.Context, root *schema.Root) (err error) {
+		sgOpts := &schema.SafeGetOptions{
+			Key: key,
+			RootIndex: &schema.Index{
+				Index: root.Index,
+			},
+		}
+		safeItem, err = c.ServiceClient.SafeGet(ctx, sgOpts, opts...)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -551,7 +637,8 @@ func (c *immuClient) RawSafeGet(ctx context.Context, key []byte, opts ...grpc.Ca
 		tocache := new(schema.Root)
 		tocache.Index = safeItem.Proof.At
 		tocache.Root = safeItem.Proof.Root
-		err = c.Rootservice.SetRoot(tocache, c.Options.CurrentDatabase)
+		tocache.Signature = safeItem.Proof.Signature
+		err = c.rootService(ctx).SetRoot(tocache, c.Options.CurrentDatabase)
 		if err != nil {
 			return nil, err
 		}
@@ -641,7 +728,8 @@ func (c *immuClient) SafeSet(ctx context.Context, key []byte, value []byte) (*Ve
 		return nil, ErrNotConnected
 	}
 
-	root, err := c.Rootservice.GetRoot(ctx, c.Options.CurrentDatabase)
+	ctx = c.pinPrimary(ctx)
+	root, err := c.rootService(ctx).GetRoot(ctx, c.Options.CurrentDatabase)
 	if err != nil {
 		return nil, err
 	}
@@ -713,7 +801,8 @@ func (c *immuClient) RawSafeSet(ctx context.Context, key []byte, value []byte) (
 		return nil, ErrNotConnected
 	}
 
-	root, err := c.Rootservice.GetRoot(ctx, c.Options.CurrentDatabase)
+	ctx = c.pinPrimary(ctx)
+	root, err := c.rootService(ctx).GetRoot(ctx, c.Options.CurrentDatabase)
 	if err != nil {
 		return nil, err
 	}
@@ -857,18 +946,16 @@ func (c *immuClient) RawBySafeIndex(ctx context.Context, index uint64) (*Verifie
 		return nil, ErrNotConnected
 	}
 
-	root, err := c.Rootservice.GetRoot(ctx, c.Options.CurrentDatabase)
-	if err != nil {
-		return nil, err
-	}
-
-	safeItem, err := c.ServiceClient.BySafeIndex(ctx, &schema.SafeIndexOptions{
-		Index: index,
-		RootIndex: &schema.Index{
-			Index: root.Index,
-		},
+	var safeItem *schema.SafeItem
+	ctx, root, err := c.pinnedRead(ctx, func(ctx context.Context, root *schema.Root) (err error) {
+		safeItem, err = c.ServiceClient.BySafeIndex(ctx, &schema.SafeIndexOptions{
+			Index: index,
+			RootIndex: &schema.Index{
+				Index: root.Index,
+			},
+		})
+		return err
 	})
-
 	if err != nil {
 		return nil, err
 	}
@@ -883,7 +970,8 @@ func (c *immuClient) RawBySafeIndex(ctx context.Context, index uint64) (*Verifie
 		tocache := new(schema.Root)
 		tocache.Index = safeItem.Proof.At
 		tocache.Root = safeItem.Proof.Root
-		err = c.Rootservice.SetRoot(tocache, c.Options.CurrentDatabase)
+		tocache.Signature = safeItem.Proof.Signature
+		err = c.rootService(ctx).SetRoot(tocache, c.Options.CurrentDatabase)
 		if err != nil {
 			return nil, err
 		}
@@ -945,7 +1033,8 @@ func (c *immuClient) SafeReference(ctx context.Context, reference []byte, key []
 		return nil, ErrNotConnected
 	}
 
-	root, err := c.Rootservice.GetRoot(ctx, c.Options.CurrentDatabase)
+	ctx = c.pinPrimary(ctx)
+	root, err := c.rootService(ctx).GetRoot(ctx, c.Options.CurrentDatabase)
 	if err != nil {
 		return nil, err
 	}
@@ -1021,7 +1110,8 @@ func (c *immuClient) SafeZAdd(ctx context.Context, set []byte, score float64, ke
 		return nil, ErrNotConnected
 	}
 
-	root, err := c.Rootservice.GetRoot(ctx, c.Options.CurrentDatabase)
+	ctx = c.pinPrimary(ctx)
+	root, err := c.rootService(ctx).GetRoot(ctx, c.Options.CurrentDatabase)
 	if err != nil {
 		return nil, err
 	}
@@ -1190,6 +1280,11 @@ func (c *immuClient) HealthCheck(ctx context.Context) error {
 	if !c.IsConnected() {
 		return ErrNotConnected
 	}
+	if c.endpoints != nil {
+		err := c.endpoints.healthCheck(ctx)
+		c.Logger.Debugf("health-check of %d endpoints finished in %s", len(c.endpoints.endpoints), time.Since(start))
+		return err
+	}
 	response, err := c.ServiceClient.Health(ctx, &empty.Empty{})
 	if err != nil {
 		return err
@@ -1265,9 +1360,10 @@ func (c *immuClient) verifyAndSetRoot(result *schema.Proof, root *schema.Root, c
 	if verified {
 		//saving a fresh root
 		tocache := new(schema.Root)
-		tocache.Index = result.Index
+		tocache.Index = result.At
 		tocache.Root = result.Root
-		err = c.Rootservice.SetRoot(tocache, c.Options.CurrentDatabase)
+		tocache.Signature = result.Signature
+		err = c.rootService(ctx).SetRoot(tocache, c.Options.CurrentDatabase)
 	}
 	return verified, err
 }
@@ -1321,3 +1417,240 @@ func (c *immuClient) DatabaseList(ctx context.Context, d *empty.Empty) (*schema.
 	c.Logger.Debugf("DatabaseList finished in %s", time.Since(start))
 	return result, err
 }
+
+// CreateRole creates a new role grouping permissions across databases
+func (c *immuClient) CreateRole(ctx context.Context, r *schema.CreateRoleRequest) error {
+	start := time.Now()
+	if !c.IsConnected() {
+		return ErrNotConnected
+	}
+	_, err := c.ServiceClient.CreateRole(ctx, r)
+	c.Logger.Debugf("CreateRole finished in %s", time.Since(start))
+	return err
+}
+
+// ListRoles returns the roles defined on the server
+func (c *immuClient) ListRoles(ctx context.Context) (*schema.RoleList, error) {
+	start := time.Now()
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+	result, err := c.ServiceClient.ListRoles(ctx, &empty.Empty{})
+	c.Logger.Debugf("ListRoles finished in %s", time.Since(start))
+	return result, err
+}
+
+// GrantRole assigns a role to a user
+func (c *immuClient) GrantRole(ctx context.Context, r *schema.RoleRequest) (*schema.Error, error) {
+	start := time.Now()
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+	result, err := c.ServiceClient.GrantRole(ctx, r)
+	c.Logger.Debugf("GrantRole finished in %s", time.Since(start))
+	return result, err
+}
+
+// RevokeRole removes a role from a user
+func (c *immuClient) RevokeRole(ctx context.Context, r *schema.RoleRequest) (*schema.Error, error) {
+	start := time.Now()
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+	result, err := c.ServiceClient.RevokeRole(ctx, r)
+	c.Logger.Debugf("RevokeRole finished in %s", time.Since(start))
+	return result, err
+}
+
+// UpdateRole replaces the permissions granted by a role
+func (c *immuClient) UpdateRole(ctx context.Context, r *schema.UpdateRoleRequest) error {
+	start := time.Now()
+	if !c.IsConnected() {
+		return ErrNotConnected
+	}
+	_, err := c.ServiceClient.UpdateRole(ctx, r)
+	c.Logger.Debugf("UpdateRole finished in %s", time.Since(start))
+	return err
+}
+
+// DeleteRole removes a role from the users holding it and deletes it
+func (c *immuClient) DeleteRole(ctx context.Context, r *schema.DeleteRoleRequest) error {
+	start := time.Now()
+	if !c.IsConnected() {
+		return ErrNotConnected
+	}
+	_, err := c.ServiceClient.DeleteRole(ctx, r)
+	c.Logger.Debugf("DeleteRole finished in %s", time.Since(start))
+	return err
+}
+
+// ResolveTampering acknowledges or quarantines a tampered database
+func (c *immuClient) ResolveTampering(ctx context.Context, databasename string, quarantine bool) error {
+	start := time.Now()
+	if !c.IsConnected() {
+		return ErrNotConnected
+	}
+	_, err := c.ServiceClient.ResolveTampering(ctx, &schema.ResolveTamperingRequest{
+		Databasename: databasename,
+		Quarantine:   quarantine,
+	})
+	c.Logger.Debugf("ResolveTampering finished in %s", time.Since(start))
+	return err
+}
+
+// UnloadDatabase closes a database without removing it from disk
+func (c *immuClient) UnloadDatabase(ctx context.Context, databasename string) error {
+	start := time.Now()
+	if !c.IsConnected() {
+		return ErrNotConnected
+	}
+	_, err := c.ServiceClient.UnloadDatabase(ctx, &schema.Database{Databasename: databasename})
+	c.Logger.Debugf("UnloadDatabase finished in %s", time.Since(start))
+	return err
+}
+
+// LoadDatabase opens a database which has been unloaded
+func (c *immuClient) LoadDatabase(ctx context.Context, databasename string) error {
+	start := time.Now()
+	if !c.IsConnected() {
+		return ErrNotConnected
+	}
+	_, err := c.ServiceClient.LoadDatabase(ctx, &schema.Database{Databasename: databasename})
+	c.Logger.Debugf("LoadDatabase finished in %s", time.Since(start))
+	return err
+}
+
+// RenameDatabase renames a database
+func (c *immuClient) RenameDatabase(ctx context.Context, databasename string, newDatabasename string) error {
+	start := time.Now()
+	if !c.IsConnected() {
+		return ErrNotConnected
+	}
+	_, err := c.ServiceClient.RenameDatabase(ctx, &schema.RenameDatabaseRequest{
+		Databasename:    databasename,
+		NewDatabasename: newDatabasename,
+	})
+	c.Logger.Debugf("RenameDatabase finished in %s", time.Since(start))
+	return err
+}
+
+// DropDatabase archives and removes a database, returning the path of the archive on the server
+func (c *immuClient) DropDatabase(ctx context.Context, databasename string) (string, error) {
+	start := time.Now()
+	if !c.IsConnected() {
+		return "", ErrNotConnected
+	}
+	reply, err := c.ServiceClient.DropDatabase(ctx, &schema.Database{Databasename: databasename})
+	c.Logger.Debugf("DropDatabase finished in %s", time.Since(start))
+	if err != nil {
+		return "", err
+	}
+	return reply.Archive, nil
+}
+
+// UpdateDatabaseSettings replaces the settings of a database
+func (c *immuClient) UpdateDatabaseSettings(ctx context.Context, databasename string, settings *schema.DatabaseSettings) error {
+	start := time.Now()
+	if !c.IsConnected() {
+		return ErrNotConnected
+	}
+	_, err := c.ServiceClient.UpdateDatabaseSettings(ctx, &schema.UpdateDatabaseSettingsRequest{
+		Databasename: databasename,
+		Settings:     settings,
+	})
+	c.Logger.Debugf("UpdateDatabaseSettings finished in %s", time.Since(start))
+	return err
+}
+
+// SetDatabaseReadOnly freezes a database, or makes it writable again
+func (c *immuClient) SetDatabaseReadOnly(ctx context.Context, databasename string, readOnly bool) error {
+	start := time.Now()
+	if !c.IsConnected() {
+		return ErrNotConnected
+	}
+	_, err := c.ServiceClient.SetDatabaseReadOnly(ctx, &schema.SetDatabaseReadOnlyRequest{
+		Databasename: databasename,
+		ReadOnly:     readOnly,
+	})
+	c.Logger.Debugf("SetDatabaseReadOnly finished in %s", time.Since(start))
+	return err
+}
+
+// CorruptionCheckerStatus returns the progress and the failures of the corruption checker on a database, or on all of them if databasename is empty
+func (c *immuClient) CorruptionCheckerStatus(ctx context.Context, databasename string) (*schema.CorruptionCheckerStatusResponse, error) {
+	start := time.Now()
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+	status, err := c.ServiceClient.CorruptionCheckerStatus(ctx, &schema.CorruptionCheckerStatusRequest{
+		Databasename: databasename,
+	})
+	c.Logger.Debugf("CorruptionCheckerStatus finished in %s", time.Since(start))
+	return status, err
+}
+
+// VerifyDatabase has the server rebuild the tree of a database out of its entries and compare it with the persisted one.
+// onProgress, if not nil, receives the progress messages; the last one, holding the outcome, is returned.
+func (c *immuClient) VerifyDatabase(ctx context.Context, databasename string, onProgress func(*schema.VerifyDatabaseProgress)) (*schema.VerifyDatabaseProgress, error) {
+	start := time.Now()
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+	stream, err := c.ServiceClient.VerifyDatabase(ctx, &schema.VerifyDatabaseRequest{
+		Databasename: databasename,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var last *schema.VerifyDatabaseProgress
+	for {
+		progress, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if onProgress != nil {
+			onProgress(progress)
+		}
+		last = progress
+	}
+	c.Logger.Debugf("VerifyDatabase finished in %s", time.Since(start))
+	if last == nil || !last.Done {
+		return nil, fmt.Errorf("verification of database %s ended unexpectedly", databasename)
+	}
+	return last, nil
+}
+
+// Backup has the server take a consistent snapshot of the given databases, or of all of them if none is given,
+// and writes the resulting tar.gz archive to w. It returns the number of bytes written.
+func (c *immuClient) Backup(ctx context.Context, databases []string, w io.Writer) (int64, error) {
+	start := time.Now()
+	if !c.IsConnected() {
+		return 0, ErrNotConnected
+	}
+	stream, err := c.ServiceClient.Backup(ctx, &schema.BackupRequest{
+		Databases: databases,
+	})
+	if err != nil {
+		return 0, err
+	}
+	var written int64
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return written, err
+		}
+		n, err := w.Write(chunk.Content)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	c.Logger.Debugf("Backup finished in %s", time.Since(start))
+	return written, nil
+}
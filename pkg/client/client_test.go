@@ -174,6 +174,7 @@ func cleanup() {
 	if err := os.Remove(".root-"); err != nil {
 		log.Println(err)
 	}
+	os.Remove(".root-.lock")
 }
 func cleanupDump() {
 	if err := os.Remove(BkpFileName); err != nil {
@@ -0,0 +1,460 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/client/cache"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrServerUuidChanged is reported when the server answering at an endpoint is not the one seen before
+var ErrServerUuidChanged = errors.New("server UUID of the endpoint changed")
+
+// readOnlyMethods are the ImmuService methods which can be served by any endpoint
+var readOnlyMethods = map[string]bool{
+	"Get": true, "GetSV": true, "SafeGet": true, "SafeGetSV": true, "GetBatch": true, "GetBatchSV": true,
+	"Scan": true, "ScanSV": true, "Count": true, "CurrentRoot": true, "Inclusion": true, "Consistency": true,
+	"ByIndex": true, "BySafeIndex": true, "ByIndexSV": true, "History": true, "HistorySV": true,
+	"Health": true, "ZScan": true, "ZScanSV": true, "IScan": true, "IScanSV": true,
+}
+
+// EndpointStatus the state of one of the endpoints of a multi-endpoint client
+type EndpointStatus struct {
+	Address    string
+	Primary    bool
+	Healthy    bool
+	ServerUuid string
+	LastError  error
+}
+
+type endpoint struct {
+	address       string
+	primary       bool
+	conn          *grpc.ClientConn
+	serviceClient schema.ImmuServiceClient
+	rootService   RootService
+	serverUuid    string
+	uuidChanged   bool
+	token         string
+	downUntil     time.Time
+	lastErr       error
+	sync.RWMutex
+}
+
+func (ep *endpoint) available(now time.Time) bool {
+	ep.RLock()
+	defer ep.RUnlock()
+	return !ep.uuidChanged && !now.Before(ep.downUntil)
+}
+
+func (ep *endpoint) markDown(err error, retryAfter time.Duration) {
+	ep.Lock()
+	defer ep.Unlock()
+	ep.lastErr = err
+	ep.downUntil = time.Now().Add(retryAfter)
+}
+
+func (ep *endpoint) getToken() string {
+	ep.RLock()
+	defer ep.RUnlock()
+	return ep.token
+}
+
+// tokenUnaryInterceptor authenticates the calls to a secondary endpoint with the token it issued
+func (ep *endpoint) tokenUnaryInterceptor(
+	ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+) error {
+	return invoker(ctx, method, req, reply, cc, withToken(opts, ep.getToken())...)
+}
+
+func (ep *endpoint) tokenStreamInterceptor(
+	ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	return streamer(ctx, desc, cc, method, withToken(opts, ep.getToken())...)
+}
+
+// endpointPool the endpoints of a multi-endpoint client: writes go to the primary one, reads to any available one
+type endpointPool struct {
+	endpoints  []*endpoint
+	primary    *endpoint
+	next       int
+	retryAfter time.Duration
+	logger     logger.Logger
+	sync.Mutex
+}
+
+type pinnedEndpointKey struct{}
+
+// connectEndpoints dials the secondary endpoints; the primary one is the connection already dialed to Options.Bind()
+func (c *immuClient) connectEndpoints() error {
+	// the root services, and so the roots verified so far, survive a reconnection: the ones of the secondary
+	// endpoints are rebuilt on the new connections, the one of the primary endpoint is rebuilt by Reconnect
+	rootServices := map[string]RootService{}
+	if c.endpoints != nil {
+		for _, ep := range c.endpoints.endpoints {
+			rootServices[ep.address] = ep.rootService
+		}
+	}
+	c.closeEndpoints()
+	primary := &endpoint{address: c.Options.Bind(), primary: true, conn: c.clientConn, rootService: rootServices[c.Options.Bind()]}
+	primary.serviceClient = schema.NewImmuServiceClient(c.clientConn)
+	pool := &endpointPool{
+		endpoints:  []*endpoint{primary},
+		primary:    primary,
+		retryAfter: c.Options.EndpointRetryAfter,
+		logger:     c.Logger,
+	}
+	for _, address := range c.Options.Endpoints {
+		if address == primary.address {
+			continue
+		}
+		ep := &endpoint{address: address, rootService: rootServices[address]}
+		dialOptions := append(append([]grpc.DialOption{}, *c.Options.DialOptions...),
+			grpc.WithChainUnaryInterceptor(ep.tokenUnaryInterceptor),
+			grpc.WithChainStreamInterceptor(ep.tokenStreamInterceptor))
+		conn, err := grpc.Dial(address, dialOptions...)
+		if err != nil {
+			pool.close()
+			return err
+		}
+		ep.conn = conn
+		ep.serviceClient = schema.NewImmuServiceClient(conn)
+		ep.rootService = reconnectedRootService(ep.rootService, ep.serviceClient)
+		pool.endpoints = append(pool.endpoints, ep)
+	}
+	c.endpoints = pool
+	return nil
+}
+
+func (c *immuClient) closeEndpoints() {
+	if c.endpoints != nil {
+		c.endpoints.close()
+		c.endpoints = nil
+	}
+}
+
+// close closes the connections of the secondary endpoints, the primary one is owned by the client
+func (p *endpointPool) close() {
+	for _, ep := range p.endpoints {
+		if !ep.primary && ep.conn != nil {
+			ep.conn.Close()
+		}
+	}
+}
+
+// withEndpointRootServices gives each secondary endpoint its own root service, so that the roots of every
+// server are verified and cached under its own UUID
+func (c *immuClient) withEndpointRootServices(rootCache cache.Cache, l logger.Logger, serverSigningPubKey crypto.PublicKey) {
+	if c.endpoints == nil {
+		return
+	}
+	for _, ep := range c.endpoints.endpoints {
+		if ep.primary {
+			ep.rootService = c.Rootservice
+			continue
+		}
+		ep.rootService = NewVerifyingRootService(ep.serviceClient, rootCache, l, serverSigningPubKey)
+	}
+}
+
+// candidates returns the available endpoints in round robin order
+func (p *endpointPool) candidates() []*endpoint {
+	p.Lock()
+	defer p.Unlock()
+	now := time.Now()
+	result := make([]*endpoint, 0, len(p.endpoints))
+	for i := range p.endpoints {
+		ep := p.endpoints[(p.next+i)%len(p.endpoints)]
+		if ep.available(now) {
+			result = append(result, ep)
+		}
+	}
+	p.next = (p.next + 1) % len(p.endpoints)
+	return result
+}
+
+// healthCheck checks every endpoint, recording the UUID of its server, and fails only if none is healthy
+func (p *endpointPool) healthCheck(ctx context.Context) error {
+	var err error
+	healthy := 0
+	for _, ep := range p.endpoints {
+		if e := p.check(ctx, ep); e != nil {
+			err = e
+			continue
+		}
+		healthy++
+	}
+	if healthy == 0 {
+		return err
+	}
+	return nil
+}
+
+func (p *endpointPool) check(ctx context.Context, ep *endpoint) error {
+	var metadata runtime.ServerMetadata
+	response, err := ep.serviceClient.Health(ctx, new(empty.Empty), grpc.Header(&metadata.HeaderMD))
+	if err == nil && !response.GetStatus() {
+		err = ErrHealthCheckFailed
+	}
+	if err != nil {
+		ep.markDown(err, p.retryAfter)
+		return err
+	}
+	var serverUuid string
+	if uuids := metadata.HeaderMD.Get(server.SERVER_UUID_HEADER); len(uuids) > 0 {
+		serverUuid = uuids[0]
+	}
+	ep.Lock()
+	defer ep.Unlock()
+	if ep.uuidChanged {
+		return ep.lastErr
+	}
+	if ep.serverUuid != "" && serverUuid != ep.serverUuid {
+		p.logger.Errorf("server UUID of endpoint %s changed from %s to %s, no read will be sent to it anymore",
+			ep.address, ep.serverUuid, serverUuid)
+		ep.uuidChanged = true
+		ep.lastErr = ErrServerUuidChanged
+		return ep.lastErr
+	}
+	ep.serverUuid = serverUuid
+	ep.downUntil = time.Time{}
+	ep.lastErr = nil
+	return nil
+}
+
+// Endpoints returns the state of the endpoints of a multi-endpoint client
+func (c *immuClient) Endpoints() []EndpointStatus {
+	if c.endpoints == nil {
+		return nil
+	}
+	now := time.Now()
+	result := make([]EndpointStatus, 0, len(c.endpoints.endpoints))
+	for _, ep := range c.endpoints.endpoints {
+		healthy := ep.available(now)
+		ep.RLock()
+		result = append(result, EndpointStatus{
+			Address:    ep.address,
+			Primary:    ep.primary,
+			Healthy:    healthy,
+			ServerUuid: ep.serverUuid,
+			LastError:  ep.lastErr,
+		})
+		ep.RUnlock()
+	}
+	return result
+}
+
+// pinPrimary makes all the calls made with the returned context go to the primary endpoint, so that the proofs
+// returned by writes are verified against the roots of the same server
+func (c *immuClient) pinPrimary(ctx context.Context) context.Context {
+	if c.endpoints == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, pinnedEndpointKey{}, c.endpoints.primary)
+}
+
+// pinnedRead reads the trusted root and runs call with it, making all the calls go to the same endpoint so that
+// the proofs returned are verified against the roots of the same server. If the endpoint is unavailable, it is
+// marked as down and both the root and call are retried on the next endpoint, before any proof is checked.
+// The context pinned to the endpoint which served the call and the root used are returned
+func (c *immuClient) pinnedRead(
+	ctx context.Context, call func(ctx context.Context, root *schema.Root) error,
+) (context.Context, *schema.Root, error) {
+	if c.endpoints == nil {
+		root, err := c.Rootservice.GetRoot(ctx, c.Options.CurrentDatabase)
+		if err != nil {
+			return ctx, nil, err
+		}
+		return ctx, root, call(ctx, root)
+	}
+	var err error
+	for _, ep := range c.endpoints.readCandidates() {
+		pinned := context.WithValue(ctx, pinnedEndpointKey{}, ep)
+		var root *schema.Root
+		if root, err = c.rootService(pinned).GetRoot(pinned, c.Options.CurrentDatabase); err == nil {
+			if err = call(pinned, root); err == nil {
+				return pinned, root, nil
+			}
+		}
+		if status.Code(err) != codes.Unavailable {
+			return pinned, root, err
+		}
+		ep.markDown(err, c.endpoints.retryAfter)
+		c.Logger.Warningf("endpoint %s is unavailable, failing over: %v", ep.address, err)
+	}
+	return ctx, nil, err
+}
+
+// readCandidates returns, in round robin order, the available endpoints which can verify the proofs of their
+// server with their own root service. The primary endpoint is always among them, even if considered down
+func (p *endpointPool) readCandidates() []*endpoint {
+	var result []*endpoint
+	primary := false
+	for _, ep := range p.candidates() {
+		if ep.primary || ep.rootService != nil {
+			result = append(result, ep)
+			primary = primary || ep.primary
+		}
+	}
+	if !primary {
+		result = append(result, p.primary)
+	}
+	return result
+}
+
+// rootService returns the root service of the endpoint the calls made with ctx are pinned to
+func (c *immuClient) rootService(ctx context.Context) RootService {
+	if ep, ok := ctx.Value(pinnedEndpointKey{}).(*endpoint); ok && ep.rootService != nil {
+		return ep.rootService
+	}
+	return c.Rootservice
+}
+
+// endpointsDialOptions returns the interceptor routing the calls among the endpoints, if more than one is set
+func (c *immuClient) endpointsDialOptions(options *Options) []grpc.DialOption {
+	if len(options.Endpoints) == 0 {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithChainUnaryInterceptor(c.endpointsUnaryInterceptor)}
+}
+
+func (c *immuClient) endpointsUnaryInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	pool := c.endpoints
+	if pool == nil || cc != pool.primary.conn {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	name := method
+	if len(method) > len(immuServicePrefix) && method[:len(immuServicePrefix)] == immuServicePrefix {
+		name = method[len(immuServicePrefix):]
+	}
+	if ep, ok := ctx.Value(pinnedEndpointKey{}).(*endpoint); ok {
+		return pool.invoke(ctx, ep, method, req, reply, invoker, cc, opts)
+	}
+	switch {
+	case name == "Login" || name == "UseDatabase":
+		if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+			return err
+		}
+		pool.broadcastSession(ctx, name, method, req)
+		return nil
+	case !readOnlyMethods[name]:
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	var err error
+	for _, ep := range pool.candidates() {
+		if err = pool.invoke(ctx, ep, method, req, reply, invoker, cc, opts); err == nil {
+			return nil
+		}
+		switch status.Code(err) {
+		case codes.Unavailable:
+			ep.markDown(err, pool.retryAfter)
+			pool.logger.Warningf("endpoint %s is unavailable, failing over: %v", ep.address, err)
+		case codes.Unauthenticated:
+			if ep.primary {
+				return err
+			}
+		default:
+			return err
+		}
+	}
+	if err == nil || status.Code(err) == codes.Unauthenticated {
+		// no secondary endpoint could serve the call, the primary one is tried even if considered down
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	return err
+}
+
+func (p *endpointPool) invoke(
+	ctx context.Context, ep *endpoint, method string, req, reply interface{},
+	invoker grpc.UnaryInvoker, cc *grpc.ClientConn, opts []grpc.CallOption,
+) error {
+	if ep.primary {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	return ep.conn.Invoke(ctx, method, req, reply, withoutToken(opts)...)
+}
+
+// broadcastSession logs in and selects the database also on the secondary endpoints, each of which issues its own token
+func (p *endpointPool) broadcastSession(ctx context.Context, name string, method string, req interface{}) {
+	for _, ep := range p.endpoints {
+		if ep.primary {
+			continue
+		}
+		var token string
+		var err error
+		switch name {
+		case "Login":
+			reply := new(schema.LoginResponse)
+			if err = ep.conn.Invoke(ctx, method, req, reply); err == nil {
+				token = string(reply.GetToken())
+			}
+		case "UseDatabase":
+			reply := new(schema.UseDatabaseReply)
+			if err = ep.conn.Invoke(ctx, method, req, reply); err == nil {
+				token = reply.GetToken()
+			}
+		}
+		if err != nil {
+			p.logger.Warningf("error sending %s to endpoint %s: %v", name, ep.address, err)
+			ep.markDown(err, p.retryAfter)
+			continue
+		}
+		ep.Lock()
+		ep.token = token
+		ep.Unlock()
+	}
+}
+
+// withoutToken removes the token of the primary endpoint from the call options
+func withoutToken(opts []grpc.CallOption) []grpc.CallOption {
+	callOpts := make([]grpc.CallOption, 0, len(opts))
+	for _, o := range opts {
+		if _, ok := o.(grpc.PerRPCCredsCallOption); !ok {
+			callOpts = append(callOpts, o)
+		}
+	}
+	return callOpts
+}
+
+// withToken replaces the token in the call options, if token is not empty
+func withToken(opts []grpc.CallOption, token string) []grpc.CallOption {
+	if token == "" {
+		return opts
+	}
+	return append(withoutToken(opts), grpc.PerRPCCredentials(auth.TokenAuth{Token: token}))
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/client/cache"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newReplica() (*bufconn.Listener, *grpc.Server) {
+	is := server.DefaultServer()
+	is = is.WithOptions(is.Options.WithAuth(true).WithInMemoryStore(true))
+	is.Start()
+	replicaLis := bufconn.Listen(bufSize)
+	uuidContext := server.NewUuidContext(xid.New())
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(uuidContext.UuidContextSetter, auth.ServerUnaryInterceptor),
+		grpc.ChainStreamInterceptor(uuidContext.UuidStreamContextSetter, auth.ServerStreamInterceptor),
+	)
+	schema.RegisterImmuServiceServer(s, is)
+	go s.Serve(replicaLis)
+	return replicaLis, s
+}
+
+func TestMultiEndpointClient(t *testing.T) {
+	setup()
+	ctx := context.Background()
+	replicaLis, replica := newReplica()
+	defer replica.Stop()
+
+	options := DefaultOptions().
+		WithAddress("primary").
+		WithEndpoints([]string{"primary:3322", "replica:3322"}).
+		WithRetryPolicy(DefaultRetryPolicy())
+	dialOptions := []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			if address == "replica:3322" {
+				return replicaLis.Dial()
+			}
+			return lis.Dial()
+		}),
+		grpc.WithInsecure(),
+	}
+	c := DefaultClient().WithOptions(options.WithDialOptions(&dialOptions))
+	dialOptions = append(dialOptions, c.reconnectDialOptions(options)...)
+	dialOptions = append(dialOptions, c.endpointsDialOptions(options)...)
+	clientConn, err := c.Connect(ctx)
+	require.NoError(t, err)
+	nm, _ := NewNtpMock()
+	c.WithClientConn(clientConn).
+		WithServiceClient(schema.NewImmuServiceClient(clientConn)).
+		WithTimestampService(NewTimestampService(nm)).
+		WithRootService(NewRootService(c.ServiceClient, cache.NewInMemoryCache(), slog))
+	// the test servers do not publish their UUID, so each endpoint needs its own cache
+	for _, ep := range c.endpoints.endpoints {
+		ep.rootService = NewRootService(ep.serviceClient, cache.NewInMemoryCache(), logger.NewSimpleLogger("test", os.Stdout))
+	}
+	require.Len(t, c.Endpoints(), 2)
+	require.True(t, c.Endpoints()[0].Primary)
+
+	_, err = c.Login(ctx, []byte(username), []byte(plainPass))
+	require.NoError(t, err)
+	_, err = c.UseDatabase(ctx, &schema.Database{Databasename: "defaultdb"})
+	require.NoError(t, err)
+	require.NotEmpty(t, c.endpoints.endpoints[1].getToken())
+	require.NoError(t, c.HealthCheck(ctx))
+
+	// writes go to the primary only, reads to both endpoints
+	_, err = c.Set(ctx, []byte("endpoint-key"), []byte("primary"))
+	require.NoError(t, err)
+	_, err = c.endpoints.endpoints[1].serviceClient.Set(ctx, &schema.KeyValue{Key: []byte("endpoint-key"), Value: []byte("replica")})
+	require.NoError(t, err)
+	values := map[string]bool{}
+	for i := 0; i < 4; i++ {
+		item, err := c.ServiceClient.Get(ctx, &schema.Key{Key: []byte("endpoint-key")})
+		require.NoError(t, err)
+		values[string(item.GetValue())] = true
+	}
+	require.Len(t, values, 2)
+
+	for i := 0; i < 4; i++ {
+		_, err = c.SafeSet(ctx, []byte("endpoint-safe-key"), []byte("v"))
+		require.NoError(t, err)
+		kv, err := c.NewSKV([]byte("endpoint-safe-key"), []byte("v")).ToKV()
+		require.NoError(t, err)
+		_, err = c.endpoints.endpoints[1].serviceClient.Set(ctx, kv)
+		require.NoError(t, err)
+		vi, err := c.SafeGet(ctx, []byte("endpoint-safe-key"))
+		require.NoError(t, err)
+		require.True(t, vi.Verified)
+	}
+
+	// the replica goes away: reads fail over to the primary
+	replica.Stop()
+	for i := 0; i < 2; i++ {
+		item, err := c.ServiceClient.Get(ctx, &schema.Key{Key: []byte("endpoint-key")})
+		require.NoError(t, err)
+		require.NotNil(t, item)
+	}
+	require.False(t, c.Endpoints()[1].Healthy)
+	require.NotNil(t, c.Endpoints()[1].LastError)
+
+	// a different server answers at the primary address
+	c.endpoints.primary.serverUuid = "another-uuid"
+	require.Error(t, c.HealthCheck(ctx))
+	require.Equal(t, ErrServerUuidChanged, c.Endpoints()[0].LastError)
+	require.NoError(t, c.Disconnect())
+}
+
+func TestNewImmuClientEndpointsFailover(t *testing.T) {
+	ctx := context.Background()
+	primaryLis, primary := newReplica()
+	defer primary.Stop()
+	replicaLis, replica := newReplica()
+	defer replica.Stop()
+	dir, err := ioutil.TempDir("", "endpoints-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	dialOptions := []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			if address == "replica:3322" {
+				return replicaLis.Dial()
+			}
+			return primaryLis.Dial()
+		}),
+	}
+	options := DefaultOptions().
+		WithAddress("primary").
+		WithDir(dir).
+		WithEndpoints([]string{"primary:3322", "replica:3322"}).
+		WithRetryPolicy(DefaultRetryPolicy()).
+		WithDialOptions(&dialOptions)
+	ic, err := NewImmuClient(options)
+	require.NoError(t, err)
+	c := ic.(*immuClient)
+	defer c.Disconnect()
+
+	// every endpoint verifies the proofs of its server with the roots of that server only
+	require.Len(t, c.endpoints.endpoints, 2)
+	require.Equal(t, c.Rootservice, c.endpoints.primary.rootService)
+	require.NotNil(t, c.endpoints.endpoints[1].rootService)
+	require.NotEqual(t, c.endpoints.endpoints[0].serverUuid, c.endpoints.endpoints[1].serverUuid)
+
+	_, err = c.Login(ctx, []byte(auth.SysAdminUsername), []byte(auth.SysAdminPassword))
+	require.NoError(t, err)
+	_, err = c.UseDatabase(ctx, &schema.Database{Databasename: "defaultdb"})
+	require.NoError(t, err)
+	_, err = c.SafeSet(ctx, []byte("failover-key"), []byte("v"))
+	require.NoError(t, err)
+	kv, err := c.NewSKV([]byte("failover-key"), []byte("v")).ToKV()
+	require.NoError(t, err)
+	_, err = c.endpoints.endpoints[1].serviceClient.Set(ctx, kv)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		vi, err := c.SafeGet(ctx, []byte("failover-key"))
+		require.NoError(t, err)
+		require.True(t, vi.Verified)
+	}
+
+	// after a reconnection every endpoint verifies the proofs on its new connection
+	require.NoError(t, c.Reconnect(ctx))
+	require.Equal(t, c.Rootservice, c.endpoints.primary.rootService)
+	for _, ep := range c.endpoints.endpoints {
+		require.Equal(t, ep.serviceClient, ep.rootService.(*rootservice).client)
+	}
+	for i := 0; i < 2; i++ {
+		vi, err := c.SafeGet(ctx, []byte("failover-key"))
+		require.NoError(t, err)
+		require.True(t, vi.Verified)
+	}
+
+	// the replica goes away: the safe reads fail over to the primary, root included
+	replica.Stop()
+	for i := 0; i < 2; i++ {
+		vi, err := c.SafeGet(ctx, []byte("failover-key"))
+		require.NoError(t, err)
+		require.True(t, vi.Verified)
+		vi, err = c.RawBySafeIndex(ctx, vi.Index)
+		require.NoError(t, err)
+		require.True(t, vi.Verified)
+	}
+	require.False(t, c.Endpoints()[1].Healthy)
+	require.NotNil(t, c.Endpoints()[1].LastError)
+}
@@ -25,4 +25,14 @@ var (
 	ErrAlreadyConnected  = errors.New("already connected")
 	ErrNotConnected      = errors.New("not connected")
 	ErrHealthCheckFailed = errors.New("health check failed")
+	ErrNoCredentials     = errors.New("no credentials or token provider to login again")
 )
+
+// Errors related to root signatures
+var (
+	ErrMissingRootSignature = errors.New("root is not signed by the server")
+	ErrInvalidRootSignature = errors.New("root signature does not match the pinned server public key")
+)
+
+// ErrInconclusiveConsistency is returned when the server root keeps changing while the consistency of two roots is proven
+var ErrInconclusiveConsistency = errors.New("consistency of the roots could not be proven against a single server root")
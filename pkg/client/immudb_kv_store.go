@@ -0,0 +1,167 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client/cache"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type immudbKVStore struct {
+	client ImmuClient
+	prefix []byte
+}
+
+// NewImmudbKVStore returns a kv store backed by the immudb instance of client. immudb has no conditional writes,
+// so every value is appended under a new versioned key and, when several clients write the same version, the
+// first write in the immudb history wins the compare-and-swap.
+// The values read are verified against the trusted root of client, which therefore must not keep it in a shared
+// cache backed by this same store.
+// immudb never removes entries: every update adds one versioned key under prefix, which is why the store is meant
+// for small values updated rarely, such as trusted roots. Reads only look at the latest version, so they do not
+// slow down as versions accumulate.
+// Which version is the latest is told by the server and can not be proven: a verified read shows that a version
+// exists, not that no newer one does. The version following the one found is probed with a verified read, so that
+// a newer version left out of the listing is found, but a server hiding every newer version can still serve an
+// older value, and make a compare-and-swap succeed against it.
+func NewImmudbKVStore(client ImmuClient, prefix []byte) cache.KVStore {
+	return &immudbKVStore{client: client, prefix: prefix}
+}
+
+func (s *immudbKVStore) versionPrefix(key []byte) []byte {
+	k := make([]byte, 0, len(s.prefix)+len(key)+1)
+	k = append(k, s.prefix...)
+	k = append(k, key...)
+	return append(k, '/')
+}
+
+// versionKey encodes the version with a fixed width so that keys sort by version
+func (s *immudbKVStore) versionKey(key []byte, version uint64) []byte {
+	return append(s.versionPrefix(key), fmt.Sprintf("%020d", version)...)
+}
+
+// latest returns the latest version of key and its value. The scan telling which is the latest versioned key is not
+// verified: the following versions are probed with verified reads until one is not found, then the value is verified
+func (s *immudbKVStore) latest(ctx context.Context, key []byte) (uint64, []byte, error) {
+	version, err := s.scanLatest(ctx, key)
+	if err != nil {
+		return 0, nil, err
+	}
+	for {
+		next := s.versionKey(key, version+1)
+		item, err := s.client.RawSafeGet(ctx, next)
+		if status.Code(err) == codes.NotFound {
+			break
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+		if !item.Verified || !bytes.Equal(item.Key, next) {
+			return 0, nil, fmt.Errorf("versioned key %s could not be verified", next)
+		}
+		version++
+	}
+	if version == 0 {
+		return 0, nil, nil
+	}
+	first, err := s.first(ctx, s.versionKey(key, version))
+	if err != nil {
+		return 0, nil, err
+	}
+	return version, first.Value, nil
+}
+
+// scanLatest returns the latest version of key listed by the server, 0 if there is none
+func (s *immudbKVStore) scanLatest(ctx context.Context, key []byte) (uint64, error) {
+	prefix := s.versionPrefix(key)
+	list, err := (*s.client.GetServiceClient()).Scan(ctx, &schema.ScanOptions{Prefix: prefix, Limit: 1, Reverse: true})
+	if err != nil {
+		return 0, err
+	}
+	if len(list.GetItems()) == 0 {
+		return 0, nil
+	}
+	versionKey := list.GetItems()[0].GetKey()
+	if !bytes.HasPrefix(versionKey, prefix) {
+		return 0, fmt.Errorf("invalid versioned key %s", versionKey)
+	}
+	version, err := strconv.ParseUint(string(versionKey[len(prefix):]), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid versioned key %s: %v", versionKey, err)
+	}
+	return version, nil
+}
+
+// first returns the earliest write of a versioned key, the one that won the compare-and-swap, verified against
+// the trusted root
+func (s *immudbKVStore) first(ctx context.Context, versionKey []byte) (*VerifiedItem, error) {
+	history, err := (*s.client.GetServiceClient()).History(ctx, &schema.Key{Key: versionKey})
+	if err != nil {
+		return nil, err
+	}
+	var index uint64
+	found := false
+	for _, item := range history.GetItems() {
+		if !found || item.GetIndex() < index {
+			index, found = item.GetIndex(), true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("no value found for versioned key %s", versionKey)
+	}
+	first, err := s.client.RawBySafeIndex(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+	if !first.Verified || !bytes.Equal(first.Key, versionKey) {
+		return nil, fmt.Errorf("value of versioned key %s at index %d could not be verified", versionKey, index)
+	}
+	return first, nil
+}
+
+func (s *immudbKVStore) Get(key []byte) ([]byte, error) {
+	_, value, err := s.latest(context.Background(), key)
+	return value, err
+}
+
+func (s *immudbKVStore) CompareAndSwap(key []byte, old []byte, value []byte) (bool, error) {
+	ctx := context.Background()
+	version, current, err := s.latest(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	if (old == nil && current != nil) || (old != nil && !bytes.Equal(current, old)) {
+		return false, nil
+	}
+	versionKey := s.versionKey(key, version+1)
+	index, err := (*s.client.GetServiceClient()).Set(ctx, &schema.KeyValue{Key: versionKey, Value: value})
+	if err != nil {
+		return false, err
+	}
+	first, err := s.first(ctx, versionKey)
+	if err != nil {
+		return false, err
+	}
+	return first.Index == index.GetIndex(), nil
+}
@@ -19,6 +19,7 @@ package client
 import (
 	"encoding/json"
 	"strconv"
+	"time"
 
 	"google.golang.org/grpc"
 )
@@ -28,17 +29,33 @@ const AdminTokenFileSuffix = "_admin"
 
 // Options client options
 type Options struct {
-	Dir                string
-	Address            string
-	Port               int
-	HealthCheckRetries int
-	MTLs               bool
-	MTLsOptions        MTLsOptions
-	Auth               bool
-	DialOptions        *[]grpc.DialOption
-	Config             string
-	TokenFileName      string
-	CurrentDatabase    string
+	Dir                 string
+	Address             string
+	Port                int
+	HealthCheckRetries  int
+	MTLs                bool
+	MTLsOptions         MTLsOptions
+	Auth                bool
+	DialOptions         *[]grpc.DialOption
+	Config              string
+	TokenFileName       string
+	CurrentDatabase     string
+	ServerSigningPubKey string
+	// VerifiedItemCacheSize is the maximum number of items verified by SafeGet kept in memory, 0 disables the cache
+	VerifiedItemCacheSize int
+	// RetryPolicy enables retrying the idempotent calls when the server is unavailable and logging in
+	// again when the token is rejected; nil disables both
+	RetryPolicy *RetryPolicy
+	// Username and Password are the credentials used to login again, if not set the ones of the last Login are used
+	Username string
+	Password string `json:"-"`
+	// TokenProvider, if set, is used instead of the credentials to get a new token
+	TokenProvider TokenProvider `json:"-"`
+	// Endpoints are the addresses (host:port) of the replicas the read-only calls can be sent to;
+	// the primary endpoint, receiving the writes, is Address:Port
+	Endpoints []string
+	// EndpointRetryAfter is how long an unavailable endpoint is not used
+	EndpointRetryAfter time.Duration
 }
 
 // DefaultOptions ...
@@ -53,6 +70,7 @@ func DefaultOptions() *Options {
 		Config:             "configs/immuclient.toml",
 		TokenFileName:      "token",
 		DialOptions:        &[]grpc.DialOption{},
+		EndpointRetryAfter: 10 * time.Second,
 	}
 }
 
@@ -118,6 +136,49 @@ func (o *Options) WithDialOptions(dialOptions *[]grpc.DialOption) *Options {
 	return o
 }
 
+// WithServerSigningPubKey sets the path of the PEM encoded public key pinned to verify the roots signed by the server
+func (o *Options) WithServerSigningPubKey(serverSigningPubKey string) *Options {
+	o.ServerSigningPubKey = serverSigningPubKey
+	return o
+}
+
+// WithVerifiedItemCacheSize sets the maximum number of verified items cached by SafeGet
+func (o *Options) WithVerifiedItemCacheSize(size int) *Options {
+	o.VerifiedItemCacheSize = size
+	return o
+}
+
+// WithRetryPolicy sets the policy retrying the calls and logging in again
+func (o *Options) WithRetryPolicy(retryPolicy *RetryPolicy) *Options {
+	o.RetryPolicy = retryPolicy
+	return o
+}
+
+// WithCredentials sets the credentials used to login again when the token is rejected
+func (o *Options) WithCredentials(username string, password string) *Options {
+	o.Username = username
+	o.Password = password
+	return o
+}
+
+// WithTokenProvider sets the callback returning a new token when the current one is rejected
+func (o *Options) WithTokenProvider(tokenProvider TokenProvider) *Options {
+	o.TokenProvider = tokenProvider
+	return o
+}
+
+// WithEndpoints sets the addresses (host:port) of the replicas the read-only calls can be sent to
+func (o *Options) WithEndpoints(endpoints []string) *Options {
+	o.Endpoints = endpoints
+	return o
+}
+
+// WithEndpointRetryAfter sets how long an unavailable endpoint is not used
+func (o *Options) WithEndpointRetryAfter(retryAfter time.Duration) *Options {
+	o.EndpointRetryAfter = retryAfter
+	return o
+}
+
 // Bind concatenates address and port
 func (o *Options) Bind() string {
 	return o.Address + ":" + strconv.Itoa(o.Port)
@@ -0,0 +1,310 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const immuServicePrefix = "/immudb.schema.ImmuService/"
+
+// TokenProvider returns a fresh token, used to login again when the server rejects the current one
+type TokenProvider func(ctx context.Context) (string, error)
+
+// RetryPolicy configures how the calls failing because the server is unavailable are retried
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of a call, the first one included
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// IdempotentMethods are the names of the ImmuService methods which are safe to send again
+	IdempotentMethods map[string]bool
+}
+
+// DefaultRetryPolicy returns a policy retrying the read-only methods up to 5 times, with an exponential backoff
+func DefaultRetryPolicy() *RetryPolicy {
+	methods := map[string]bool{}
+	for _, m := range []string{
+		"Get", "GetSV", "SafeGet", "SafeGetSV", "GetBatch", "GetBatchSV", "Scan", "ScanSV", "Count",
+		"CurrentRoot", "Inclusion", "Consistency", "ByIndex", "BySafeIndex", "ByIndexSV", "History", "HistorySV",
+		"Health", "ZScan", "ZScanSV", "IScan", "IScanSV", "ListUsers", "GetUser", "DatabaseList", "ListRoles",
+		"CorruptionCheckerStatus", "Login", "UseDatabase",
+	} {
+		methods[m] = true
+	}
+	return &RetryPolicy{
+		MaxAttempts:       5,
+		InitialBackoff:    100 * time.Millisecond,
+		MaxBackoff:        5 * time.Second,
+		Multiplier:        2,
+		IdempotentMethods: methods,
+	}
+}
+
+// WithMaxAttempts sets the maximum number of attempts of a call
+func (p *RetryPolicy) WithMaxAttempts(maxAttempts int) *RetryPolicy {
+	p.MaxAttempts = maxAttempts
+	return p
+}
+
+// WithBackoff sets the initial and maximum backoff between two attempts and the multiplier applied after each one
+func (p *RetryPolicy) WithBackoff(initial time.Duration, max time.Duration, multiplier float64) *RetryPolicy {
+	p.InitialBackoff = initial
+	p.MaxBackoff = max
+	p.Multiplier = multiplier
+	return p
+}
+
+func (p *RetryPolicy) isIdempotent(fullMethod string) bool {
+	if len(fullMethod) <= len(immuServicePrefix) || fullMethod[:len(immuServicePrefix)] != immuServicePrefix {
+		return false
+	}
+	return p.IdempotentMethods[fullMethod[len(immuServicePrefix):]]
+}
+
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d = time.Duration(float64(d) * p.Multiplier)
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// reloginKey marks the context of the calls issued while logging in again, which must not trigger another login
+type reloginKey struct{}
+
+// session holds the token and the database selection replayed after the client logs in again
+type session struct {
+	token    string
+	user     []byte
+	password []byte
+	database string
+	sync.RWMutex
+	relogin sync.Mutex
+}
+
+func (s *session) getToken() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.token
+}
+
+func (s *session) setToken(token string) {
+	s.Lock()
+	defer s.Unlock()
+	s.token = token
+}
+
+// reconnectDialOptions returns the interceptors retrying the calls and logging in again, if a retry policy is set
+func (c *immuClient) reconnectDialOptions(options *Options) []grpc.DialOption {
+	if options.RetryPolicy == nil {
+		return nil
+	}
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(c.reconnectUnaryInterceptor),
+		grpc.WithChainStreamInterceptor(c.reconnectStreamInterceptor),
+	}
+}
+
+// withSessionToken replaces the token the connection was dialed with by the one of the current session
+func (c *immuClient) withSessionToken(opts []grpc.CallOption) []grpc.CallOption {
+	return withToken(opts, c.session.getToken())
+}
+
+func (c *immuClient) reconnectUnaryInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	if c.endpoints != nil && cc != c.endpoints.primary.conn {
+		// secondary endpoints are retried and authenticated by the endpoints interceptor
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	policy := c.Options.RetryPolicy
+	var err error
+	relogged := false
+	for attempt := 1; ; attempt++ {
+		token := c.session.getToken()
+		err = invoker(ctx, method, req, reply, cc, withToken(opts, token)...)
+		if err == nil {
+			c.trackSession(method, req, reply)
+			return nil
+		}
+		switch status.Code(err) {
+		case codes.Unauthenticated:
+			// the call has been rejected before being executed, so any method can be sent again
+			if relogged || method == immuServicePrefix+"Login" || ctx.Value(reloginKey{}) != nil {
+				return err
+			}
+			if lerr := c.relogin(ctx, token); lerr != nil {
+				c.Logger.Warningf("unable to login again after %s failed: %v", method, lerr)
+				return err
+			}
+			relogged = true
+			continue
+		case codes.Unavailable:
+			if attempt >= policy.MaxAttempts || !policy.isIdempotent(method) {
+				return err
+			}
+		default:
+			return err
+		}
+		c.Logger.Debugf("%s failed (attempt %d of %d), retrying: %v", method, attempt, policy.MaxAttempts, err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(policy.backoff(attempt)):
+		}
+	}
+}
+
+func (c *immuClient) reconnectStreamInterceptor(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	if c.endpoints != nil && cc != c.endpoints.primary.conn {
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+	return streamer(ctx, desc, cc, method, c.withSessionToken(opts)...)
+}
+
+// trackSession remembers the credentials, the token and the database selected by the successful calls
+func (c *immuClient) trackSession(method string, req, reply interface{}) {
+	switch method {
+	case immuServicePrefix + "Login":
+		lr, lok := req.(*schema.LoginRequest)
+		res, rok := reply.(*schema.LoginResponse)
+		if !lok || !rok {
+			return
+		}
+		c.session.Lock()
+		c.session.user, c.session.password = lr.GetUser(), lr.GetPassword()
+		c.session.token = string(res.GetToken())
+		c.session.database = ""
+		c.session.Unlock()
+	case immuServicePrefix + "UseDatabase":
+		db, dok := req.(*schema.Database)
+		res, rok := reply.(*schema.UseDatabaseReply)
+		if !dok || !rok {
+			return
+		}
+		c.session.Lock()
+		c.session.token = res.GetToken()
+		c.session.database = db.GetDatabasename()
+		c.session.Unlock()
+	}
+}
+
+// relogin gets a new token, from the token provider or logging in with the stored credentials,
+// and selects again the database in use. If failedToken is not empty and the session token has
+// changed since it was used, another call has already logged in again and nothing is done
+func (c *immuClient) relogin(ctx context.Context, failedToken string) error {
+	c.session.relogin.Lock()
+	defer c.session.relogin.Unlock()
+	if failedToken != "" && c.session.getToken() != failedToken {
+		return nil
+	}
+	ctx = context.WithValue(ctx, reloginKey{}, true)
+
+	c.session.RLock()
+	user, password, database := c.session.user, c.session.password, c.session.database
+	c.session.RUnlock()
+	if c.Options.Username != "" {
+		user, password = []byte(c.Options.Username), []byte(c.Options.Password)
+	}
+
+	var token string
+	switch {
+	case c.Options.TokenProvider != nil:
+		t, err := c.Options.TokenProvider(ctx)
+		if err != nil {
+			return err
+		}
+		token = t
+	case len(user) > 0:
+		res, err := c.ServiceClient.Login(ctx, &schema.LoginRequest{User: user, Password: password})
+		if err != nil {
+			return err
+		}
+		token = string(res.GetToken())
+	default:
+		return ErrNoCredentials
+	}
+	c.session.setToken(token)
+	c.Logger.Infof("logged in again")
+
+	if database != "" {
+		if _, err := c.ServiceClient.UseDatabase(ctx, &schema.Database{Databasename: database}); err != nil {
+			return err
+		}
+		c.Logger.Infof("database %s selected again", database)
+	}
+	return nil
+}
+
+// Reconnect closes the connection, if any, and dials the server again, logging in again with the
+// stored credentials or token provider and selecting again the database in use
+func (c *immuClient) Reconnect(ctx context.Context) error {
+	c.Lock()
+	if c.clientConn != nil {
+		c.clientConn.Close()
+	}
+	clientConn, err := c.Connect(ctx)
+	if err != nil {
+		c.Unlock()
+		return err
+	}
+	c.WithClientConn(clientConn)
+	c.WithServiceClient(schema.NewImmuServiceClient(clientConn))
+	// the root service is bound to the closed connection: it is rebuilt on the new one, keeping the verified roots
+	c.Rootservice = reconnectedRootService(c.Rootservice, c.ServiceClient)
+	if c.endpoints != nil {
+		c.endpoints.primary.rootService = c.Rootservice
+	}
+	c.Unlock()
+	if err = c.WaitForHealthCheck(ctx); err != nil {
+		return err
+	}
+	c.session.RLock()
+	loggedIn := c.session.token != "" || c.Options.Username != "" || c.Options.TokenProvider != nil
+	c.session.RUnlock()
+	if !c.Options.Auth || !loggedIn {
+		return nil
+	}
+	if err = c.relogin(ctx, ""); err != nil && err != ErrNoCredentials {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client/cache"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newReconnectingClient(options *Options) *immuClient {
+	c := DefaultClient().WithOptions(options)
+	dialOptions := []grpc.DialOption{grpc.WithContextDialer(bufDialer), grpc.WithInsecure()}
+	dialOptions = append(dialOptions, c.reconnectDialOptions(options)...)
+	options.WithDialOptions(&dialOptions)
+	clientConn, _ := c.Connect(context.TODO())
+	serviceClient := schema.NewImmuServiceClient(clientConn)
+	nm, _ := NewNtpMock()
+	return c.WithClientConn(clientConn).
+		WithTimestampService(NewTimestampService(nm)).
+		WithServiceClient(serviceClient).
+		WithRootService(NewRootService(serviceClient, cache.NewInMemoryCache(), logger.NewSimpleLogger("test", os.Stdout)))
+}
+
+func TestReconnectRelogin(t *testing.T) {
+	setup()
+	ctx := context.Background()
+	c := newReconnectingClient(DefaultOptions().WithRetryPolicy(DefaultRetryPolicy()))
+
+	_, err := c.Login(ctx, []byte(username), []byte(plainPass))
+	require.NoError(t, err)
+	_, err = c.CreateDatabase(ctx, &schema.Database{Databasename: "reconnectdb"})
+	require.NoError(t, err)
+	_, err = c.UseDatabase(ctx, &schema.Database{Databasename: "reconnectdb"})
+	require.NoError(t, err)
+	_, err = c.Set(ctx, []byte("reconnect-key"), []byte("v1"))
+	require.NoError(t, err)
+
+	// the token is invalidated: the client logs in again and selects the same database
+	_, err = (*client.GetServiceClient()).Logout(ctx, &empty.Empty{})
+	require.NoError(t, err)
+	item, err := c.Get(ctx, []byte("reconnect-key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("v1"), item.Value.Payload)
+
+	require.NoError(t, c.Disconnect())
+	_, err = c.Get(ctx, []byte("reconnect-key"))
+	require.Equal(t, ErrNotConnected, err)
+	require.NoError(t, c.Reconnect(ctx))
+	item, err = c.Get(ctx, []byte("reconnect-key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("v1"), item.Value.Payload)
+
+	// the root service verifies the proofs on the new connection
+	index, err := c.SafeSet(ctx, []byte("reconnect-key"), []byte("v2"))
+	require.NoError(t, err)
+	require.True(t, index.Verified)
+	vi, err := c.SafeGet(ctx, []byte("reconnect-key"))
+	require.NoError(t, err)
+	require.True(t, vi.Verified)
+	require.Equal(t, []byte("v2"), vi.Value)
+	c.Disconnect()
+}
+
+func TestReconnectTokenProvider(t *testing.T) {
+	setup()
+	ctx := context.Background()
+	provided := 0
+	var c *immuClient
+	c = newReconnectingClient(DefaultOptions().
+		WithRetryPolicy(DefaultRetryPolicy()).
+		WithTokenProvider(func(ctx context.Context) (string, error) {
+			provided++
+			res, err := c.ServiceClient.Login(ctx, &schema.LoginRequest{User: []byte(username), Password: []byte(plainPass)})
+			if err != nil {
+				return "", err
+			}
+			return string(res.GetToken()), nil
+		}))
+
+	_, err := c.Set(ctx, []byte("provider-key"), []byte("v"))
+	require.NoError(t, err)
+	require.Equal(t, 1, provided)
+
+	// another call has already logged in again: the token it replaced does not trigger a new login
+	token := c.session.getToken()
+	require.NoError(t, c.relogin(ctx, "replaced-token"))
+	require.Equal(t, 1, provided)
+	require.Equal(t, token, c.session.getToken())
+	c.Disconnect()
+
+	c = newReconnectingClient(DefaultOptions().WithRetryPolicy(DefaultRetryPolicy()))
+	_, err = c.Set(ctx, []byte("provider-key"), []byte("v"))
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	c.Disconnect()
+}
+
+func TestRetryPolicy(t *testing.T) {
+	c := DefaultClient().WithOptions(DefaultOptions().
+		WithRetryPolicy(DefaultRetryPolicy().WithMaxAttempts(3).WithBackoff(time.Millisecond, 2*time.Millisecond, 2)))
+	attempts := 0
+	unavailable := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		attempts++
+		return status.Error(codes.Unavailable, "server restarting")
+	}
+
+	err := c.reconnectUnaryInterceptor(context.TODO(), immuServicePrefix+"Get", nil, nil, nil, unavailable)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, 3, attempts)
+
+	attempts = 0
+	err = c.reconnectUnaryInterceptor(context.TODO(), immuServicePrefix+"Set", nil, nil, nil, unavailable)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, 1, attempts)
+
+	attempts = 0
+	failing := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		attempts++
+		return errors.New("failure")
+	}
+	require.Error(t, c.reconnectUnaryInterceptor(context.TODO(), immuServicePrefix+"Get", nil, nil, nil, failing))
+	require.Equal(t, 1, attempts)
+
+	p := DefaultRetryPolicy().WithBackoff(100*time.Millisecond, time.Second, 2)
+	require.Equal(t, 100*time.Millisecond, p.backoff(1))
+	require.Equal(t, 400*time.Millisecond, p.backoff(3))
+	require.Equal(t, time.Second, p.backoff(10))
+	require.False(t, p.isIdempotent("/other.Service/Get"))
+}
@@ -18,6 +18,7 @@ package client
 
 import (
 	"context"
+	"crypto"
 	"fmt"
 	"sync"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/codenotary/immudb/pkg/client/cache"
 	"github.com/codenotary/immudb/pkg/logger"
 	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/signer"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"google.golang.org/grpc"
@@ -37,15 +39,22 @@ type RootService interface {
 }
 
 type rootservice struct {
-	client     schema.ImmuServiceClient
-	cache      cache.Cache
-	serverUuid string
-	logger     logger.Logger
+	client              schema.ImmuServiceClient
+	cache               cache.Cache
+	serverUuid          string
+	logger              logger.Logger
+	serverSigningPubKey crypto.PublicKey
 	sync.RWMutex
 }
 
 // NewRootService ...
 func NewRootService(immuC schema.ImmuServiceClient, cache cache.Cache, logger logger.Logger) RootService {
+	return NewVerifyingRootService(immuC, cache, logger, nil)
+}
+
+// NewVerifyingRootService returns a root service which accepts only the roots signed with the private key
+// matching serverSigningPubKey. A nil key disables the verification.
+func NewVerifyingRootService(immuC schema.ImmuServiceClient, cache cache.Cache, logger logger.Logger, serverSigningPubKey crypto.PublicKey) RootService {
 	serverUuid, err := GetServerUuid(context.Background(), immuC)
 	if err != nil {
 		if err != ErrNoServerUuid {
@@ -54,10 +63,29 @@ func NewRootService(immuC schema.ImmuServiceClient, cache cache.Cache, logger lo
 		logger.Warningf(err.Error())
 	}
 	return &rootservice{
-		client:     immuC,
-		cache:      cache,
-		logger:     logger,
-		serverUuid: serverUuid,
+		client:              immuC,
+		cache:               cache,
+		logger:              logger,
+		serverUuid:          serverUuid,
+		serverSigningPubKey: serverSigningPubKey,
+	}
+}
+
+// reconnectedRootService returns rs bound to client, sharing its cache and so the roots verified so far.
+// The root services which are not built by NewRootService are returned as they are.
+func reconnectedRootService(rs RootService, client schema.ImmuServiceClient) RootService {
+	r, ok := rs.(*rootservice)
+	if !ok {
+		return rs
+	}
+	r.RLock()
+	defer r.RUnlock()
+	return &rootservice{
+		client:              client,
+		cache:               r.cache,
+		logger:              r.logger,
+		serverUuid:          r.serverUuid,
+		serverSigningPubKey: r.serverSigningPubKey,
 	}
 }
 
@@ -72,7 +100,10 @@ func (r *rootservice) GetRoot(ctx context.Context, databasename string) (*schema
 	if root, err := r.client.CurrentRoot(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD)); err != nil {
 		return nil, err
 	} else {
-		if err := r.cache.Set(root, r.serverUuid, databasename); err != nil {
+		if err := r.verifySignature(root, databasename); err != nil {
+			return nil, err
+		}
+		if err := r.setRoot(ctx, root, databasename); err != nil {
 			return nil, err
 		}
 		return root, nil
@@ -82,7 +113,52 @@ func (r *rootservice) GetRoot(ctx context.Context, databasename string) (*schema
 func (r *rootservice) SetRoot(root *schema.Root, databasename string) error {
 	defer r.Unlock()
 	r.Lock()
-	return r.cache.Set(root, r.serverUuid, databasename)
+	if err := r.verifySignature(root, databasename); err != nil {
+		return err
+	}
+	return r.setRoot(context.Background(), root, databasename)
+}
+
+// setRoot stores root in the cache. When another process sharing the cache has already stored a newer root,
+// the older one is only checked to belong to the same history.
+func (r *rootservice) setRoot(ctx context.Context, root *schema.Root, databasename string) error {
+	err := r.cache.Set(root, r.serverUuid, databasename)
+	if err != cache.ErrStaleRoot {
+		return err
+	}
+	cached, err := r.cache.Get(r.serverUuid, databasename)
+	if err != nil {
+		return err
+	}
+	consistent, err := VerifyRootsConsistency(ctx, r.client, root, cached)
+	if err != nil {
+		return err
+	}
+	if !consistent {
+		return cache.ErrInconsistentRoot
+	}
+	return nil
+}
+
+// verifySignature checks the signature of the server over a root, if a server public key is pinned
+func (r *rootservice) verifySignature(root *schema.Root, databasename string) error {
+	if r.serverSigningPubKey == nil {
+		return nil
+	}
+	return VerifyRootSignature(r.serverSigningPubKey, r.serverUuid, databasename, root)
+}
+
+// VerifyRootSignature checks that root has been signed, with the private key matching serverSigningPubKey, by the
+// server identified by serverUuid for the given database. Signed roots can be kept as receipts of what the server committed to.
+func VerifyRootSignature(serverSigningPubKey crypto.PublicKey, serverUuid string, databasename string, root *schema.Root) error {
+	if root.GetSignature() == nil || len(root.GetSignature().GetSignature()) == 0 {
+		return ErrMissingRootSignature
+	}
+	payload := signer.RootPayload(serverUuid, databasename, root.GetIndex(), root.GetRoot())
+	if err := signer.Verify(serverSigningPubKey, payload, root.GetSignature().GetSignature()); err != nil {
+		return ErrInvalidRootSignature
+	}
+	return nil
 }
 
 // ErrNoServerUuid ...
@@ -18,8 +18,11 @@ package client
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"github.com/codenotary/immudb/pkg/api/schema"
 	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/codenotary/immudb/pkg/signer"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
@@ -44,6 +47,27 @@ func TestRootService(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestVerifyingRootService(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+
+	root := &schema.Root{Index: 7, Root: []byte("root")}
+	root.Signature = &schema.Signature{
+		Signature: ed25519.Sign(key, signer.RootPayload("uuid", "defaultdb", root.Index, root.Root)),
+	}
+
+	assert.Nil(t, VerifyRootSignature(pub, "uuid", "defaultdb", root))
+	assert.Equal(t, ErrInvalidRootSignature, VerifyRootSignature(otherPub, "uuid", "defaultdb", root))
+	assert.Equal(t, ErrInvalidRootSignature, VerifyRootSignature(pub, "uuid", "otherdb", root))
+	assert.Equal(t, ErrInvalidRootSignature, VerifyRootSignature(pub, "other", "defaultdb", root))
+	assert.Equal(t, ErrMissingRootSignature, VerifyRootSignature(pub, "uuid", "defaultdb", &schema.Root{Index: 7, Root: []byte("root")}))
+
+	rs := NewVerifyingRootService(&immuServiceClientMock{}, &cacheMock{}, &mockLogger{}, pub)
+	assert.Equal(t, ErrMissingRootSignature, rs.SetRoot(&schema.Root{}, "defaultdb"))
+}
+
 type cacheMock struct{}
 
 func (m *cacheMock) Get(serverUuid string, databasename string) (*schema.Root, error) {
@@ -214,3 +238,61 @@ func (m *immuServiceClientMock) SetActiveUser(ctx context.Context, in *schema.Se
 func (m *immuServiceClientMock) DatabaseList(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*schema.DatabaseListResponse, error) {
 	return &schema.DatabaseListResponse{}, nil
 }
+func (m *immuServiceClientMock) CreateRole(ctx context.Context, in *schema.CreateRoleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+func (m *immuServiceClientMock) ListRoles(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*schema.RoleList, error) {
+	return &schema.RoleList{}, nil
+}
+func (m *immuServiceClientMock) GrantRole(ctx context.Context, in *schema.RoleRequest, opts ...grpc.CallOption) (*schema.Error, error) {
+	return &schema.Error{}, nil
+}
+func (m *immuServiceClientMock) RevokeRole(ctx context.Context, in *schema.RoleRequest, opts ...grpc.CallOption) (*schema.Error, error) {
+	return &schema.Error{}, nil
+}
+func (m *immuServiceClientMock) UpdateRole(ctx context.Context, in *schema.UpdateRoleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+func (m *immuServiceClientMock) DeleteRole(ctx context.Context, in *schema.DeleteRoleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func (m *immuServiceClientMock) ResolveTampering(ctx context.Context, in *schema.ResolveTamperingRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func (m *immuServiceClientMock) UnloadDatabase(ctx context.Context, in *schema.Database, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func (m *immuServiceClientMock) LoadDatabase(ctx context.Context, in *schema.Database, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func (m *immuServiceClientMock) RenameDatabase(ctx context.Context, in *schema.RenameDatabaseRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func (m *immuServiceClientMock) DropDatabase(ctx context.Context, in *schema.Database, opts ...grpc.CallOption) (*schema.DropDatabaseReply, error) {
+	return &schema.DropDatabaseReply{}, nil
+}
+
+func (m *immuServiceClientMock) UpdateDatabaseSettings(ctx context.Context, in *schema.UpdateDatabaseSettingsRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func (m *immuServiceClientMock) SetDatabaseReadOnly(ctx context.Context, in *schema.SetDatabaseReadOnlyRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func (m *immuServiceClientMock) CorruptionCheckerStatus(ctx context.Context, in *schema.CorruptionCheckerStatusRequest, opts ...grpc.CallOption) (*schema.CorruptionCheckerStatusResponse, error) {
+	return &schema.CorruptionCheckerStatusResponse{}, nil
+}
+
+func (m *immuServiceClientMock) VerifyDatabase(ctx context.Context, in *schema.VerifyDatabaseRequest, opts ...grpc.CallOption) (schema.ImmuService_VerifyDatabaseClient, error) {
+	return nil, nil
+}
+
+func (m *immuServiceClientMock) Backup(ctx context.Context, in *schema.BackupRequest, opts ...grpc.CallOption) (schema.ImmuService_BackupClient, error) {
+	return nil, nil
+}
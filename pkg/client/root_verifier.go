@@ -0,0 +1,89 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client/cache"
+)
+
+// maxConsistencyAttempts number of times the consistency of two roots is checked while the server keeps advancing
+const maxConsistencyAttempts = 3
+
+// VerifyRootsConsistency returns false if the server cannot prove that the two roots belong to the same history
+func VerifyRootsConsistency(ctx context.Context, serviceClient schema.ImmuServiceClient, a *schema.Root, b *schema.Root) (bool, error) {
+	if a.GetIndex() > b.GetIndex() {
+		a, b = b, a
+	}
+	if a.GetIndex() == b.GetIndex() {
+		return bytes.Equal(a.GetRoot(), b.GetRoot()), nil
+	}
+	for i := 0; i < maxConsistencyAttempts; i++ {
+		consistent, conclusive, err := proveRootsConsistency(ctx, serviceClient, a, b)
+		if err != nil || conclusive {
+			return consistent, err
+		}
+	}
+	return false, ErrInconclusiveConsistency
+}
+
+// proveRootsConsistency asks the server to prove that a, older than b, and b are both part of the history ending
+// at one same root. The outcome is not conclusive if the two proofs end at different roots of the server
+func proveRootsConsistency(ctx context.Context, serviceClient schema.ImmuServiceClient, a *schema.Root, b *schema.Root) (consistent bool, conclusive bool, err error) {
+	proofA, err := serviceClient.Consistency(ctx, &schema.Index{Index: a.GetIndex()})
+	if err != nil {
+		return false, false, err
+	}
+	if !proofA.Verify(schema.Root{Index: a.GetIndex(), Root: a.GetRoot()}) {
+		return false, true, nil
+	}
+	// the history proven for a reaches b itself
+	if proofA.Second == b.GetIndex() {
+		return bytes.Equal(proofA.SecondRoot, b.GetRoot()), true, nil
+	}
+	proofB, err := serviceClient.Consistency(ctx, &schema.Index{Index: b.GetIndex()})
+	if err != nil {
+		return false, false, err
+	}
+	if !proofB.Verify(schema.Root{Index: b.GetIndex(), Root: b.GetRoot()}) {
+		return false, true, nil
+	}
+	// proofs ending at different roots say nothing about a and b: a forking server could answer each of them
+	// from a different history
+	if proofA.Second != proofB.Second {
+		return false, false, nil
+	}
+	return bytes.Equal(proofA.SecondRoot, proofB.SecondRoot), true, nil
+}
+
+// NewRootVerifier returns a verifier asking the server to prove the consistency of two roots,
+// to be used with cache.NewSharedCache
+func NewRootVerifier(serviceClient schema.ImmuServiceClient) cache.RootVerifier {
+	return func(a *schema.Root, b *schema.Root) error {
+		consistent, err := VerifyRootsConsistency(context.Background(), serviceClient, a, b)
+		if err != nil {
+			return err
+		}
+		if !consistent {
+			return cache.ErrInconsistentRoot
+		}
+		return nil
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client/cache"
+	"github.com/codenotary/merkletree"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestImmudbKVStore(t *testing.T) {
+	setup()
+	kv := NewImmudbKVStore(client, []byte("kvstore-test/"))
+
+	v, err := kv.Get([]byte("k"))
+	require.NoError(t, err)
+	require.Nil(t, v)
+
+	ok, err := kv.CompareAndSwap([]byte("k"), []byte("x"), []byte("v1"))
+	require.NoError(t, err)
+	require.False(t, ok)
+	ok, err = kv.CompareAndSwap([]byte("k"), nil, []byte("v1"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = kv.CompareAndSwap([]byte("k"), nil, []byte("v2"))
+	require.NoError(t, err)
+	require.False(t, ok)
+	ok, err = kv.CompareAndSwap([]byte("k"), []byte("v1"), []byte("v2"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	v, err = kv.Get([]byte("k"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("v2"), v)
+
+	// a concurrent write of the same version loses against the first one
+	_, err = (*client.GetServiceClient()).Set(context.Background(), &schema.KeyValue{
+		Key:   []byte("kvstore-test/k/00000000000000000003"),
+		Value: []byte("v3"),
+	})
+	require.NoError(t, err)
+	_, err = (*client.GetServiceClient()).Set(context.Background(), &schema.KeyValue{
+		Key:   []byte("kvstore-test/k/00000000000000000003"),
+		Value: []byte("lost"),
+	})
+	require.NoError(t, err)
+	v, err = kv.Get([]byte("k"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("v3"), v)
+
+	// the versions left out of the listing are found by the verified reads of the following ones
+	hiding := NewImmudbKVStore(&scanHidingClient{ImmuClient: client}, []byte("kvstore-test/"))
+	v, err = hiding.Get([]byte("k"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("v3"), v)
+	ok, err = hiding.CompareAndSwap([]byte("k"), []byte("v1"), []byte("stale"))
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+// scanHidingClient lists no versioned key at all
+type scanHidingClient struct {
+	ImmuClient
+}
+
+type scanHidingServiceClient struct {
+	schema.ImmuServiceClient
+}
+
+func (c *scanHidingClient) GetServiceClient() *schema.ImmuServiceClient {
+	var sc schema.ImmuServiceClient = &scanHidingServiceClient{*c.ImmuClient.GetServiceClient()}
+	return &sc
+}
+
+func (c *scanHidingServiceClient) Scan(ctx context.Context, in *schema.ScanOptions, opts ...grpc.CallOption) (*schema.ItemList, error) {
+	return &schema.ItemList{}, nil
+}
+
+func TestSharedRootCache(t *testing.T) {
+	setup()
+	ctx := context.Background()
+	serviceClient := *client.GetServiceClient()
+	kv := NewImmudbKVStore(client, []byte("shared-roots-test/"))
+	shared := cache.NewSharedCache(kv, NewRootVerifier(serviceClient))
+	serverUuid, _ := GetServerUuid(ctx, serviceClient)
+
+	_, err := client.Set(ctx, []byte("shared-root-key1"), []byte("v1"))
+	require.NoError(t, err)
+	root1, err := client.CurrentRoot(ctx)
+	require.NoError(t, err)
+	require.NoError(t, shared.Set(root1, serverUuid, "db"))
+
+	_, err = client.Set(ctx, []byte("shared-root-key2"), []byte("v2"))
+	require.NoError(t, err)
+	root2, err := client.CurrentRoot(ctx)
+	require.NoError(t, err)
+	require.NoError(t, shared.Set(root2, serverUuid, "db"))
+	root, err := shared.Get(serverUuid, "db")
+	require.NoError(t, err)
+	require.Equal(t, root2.GetIndex(), root.GetIndex())
+	require.Equal(t, root2.GetRoot(), root.GetRoot())
+
+	forked := &schema.Root{Index: root1.GetIndex(), Root: make([]byte, len(root1.GetRoot()))}
+	require.Equal(t, cache.ErrInconsistentRoot, shared.Set(forked, serverUuid, "db"))
+
+	rs := NewRootService(serviceClient, shared, slog)
+	require.Equal(t, cache.ErrInconsistentRoot, rs.SetRoot(forked, "db"))
+}
+
+func TestRootServiceStaleRoot(t *testing.T) {
+	setup()
+	ctx := context.Background()
+	serviceClient := *client.GetServiceClient()
+	dir, err := ioutil.TempDir("", "stale-root-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = client.Set(ctx, []byte("stale-root-key1"), []byte("v1"))
+	require.NoError(t, err)
+	root1, err := client.CurrentRoot(ctx)
+	require.NoError(t, err)
+	_, err = client.Set(ctx, []byte("stale-root-key2"), []byte("v2"))
+	require.NoError(t, err)
+	root2, err := client.CurrentRoot(ctx)
+	require.NoError(t, err)
+
+	// another process sharing the directory already stored a newer root
+	rs := NewRootService(serviceClient, cache.NewFileCache(dir), slog)
+	require.NoError(t, rs.SetRoot(root2, "db"))
+	require.NoError(t, rs.SetRoot(root1, "db"))
+	root, err := rs.GetRoot(ctx, "db")
+	require.NoError(t, err)
+	require.Equal(t, root2.GetIndex(), root.GetIndex())
+
+	forked := &schema.Root{Index: root1.GetIndex(), Root: make([]byte, len(root1.GetRoot()))}
+	require.Equal(t, cache.ErrInconsistentRoot, rs.SetRoot(forked, "db"))
+}
+
+// forkingServiceClient answers the consistency proof of each index out of its own tree
+type forkingServiceClient struct {
+	immuServiceClientMock
+	trees map[uint64]merkletree.Storer
+}
+
+func (c *forkingServiceClient) Consistency(ctx context.Context, in *schema.Index, opts ...grpc.CallOption) (*schema.ConsistencyProof, error) {
+	tree := c.trees[in.Index]
+	at := tree.Width() - 1
+	root := merkletree.Root(tree)
+	return &schema.ConsistencyProof{
+		First:      in.Index,
+		Second:     at,
+		SecondRoot: root[:],
+		Path:       merkletree.ConsistencyProof(tree, at, in.Index).ToSlice(),
+	}, nil
+}
+
+func newTestTree(leaves ...string) merkletree.Storer {
+	tree := merkletree.NewMemStore()
+	for _, leaf := range leaves {
+		merkletree.Append(tree, []byte(leaf))
+	}
+	return tree
+}
+
+func testTreeRoot(tree merkletree.Storer) *schema.Root {
+	root := merkletree.Root(tree)
+	return &schema.Root{Index: tree.Width() - 1, Root: root[:]}
+}
+
+func TestVerifyRootsConsistencyForkingServer(t *testing.T) {
+	ctx := context.Background()
+	a := testTreeRoot(newTestTree("a", "b"))
+	b := testTreeRoot(newTestTree("a", "b", "c", "d"))
+	history := newTestTree("a", "b", "c", "d", "e", "f")
+	forked := newTestTree("a", "b", "x", "y", "z")
+
+	// both proofs verify, but they end at the heads of two different histories
+	sc := &forkingServiceClient{trees: map[uint64]merkletree.Storer{a.Index: forked, b.Index: history}}
+	consistent, err := VerifyRootsConsistency(ctx, sc, a, b)
+	require.Equal(t, ErrInconclusiveConsistency, err)
+	require.False(t, consistent)
+
+	// the proof of the older root reaches the index of the newer one through a different history
+	sc.trees[a.Index] = newTestTree("a", "b", "x", "y")
+	consistent, err = VerifyRootsConsistency(ctx, sc, a, b)
+	require.NoError(t, err)
+	require.False(t, consistent)
+
+	sc.trees[a.Index] = history
+	consistent, err = VerifyRootsConsistency(ctx, sc, b, a)
+	require.NoError(t, err)
+	require.True(t, consistent)
+
+	sc.trees[a.Index] = newTestTree("a", "b", "c", "d")
+	consistent, err = VerifyRootsConsistency(ctx, sc, a, b)
+	require.NoError(t, err)
+	require.True(t, consistent)
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/golang/protobuf/proto"
+)
+
+// ErrNotAProtoMessage is returned when a value given to a protobuf typed method is not a protobuf message
+var ErrNotAProtoMessage = errors.New("value is not a protobuf message")
+
+// ErrInvalidListDestination is returned when a list of values can not be decoded into the given destination
+var ErrInvalidListDestination = errors.New("destination must be a pointer to a slice")
+
+// valueCodec encodes the typed values into the payload stored by the byte-level API
+type valueCodec struct {
+	marshal   func(v interface{}) ([]byte, error)
+	unmarshal func(payload []byte, v interface{}) error
+	// newElem returns a new value to decode a list element of type t into, and how to store it in the list
+	newElem func(t reflect.Type) (interface{}, func() reflect.Value)
+}
+
+var jsonCodec = valueCodec{
+	marshal:   json.Marshal,
+	unmarshal: json.Unmarshal,
+	newElem: func(t reflect.Type) (interface{}, func() reflect.Value) {
+		elem := reflect.New(t)
+		return elem.Interface(), elem.Elem
+	},
+}
+
+var protoCodec = valueCodec{
+	marshal: func(v interface{}) ([]byte, error) {
+		m, ok := v.(proto.Message)
+		if !ok {
+			return nil, ErrNotAProtoMessage
+		}
+		return proto.Marshal(m)
+	},
+	unmarshal: func(payload []byte, v interface{}) error {
+		m, ok := v.(proto.Message)
+		if !ok {
+			return ErrNotAProtoMessage
+		}
+		return proto.Unmarshal(payload, m)
+	},
+	newElem: func(t reflect.Type) (interface{}, func() reflect.Value) {
+		// protobuf messages are decoded into pointers, so lists hold pointers to messages
+		if t.Kind() != reflect.Ptr {
+			return nil, nil
+		}
+		elem := reflect.New(t.Elem())
+		return elem.Interface(), func() reflect.Value { return elem }
+	},
+}
+
+// SetJSON stores the JSON encoding of v
+func (c *immuClient) SetJSON(ctx context.Context, key []byte, v interface{}) (*schema.Index, error) {
+	return c.setTyped(ctx, jsonCodec, key, v)
+}
+
+// SafeSetJSON stores the JSON encoding of v and verifies its inclusion like SafeSet
+func (c *immuClient) SafeSetJSON(ctx context.Context, key []byte, v interface{}) (*VerifiedIndex, error) {
+	return c.safeSetTyped(ctx, jsonCodec, key, v)
+}
+
+// GetJSON decodes the JSON value of key into v
+func (c *immuClient) GetJSON(ctx context.Context, key []byte, v interface{}) (*schema.StructuredItem, error) {
+	return c.getTyped(ctx, jsonCodec, key, v)
+}
+
+// SafeGetJSON decodes the JSON value of key into v, once its inclusion has been verified like SafeGet
+func (c *immuClient) SafeGetJSON(ctx context.Context, key []byte, v interface{}) (*VerifiedItem, error) {
+	return c.safeGetTyped(ctx, jsonCodec, key, v)
+}
+
+// SetBatchJSON stores in a single batch the JSON encoding of each value under the key with the same position
+func (c *immuClient) SetBatchJSON(ctx context.Context, keys [][]byte, values []interface{}) (*schema.Index, error) {
+	return c.setBatchTyped(ctx, jsonCodec, keys, values)
+}
+
+// HistoryJSON decodes every JSON value of key into out, which must be a pointer to a slice
+func (c *immuClient) HistoryJSON(ctx context.Context, key []byte, out interface{}) (*schema.StructuredItemList, error) {
+	list, err := c.History(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return list, decodeList(jsonCodec, list, out)
+}
+
+// ScanJSON decodes the JSON values of the keys starting with prefix into out, which must be a pointer to a slice
+func (c *immuClient) ScanJSON(ctx context.Context, prefix []byte, out interface{}) (*schema.StructuredItemList, error) {
+	list, err := c.Scan(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	return list, decodeList(jsonCodec, list, out)
+}
+
+// SetProto stores the protobuf encoding of m
+func (c *immuClient) SetProto(ctx context.Context, key []byte, m proto.Message) (*schema.Index, error) {
+	return c.setTyped(ctx, protoCodec, key, m)
+}
+
+// SafeSetProto stores the protobuf encoding of m and verifies its inclusion like SafeSet
+func (c *immuClient) SafeSetProto(ctx context.Context, key []byte, m proto.Message) (*VerifiedIndex, error) {
+	return c.safeSetTyped(ctx, protoCodec, key, m)
+}
+
+// GetProto decodes the protobuf value of key into m
+func (c *immuClient) GetProto(ctx context.Context, key []byte, m proto.Message) (*schema.StructuredItem, error) {
+	return c.getTyped(ctx, protoCodec, key, m)
+}
+
+// SafeGetProto decodes the protobuf value of key into m, once its inclusion has been verified like SafeGet
+func (c *immuClient) SafeGetProto(ctx context.Context, key []byte, m proto.Message) (*VerifiedItem, error) {
+	return c.safeGetTyped(ctx, protoCodec, key, m)
+}
+
+// SetBatchProto stores in a single batch the protobuf encoding of each message under the key with the same position
+func (c *immuClient) SetBatchProto(ctx context.Context, keys [][]byte, messages []proto.Message) (*schema.Index, error) {
+	values := make([]interface{}, len(messages))
+	for i, m := range messages {
+		values[i] = m
+	}
+	return c.setBatchTyped(ctx, protoCodec, keys, values)
+}
+
+// HistoryProto decodes every protobuf value of key into out, which must be a pointer to a slice of message pointers
+func (c *immuClient) HistoryProto(ctx context.Context, key []byte, out interface{}) (*schema.StructuredItemList, error) {
+	list, err := c.History(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return list, decodeList(protoCodec, list, out)
+}
+
+// ScanProto decodes the protobuf values of the keys starting with prefix into out, which must be a pointer to a
+// slice of message pointers
+func (c *immuClient) ScanProto(ctx context.Context, prefix []byte, out interface{}) (*schema.StructuredItemList, error) {
+	list, err := c.Scan(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	return list, decodeList(protoCodec, list, out)
+}
+
+func (c *immuClient) setTyped(ctx context.Context, codec valueCodec, key []byte, v interface{}) (*schema.Index, error) {
+	payload, err := codec.marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return c.Set(ctx, key, payload)
+}
+
+func (c *immuClient) safeSetTyped(ctx context.Context, codec valueCodec, key []byte, v interface{}) (*VerifiedIndex, error) {
+	payload, err := codec.marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return c.SafeSet(ctx, key, payload)
+}
+
+func (c *immuClient) getTyped(ctx context.Context, codec valueCodec, key []byte, v interface{}) (*schema.StructuredItem, error) {
+	item, err := c.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.unmarshal(item.GetValue().GetPayload(), v); err != nil {
+		return nil, fmt.Errorf("error decoding value of key %s: %v", key, err)
+	}
+	return item, nil
+}
+
+func (c *immuClient) safeGetTyped(ctx context.Context, codec valueCodec, key []byte, v interface{}) (*VerifiedItem, error) {
+	vi, err := c.SafeGet(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.unmarshal(vi.Value, v); err != nil {
+		return nil, fmt.Errorf("error decoding value of key %s: %v", key, err)
+	}
+	return vi, nil
+}
+
+func (c *immuClient) setBatchTyped(ctx context.Context, codec valueCodec, keys [][]byte, values []interface{}) (*schema.Index, error) {
+	if len(keys) != len(values) {
+		return nil, fmt.Errorf("%d keys given for %d values", len(keys), len(values))
+	}
+	request := &BatchRequest{
+		Keys:   make([]io.Reader, len(keys)),
+		Values: make([]io.Reader, len(values)),
+	}
+	for i, v := range values {
+		payload, err := codec.marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		// batch values are stored structured like the ones of Set, so that they can be read back by Get and Scan
+		kv, err := c.NewSKV(keys[i], payload).ToKV()
+		if err != nil {
+			return nil, err
+		}
+		request.Keys[i] = bytes.NewReader(kv.GetKey())
+		request.Values[i] = bytes.NewReader(kv.GetValue())
+	}
+	return c.SetBatch(ctx, request)
+}
+
+// decodeList decodes the values of list into a new element appended to the slice pointed by out
+func decodeList(codec valueCodec, list *schema.StructuredItemList, out interface{}) error {
+	slice := reflect.ValueOf(out)
+	if slice.Kind() != reflect.Ptr || slice.Elem().Kind() != reflect.Slice {
+		return ErrInvalidListDestination
+	}
+	slice = slice.Elem()
+	elemType := slice.Type().Elem()
+	for _, item := range list.GetItems() {
+		elem, value := codec.newElem(elemType)
+		if elem == nil {
+			return ErrInvalidListDestination
+		}
+		if err := codec.unmarshal(item.GetValue().GetPayload(), elem); err != nil {
+			return fmt.Errorf("error decoding value of key %s at index %d: %v", item.GetKey(), item.GetIndex(), err)
+		}
+		slice.Set(reflect.Append(slice, value()))
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+)
+
+type typedDocument struct {
+	Name  string   `json:"name"`
+	Count int      `json:"count"`
+	Tags  []string `json:"tags"`
+}
+
+func TestJSONValues(t *testing.T) {
+	setup()
+	defer cleanup()
+	ctx := context.Background()
+
+	doc := typedDocument{Name: "first", Count: 1, Tags: []string{"a", "b"}}
+	_, err := client.SetJSON(ctx, []byte("json-doc"), doc)
+	require.NoError(t, err)
+	var got typedDocument
+	item, err := client.GetJSON(ctx, []byte("json-doc"), &got)
+	require.NoError(t, err)
+	require.Equal(t, doc, got)
+	require.Equal(t, []byte("json-doc"), item.GetKey())
+
+	doc.Count = 2
+	vi, err := client.SafeSetJSON(ctx, []byte("json-doc"), doc)
+	require.NoError(t, err)
+	require.True(t, vi.Verified)
+	var verified typedDocument
+	item2, err := client.SafeGetJSON(ctx, []byte("json-doc"), &verified)
+	require.NoError(t, err)
+	require.True(t, item2.Verified)
+	require.Equal(t, doc, verified)
+
+	var history []typedDocument
+	_, err = client.HistoryJSON(ctx, []byte("json-doc"), &history)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+
+	_, err = client.SetBatchJSON(ctx,
+		[][]byte{[]byte("json-batch-1"), []byte("json-batch-2")},
+		[]interface{}{typedDocument{Name: "b1"}, typedDocument{Name: "b2"}})
+	require.NoError(t, err)
+	var scanned []*typedDocument
+	list, err := client.ScanJSON(ctx, []byte("json-batch-"), &scanned)
+	require.NoError(t, err)
+	require.Len(t, list.GetItems(), 2)
+	require.Len(t, scanned, 2)
+	require.ElementsMatch(t, []string{"b1", "b2"}, []string{scanned[0].Name, scanned[1].Name})
+
+	_, err = client.ScanJSON(ctx, []byte("json-batch-"), scanned)
+	require.Equal(t, ErrInvalidListDestination, err)
+	_, err = client.SetBatchJSON(ctx, [][]byte{[]byte("k")}, nil)
+	require.Error(t, err)
+	_, err = client.SetJSON(ctx, []byte("json-invalid"), func() {})
+	require.Error(t, err)
+	_, err = client.Set(ctx, []byte("json-invalid"), []byte("not json"))
+	require.NoError(t, err)
+	_, err = client.GetJSON(ctx, []byte("json-invalid"), &got)
+	require.Error(t, err)
+	client.Disconnect()
+}
+
+func TestProtoValues(t *testing.T) {
+	setup()
+	defer cleanup()
+	ctx := context.Background()
+
+	root := &schema.Root{Index: 7, Root: []byte("root")}
+	_, err := client.SetProto(ctx, []byte("proto-doc"), root)
+	require.NoError(t, err)
+	got := new(schema.Root)
+	_, err = client.GetProto(ctx, []byte("proto-doc"), got)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(root, got))
+
+	root.Index = 8
+	vi, err := client.SafeSetProto(ctx, []byte("proto-doc"), root)
+	require.NoError(t, err)
+	require.True(t, vi.Verified)
+	item, err := client.SafeGetProto(ctx, []byte("proto-doc"), got)
+	require.NoError(t, err)
+	require.True(t, item.Verified)
+	require.Equal(t, uint64(8), got.GetIndex())
+
+	var history []*schema.Root
+	_, err = client.HistoryProto(ctx, []byte("proto-doc"), &history)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+
+	_, err = client.SetBatchProto(ctx,
+		[][]byte{[]byte("proto-batch-1"), []byte("proto-batch-2")},
+		[]proto.Message{&schema.Index{Index: 1}, &schema.Index{Index: 2}})
+	require.NoError(t, err)
+	var scanned []*schema.Index
+	_, err = client.ScanProto(ctx, []byte("proto-batch-"), &scanned)
+	require.NoError(t, err)
+	require.Len(t, scanned, 2)
+
+	var values []schema.Index
+	_, err = client.ScanProto(ctx, []byte("proto-batch-"), &values)
+	require.Equal(t, ErrInvalidListDestination, err)
+	client.Disconnect()
+}
@@ -54,6 +54,11 @@ func (c *immuClient) WithHomedirService(homedirService HomedirService) *immuClie
 	return c
 }
 
+func (c *immuClient) WithVerifiedItemCache(itemCache *VerifiedItemCache) *immuClient {
+	c.itemCache = itemCache
+	return c
+}
+
 func (c *immuClient) WithOptions(options *Options) *immuClient {
 	c.Options = options
 	return c
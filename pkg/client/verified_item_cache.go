@@ -0,0 +1,177 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+// VerifiedItemCacheStats the hit and miss counters of a verified item cache
+type VerifiedItemCacheStats struct {
+	Hits    uint64
+	Misses  uint64
+	Entries int
+}
+
+// VerifiedItemCache caches the items verified by SafeGet, together with the root they were verified against.
+// A cached item is served without calling the server as long as the trusted root does not move, and without
+// fetching its value again when the trusted root advances but its key has not been written since
+type VerifiedItemCache struct {
+	maxSize int
+	entries map[string]*list.Element
+	lru     *list.List
+	hits    uint64
+	misses  uint64
+	sync.Mutex
+}
+
+type verifiedItemEntry struct {
+	id   string
+	item *VerifiedItem
+	leaf []byte
+	root *schema.Root
+}
+
+// NewVerifiedItemCache returns a cache holding at most maxSize verified items, evicting the least recently used ones
+func NewVerifiedItemCache(maxSize int) *VerifiedItemCache {
+	return &VerifiedItemCache{
+		maxSize: maxSize,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+	}
+}
+
+func verifiedItemID(databasename string, key []byte) string {
+	return databasename + ":" + string(key)
+}
+
+func (vc *VerifiedItemCache) get(databasename string, key []byte) *verifiedItemEntry {
+	vc.Lock()
+	defer vc.Unlock()
+	if e, ok := vc.entries[verifiedItemID(databasename, key)]; ok {
+		vc.lru.MoveToFront(e)
+		return e.Value.(*verifiedItemEntry)
+	}
+	return nil
+}
+
+func (vc *VerifiedItemCache) set(databasename string, item *VerifiedItem, leaf []byte, root *schema.Root) {
+	if vc.maxSize <= 0 {
+		return
+	}
+	vc.Lock()
+	defer vc.Unlock()
+	id := verifiedItemID(databasename, item.Key)
+	if e, ok := vc.entries[id]; ok {
+		e.Value = &verifiedItemEntry{id: id, item: item, leaf: leaf, root: root}
+		vc.lru.MoveToFront(e)
+		return
+	}
+	vc.entries[id] = vc.lru.PushFront(&verifiedItemEntry{id: id, item: item, leaf: leaf, root: root})
+	for vc.lru.Len() > vc.maxSize {
+		oldest := vc.lru.Back()
+		vc.lru.Remove(oldest)
+		delete(vc.entries, oldest.Value.(*verifiedItemEntry).id)
+	}
+}
+
+func (vc *VerifiedItemCache) remove(databasename string, key []byte) {
+	vc.Lock()
+	defer vc.Unlock()
+	id := verifiedItemID(databasename, key)
+	if e, ok := vc.entries[id]; ok {
+		vc.lru.Remove(e)
+		delete(vc.entries, id)
+	}
+}
+
+func (vc *VerifiedItemCache) count(hit bool) {
+	vc.Lock()
+	defer vc.Unlock()
+	if hit {
+		vc.hits++
+	} else {
+		vc.misses++
+	}
+}
+
+// Stats returns the hit and miss counters and the number of cached items
+func (vc *VerifiedItemCache) Stats() VerifiedItemCacheStats {
+	vc.Lock()
+	defer vc.Unlock()
+	return VerifiedItemCacheStats{Hits: vc.hits, Misses: vc.misses, Entries: vc.lru.Len()}
+}
+
+// revalidate returns a copy of the cached item of key if it is still the latest value of key. An item verified
+// against root, the trusted root in use, is returned without any call to the server. When the trusted root has
+// moved the value is not fetched again: the server tells the latest index of key, which must be the index of the
+// cached item, and proves that the cached item is included at that index in its current root. That root becomes
+// the trusted one once it is proven consistent with root. As for SafeGet, the server is trusted not to hide a newer
+// value of the key behind the latest index it tells.
+func (vc *VerifiedItemCache) revalidate(
+	ctx context.Context,
+	serviceClient schema.ImmuServiceClient,
+	rootService RootService,
+	databasename string,
+	key []byte,
+	root *schema.Root,
+) (*VerifiedItem, error) {
+	entry := vc.get(databasename, key)
+	if entry == nil {
+		return nil, nil
+	}
+	if entry.root.GetIndex() == root.GetIndex() && bytes.Equal(entry.root.GetRoot(), root.GetRoot()) {
+		item := *entry.item
+		return &item, nil
+	}
+	latest, err := serviceClient.Get(ctx, &schema.Key{Key: key})
+	if err != nil {
+		return nil, err
+	}
+	if latest.GetIndex() != entry.item.Index {
+		vc.remove(databasename, key)
+		return nil, nil
+	}
+	proof, err := serviceClient.Inclusion(ctx, &schema.Index{Index: entry.item.Index})
+	if err != nil {
+		return nil, err
+	}
+	if !proof.Verify(entry.item.Index, entry.leaf) || proof.GetAt() < root.GetIndex() {
+		vc.remove(databasename, key)
+		return nil, nil
+	}
+	newRoot := &schema.Root{Index: proof.GetAt(), Root: proof.GetRoot()}
+	consistent, err := VerifyRootsConsistency(ctx, serviceClient, root, newRoot)
+	if err != nil {
+		return nil, err
+	}
+	if !consistent {
+		vc.remove(databasename, key)
+		return nil, nil
+	}
+	if err = rootService.SetRoot(newRoot, databasename); err != nil {
+		return nil, err
+	}
+	vc.set(databasename, entry.item, entry.leaf, newRoot)
+	item := *entry.item
+	return &item, nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client/cache"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestVerifiedItemCache(t *testing.T) {
+	setup()
+	defer cleanup()
+	ctx := context.Background()
+	client.WithVerifiedItemCache(NewVerifiedItemCache(2))
+
+	_, err := client.SafeSet(ctx, []byte("cached-key1"), []byte("v1"))
+	require.NoError(t, err)
+	vi, err := client.SafeGet(ctx, []byte("cached-key1"))
+	require.NoError(t, err)
+	require.True(t, vi.Verified)
+	require.Equal(t, VerifiedItemCacheStats{Misses: 1, Entries: 1}, client.VerifiedItemCacheStats())
+
+	vi, err = client.SafeGet(ctx, []byte("cached-key1"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("v1"), vi.Value)
+	require.Equal(t, uint64(1), client.VerifiedItemCacheStats().Hits)
+
+	// a different key moves the trusted root: the cached item is revalidated without fetching its value again
+	_, err = client.SafeSet(ctx, []byte("cached-key2"), []byte("v2"))
+	require.NoError(t, err)
+	vi, err = client.SafeGet(ctx, []byte("cached-key1"))
+	require.NoError(t, err)
+	require.True(t, vi.Verified)
+	require.Equal(t, []byte("v1"), vi.Value)
+	require.Equal(t, VerifiedItemCacheStats{Hits: 2, Misses: 1, Entries: 1}, client.VerifiedItemCacheStats())
+	vi, err = client.SafeGet(ctx, []byte("cached-key1"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("v1"), vi.Value)
+	require.Equal(t, uint64(3), client.VerifiedItemCacheStats().Hits)
+
+	// a new value of the key moves the trusted root, so the cached item is not served
+	_, err = client.SafeSet(ctx, []byte("cached-key1"), []byte("v1bis"))
+	require.NoError(t, err)
+	vi, err = client.SafeGet(ctx, []byte("cached-key1"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("v1bis"), vi.Value)
+	require.Equal(t, uint64(2), client.VerifiedItemCacheStats().Misses)
+
+	for _, k := range []string{"cached-key2", "cached-key3"} {
+		_, err = client.SafeSet(ctx, []byte(k), []byte("v"))
+		require.NoError(t, err)
+		_, err = client.SafeGet(ctx, []byte(k))
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, client.VerifiedItemCacheStats().Entries)
+	client.Disconnect()
+}
+
+// revalidationServiceClient answers the calls made to revalidate a cached item
+type revalidationServiceClient struct {
+	schema.ImmuServiceClient
+	latest uint64
+	proof  *schema.InclusionProof
+	calls  int
+}
+
+func (c *revalidationServiceClient) Get(ctx context.Context, in *schema.Key, opts ...grpc.CallOption) (*schema.Item, error) {
+	c.calls++
+	return &schema.Item{Key: in.Key, Index: c.latest}, nil
+}
+
+func (c *revalidationServiceClient) Inclusion(ctx context.Context, in *schema.Index, opts ...grpc.CallOption) (*schema.InclusionProof, error) {
+	c.calls++
+	return c.proof, nil
+}
+
+func TestVerifiedItemCacheRevalidate(t *testing.T) {
+	ctx := context.Background()
+	vc := NewVerifiedItemCache(10)
+	root := &schema.Root{Index: 3, Root: []byte{1, 2, 3}}
+	vc.set("db", &VerifiedItem{Key: []byte("lookup-key"), Value: []byte("v"), Index: 2}, []byte("leaf"), root)
+	sc := &revalidationServiceClient{latest: 2}
+	rs := &rootservice{client: sc, cache: cache.NewInMemoryCache(), logger: logger.NewSimpleLogger("test", os.Stdout)}
+
+	vi, err := vc.revalidate(ctx, sc, rs, "db", []byte("lookup-key"), &schema.Root{Index: 3, Root: []byte{1, 2, 3}})
+	require.NoError(t, err)
+	require.NotNil(t, vi)
+	require.Equal(t, []byte("v"), vi.Value)
+	require.Equal(t, 0, sc.calls)
+	// callers get a copy of the cached item
+	vi.Value = []byte("changed")
+	vi, err = vc.revalidate(ctx, sc, rs, "db", []byte("lookup-key"), root)
+	require.NoError(t, err)
+	require.Equal(t, []byte("v"), vi.Value)
+
+	vi, err = vc.revalidate(ctx, sc, rs, "otherdb", []byte("lookup-key"), root)
+	require.NoError(t, err)
+	require.Nil(t, vi)
+
+	// the trusted root moved and the server does not prove the cached item: it is dropped
+	sc.proof = &schema.InclusionProof{At: 4, Index: 2, Leaf: []byte("another leaf")}
+	vi, err = vc.revalidate(ctx, sc, rs, "db", []byte("lookup-key"), &schema.Root{Index: 4, Root: []byte{4}})
+	require.NoError(t, err)
+	require.Nil(t, vi)
+	require.Equal(t, 2, sc.calls)
+	require.Equal(t, 0, vc.Stats().Entries)
+
+	// the key has been written since the item was cached
+	vc.set("db", &VerifiedItem{Key: []byte("lookup-key"), Value: []byte("v"), Index: 2}, []byte("leaf"), root)
+	sc.latest = 5
+	vi, err = vc.revalidate(ctx, sc, rs, "db", []byte("lookup-key"), &schema.Root{Index: 6, Root: []byte{6}})
+	require.NoError(t, err)
+	require.Nil(t, vi)
+	require.Equal(t, 3, sc.calls)
+	require.Equal(t, 0, vc.Stats().Entries)
+}
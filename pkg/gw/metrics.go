@@ -26,6 +26,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
 	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client/auditor"
 	"github.com/codenotary/immudb/pkg/json"
 	"github.com/codenotary/immudb/pkg/logger"
 	"github.com/prometheus/client_golang/prometheus"
@@ -43,6 +44,12 @@ type LastAuditResult struct {
 	PreviousRoot           string
 	CurrentRootIndex       float64
 	CurrentRoot            string
+	HasRunSampleCheck      bool
+	SampleSize             int
+	SampleVerified         int
+	SampleFailed           int
+	SampleErrors           int
+	SampleFailedIndexes    []uint64
 	RunAt                  time.Time
 	sync.RWMutex
 }
@@ -56,6 +63,9 @@ type MetricsCollection struct {
 	AuditCurrRootPerServer *prometheus.GaugeVec
 	AuditRunAtPerServer    *prometheus.GaugeVec
 
+	AuditSampleVerifiedPerServer *prometheus.GaugeVec
+	AuditSampleFailedPerServer   *prometheus.GaugeVec
+
 	UptimeCounter prometheus.CounterFunc
 }
 
@@ -82,6 +92,7 @@ func (mc *MetricsCollection) UpdateAuditResult(
 	result bool,
 	prevRoot *schema.Root,
 	currRoot *schema.Root,
+	sample *auditor.SampleResult,
 ) {
 	var r float64
 	if checked && result {
@@ -112,6 +123,15 @@ func (mc *MetricsCollection) UpdateAuditResult(
 		WithLabelValues(serverID, serverAddress).Set(currRootIndex)
 	mc.AuditRunAtPerServer.
 		WithLabelValues(serverID, serverAddress).SetToCurrentTime()
+	sampleVerified, sampleFailed := -1., -1.
+	if sample != nil {
+		sampleVerified = float64(sample.Verified)
+		sampleFailed = float64(sample.Failed)
+	}
+	mc.AuditSampleVerifiedPerServer.
+		WithLabelValues(serverID, serverAddress).Set(sampleVerified)
+	mc.AuditSampleFailedPerServer.
+		WithLabelValues(serverID, serverAddress).Set(sampleFailed)
 
 	mc.lastAuditResult.Lock()
 	defer mc.lastAuditResult.Unlock()
@@ -124,6 +144,19 @@ func (mc *MetricsCollection) UpdateAuditResult(
 	mc.lastAuditResult.PreviousRoot = fmt.Sprintf("%x", prevRoot.GetRoot())
 	mc.lastAuditResult.CurrentRootIndex = currRootIndex
 	mc.lastAuditResult.CurrentRoot = fmt.Sprintf("%x", currRoot.GetRoot())
+	mc.lastAuditResult.HasRunSampleCheck = sample != nil
+	mc.lastAuditResult.SampleSize = 0
+	mc.lastAuditResult.SampleVerified = 0
+	mc.lastAuditResult.SampleFailed = 0
+	mc.lastAuditResult.SampleErrors = 0
+	mc.lastAuditResult.SampleFailedIndexes = nil
+	if sample != nil {
+		mc.lastAuditResult.SampleSize = sample.Size
+		mc.lastAuditResult.SampleVerified = sample.Verified
+		mc.lastAuditResult.SampleFailed = sample.Failed
+		mc.lastAuditResult.SampleErrors = sample.Errors
+		mc.lastAuditResult.SampleFailedIndexes = sample.FailedIndexes
+	}
 	mc.lastAuditResult.RunAt = time.Now()
 }
 
@@ -157,6 +190,14 @@ var Metrics = MetricsCollection{
 		"audit_run_at_per_server",
 		"Timestamp in unix seconds at which latest audit run.",
 	),
+	AuditSampleVerifiedPerServer: newAuditGaugeVec(
+		"audit_sample_verified_per_server",
+		"Number of sampled entries verified by the latest audit (-1 = sampling not run).",
+	),
+	AuditSampleFailedPerServer: newAuditGaugeVec(
+		"audit_sample_failed_per_server",
+		"Number of sampled entries that failed verification in the latest audit (-1 = sampling not run).",
+	),
 }
 
 // StartMetrics listens and servers the HTTP metrics server in a new goroutine.
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client/auditor"
 	"github.com/codenotary/immudb/pkg/json"
 	"github.com/codenotary/immudb/pkg/logger"
 	"github.com/stretchr/testify/require"
@@ -83,6 +84,7 @@ func TestUpdateAuditResult(t *testing.T) {
 		true,
 		&schema.Root{Index: 0, Root: []byte{1}},
 		&schema.Root{Index: 1, Root: []byte{2}},
+		&auditor.SampleResult{Size: 3, Verified: 2, Failed: 1, FailedIndexes: []uint64{7}},
 	)
 	require.Equal(t, 0., Metrics.lastAuditResult.PreviousRootIndex)
 	require.Equal(t, 1., Metrics.lastAuditResult.CurrentRootIndex)
@@ -91,12 +93,19 @@ func TestUpdateAuditResult(t *testing.T) {
 	require.True(t, Metrics.lastAuditResult.HasRunConsistencyCheck)
 	require.False(t, Metrics.lastAuditResult.HasError)
 	require.True(t, Metrics.lastAuditResult.ConsistencyCheckResult)
+	require.True(t, Metrics.lastAuditResult.HasRunSampleCheck)
+	require.Equal(t, 3, Metrics.lastAuditResult.SampleSize)
+	require.Equal(t, 2, Metrics.lastAuditResult.SampleVerified)
+	require.Equal(t, 1, Metrics.lastAuditResult.SampleFailed)
+	require.Equal(t, []uint64{7}, Metrics.lastAuditResult.SampleFailedIndexes)
 
-	Metrics.UpdateAuditResult("server1", "127.0.0.1", false, false, true, nil, nil)
+	Metrics.UpdateAuditResult("server1", "127.0.0.1", false, false, true, nil, nil, nil)
 	require.Equal(t, -1., Metrics.lastAuditResult.PreviousRootIndex)
 	require.Equal(t, -1., Metrics.lastAuditResult.CurrentRootIndex)
+	require.False(t, Metrics.lastAuditResult.HasRunSampleCheck)
+	require.Nil(t, Metrics.lastAuditResult.SampleFailedIndexes)
 
-	Metrics.UpdateAuditResult("server1", "127.0.0.1", false, true, false, nil, nil)
+	Metrics.UpdateAuditResult("server1", "127.0.0.1", false, true, false, nil, nil, nil)
 	require.Equal(t, -2., Metrics.lastAuditResult.PreviousRootIndex)
 	require.Equal(t, -2., Metrics.lastAuditResult.CurrentRootIndex)
 }
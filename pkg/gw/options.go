@@ -22,26 +22,31 @@ import (
 	"time"
 
 	"github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/client/auditor"
 )
 
 // Options immudb gateway server options
 type Options struct {
-	Dir           string
-	Address       string
-	Port          int
-	MetricsPort   int
-	ImmudbAddress string
-	ImmudbPort    int
-	Audit         bool
-	AuditInterval time.Duration
-	AuditUsername string
-	AuditPassword string `json:"-"`
-	Detached      bool
-	MTLs          bool
-	MTLsOptions   client.MTLsOptions
-	Config        string
-	Pidfile       string
-	Logfile       string
+	Dir                 string
+	Address             string
+	Port                int
+	MetricsPort         int
+	ImmudbAddress       string
+	ImmudbPort          int
+	Audit               bool
+	AuditInterval       time.Duration
+	AuditUsername       string
+	AuditPassword       string `json:"-"`
+	AuditAlerts         auditor.AlertOptions
+	AuditGossip         auditor.GossipOptions
+	AuditSampleSize     int
+	ServerSigningPubKey string
+	Detached            bool
+	MTLs                bool
+	MTLsOptions         client.MTLsOptions
+	Config              string
+	Pidfile             string
+	Logfile             string
 }
 
 // DefaultOptions ...
@@ -57,6 +62,7 @@ func DefaultOptions() Options {
 		AuditInterval: 5 * time.Minute,
 		AuditUsername: "immugwauditor",
 		AuditPassword: "",
+		AuditAlerts:   auditor.DefaultAlertOptions(),
 		Detached:      false,
 		MTLs:          false,
 		Config:        "configs/immugw.toml",
@@ -121,6 +127,30 @@ func (o Options) WithAuditPassword(auditPassword string) Options {
 	return o
 }
 
+// WithAuditAlerts sets the alert sinks of the auditor
+func (o Options) WithAuditAlerts(auditAlerts auditor.AlertOptions) Options {
+	o.AuditAlerts = auditAlerts
+	return o
+}
+
+// WithAuditGossip sets the exchange of observed roots with peer auditors
+func (o Options) WithAuditGossip(auditGossip auditor.GossipOptions) Options {
+	o.AuditGossip = auditGossip
+	return o
+}
+
+// WithAuditSampleSize sets the number of random entries verified on every audit
+func (o Options) WithAuditSampleSize(auditSampleSize int) Options {
+	o.AuditSampleSize = auditSampleSize
+	return o
+}
+
+// WithServerSigningPubKey sets the path of the PEM encoded public key the roots returned by immudb must be signed with
+func (o Options) WithServerSigningPubKey(serverSigningPubKey string) Options {
+	o.ServerSigningPubKey = serverSigningPubKey
+	return o
+}
+
 // WithMTLs sets MTLs
 func (o Options) WithMTLs(MTLs bool) Options {
 	o.MTLs = MTLs
@@ -18,6 +18,7 @@ package gw
 
 import (
 	"context"
+	"crypto"
 	"fmt"
 	"net/http"
 	"os"
@@ -33,6 +34,7 @@ import (
 	"github.com/codenotary/immudb/pkg/client/cache"
 	"github.com/codenotary/immudb/pkg/json"
 	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/signer"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/rs/cors"
 	"google.golang.org/grpc"
@@ -47,15 +49,16 @@ func (s *ImmuGwServer) Start() error {
 	defer cancel()
 
 	cliOpts := &immuclient.Options{
-		Dir:                s.Options.Dir,
-		Address:            s.Options.ImmudbAddress,
-		Port:               s.Options.ImmudbPort,
-		HealthCheckRetries: 1,
-		MTLs:               s.Options.MTLs,
-		MTLsOptions:        s.Options.MTLsOptions,
-		Auth:               true,
-		Config:             "",
-		DialOptions:        &[]grpc.DialOption{},
+		Dir:                 s.Options.Dir,
+		Address:             s.Options.ImmudbAddress,
+		Port:                s.Options.ImmudbPort,
+		HealthCheckRetries:  1,
+		MTLs:                s.Options.MTLs,
+		MTLsOptions:         s.Options.MTLsOptions,
+		Auth:                true,
+		Config:              "",
+		DialOptions:         &[]grpc.DialOption{},
+		ServerSigningPubKey: s.Options.ServerSigningPubKey,
 	}
 
 	ic, err := immuclient.NewImmuClient(cliOpts)
@@ -100,6 +103,13 @@ func (s *ImmuGwServer) Start() error {
 	}
 
 	if s.Options.Audit {
+		var serverSigningPubKey crypto.PublicKey
+		if s.Options.ServerSigningPubKey != "" {
+			if serverSigningPubKey, err = signer.LoadPublicKey(s.Options.ServerSigningPubKey); err != nil {
+				s.Logger.Errorf("unable to load server signing public key: %s", err)
+				return err
+			}
+		}
 		defaultAuditor, err := auditor.DefaultAuditor(
 			s.Options.AuditInterval,
 			fmt.Sprintf("%s:%d", s.Options.ImmudbAddress, s.Options.ImmudbPort),
@@ -108,7 +118,12 @@ func (s *ImmuGwServer) Start() error {
 			s.Options.AuditPassword,
 			cache.NewHistoryFileCache(filepath.Join(cliOpts.Dir, "auditor")),
 			Metrics.UpdateAuditResult,
-			nil)
+			nil,
+			auditor.DefaultOptions().
+				WithServerSigningPubKey(serverSigningPubKey).
+				WithAlerts(s.Options.AuditAlerts).
+				WithGossip(s.Options.AuditGossip).
+				WithSampleSize(s.Options.AuditSampleSize))
 		if err != nil {
 			s.Logger.Errorf("unable to create auditor: %s", err)
 			return err
@@ -0,0 +1,239 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+// BackupManifestName name of the manifest file stored as first entry of a backup archive
+const BackupManifestName = "manifest.json"
+
+// backupManifestVersion version of the backup archive layout
+const backupManifestVersion = 1
+
+// backupChunkSize size of the chunks sent to clients while streaming a backup
+const backupChunkSize = 64 * 1024
+
+// BackupManifest describes the content of a backup archive
+type BackupManifest struct {
+	Version   int              `json:"version"`
+	Created   time.Time        `json:"created"`
+	Databases []BackupDatabase `json:"databases"`
+}
+
+// BackupDatabase describes the snapshot of a single database contained in a backup archive
+type BackupDatabase struct {
+	Name      string     `json:"name"`
+	File      string     `json:"file"`
+	RootIndex uint64     `json:"rootIndex"`
+	Root      []byte     `json:"root,omitempty"`
+	Size      int64      `json:"size"`
+	Checksum  string     `json:"checksum"`
+	Settings  DbSettings `json:"settings"`
+}
+
+// backupDatabases takes a snapshot of each database in dir and returns the manifest describing them.
+// An empty list of names means all the loaded databases.
+func (s *ImmuServer) backupDatabases(names []string, dir string) (*BackupManifest, error) {
+	var dbs []*Db
+	if len(names) == 0 {
+		for i := 0; i < s.dbList.Length(); i++ {
+			if db := s.dbList.GetByIndex(int64(i)); db != nil {
+				dbs = append(dbs, db)
+			}
+		}
+	}
+	for _, name := range names {
+		ind, ok := s.getDbIndexByName(name)
+		if !ok {
+			return nil, fmt.Errorf("%s does not exist", name)
+		}
+		db := s.dbList.GetByIndex(ind)
+		if db == nil {
+			return nil, fmt.Errorf("database %s is not loaded", name)
+		}
+		dbs = append(dbs, db)
+	}
+	manifest := &BackupManifest{
+		Version: backupManifestVersion,
+		Created: time.Now().UTC(),
+	}
+	for _, db := range dbs {
+		bd, err := acquireAndBackupDatabase(db, dir)
+		if err != nil {
+			return nil, fmt.Errorf("error backing up database %s: %v", db.options.dbName, err)
+		}
+		manifest.Databases = append(manifest.Databases, *bd)
+	}
+	return manifest, nil
+}
+
+// acquireAndBackupDatabase backs up db, keeping it from being unloaded or dropped meanwhile
+func acquireAndBackupDatabase(db *Db, dir string) (*BackupDatabase, error) {
+	if err := db.acquire(); err != nil {
+		return nil, err
+	}
+	defer db.release()
+	return backupDatabase(db, dir)
+}
+
+// backupDatabase writes a consistent snapshot of db in dir, at the root current when the backup started
+func backupDatabase(db *Db, dir string) (*BackupDatabase, error) {
+	name := db.options.dbName + ".bak"
+	f, err := os.Create(filepath.Join(dir, name))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	root, err := db.Store.Backup(io.MultiWriter(f, h))
+	if err != nil {
+		return nil, err
+	}
+	if err = f.Sync(); err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return &BackupDatabase{
+		Name:      db.options.dbName,
+		File:      name,
+		RootIndex: root.GetIndex(),
+		Root:      root.GetRoot(),
+		Size:      fi.Size(),
+		Checksum:  hex.EncodeToString(h.Sum(nil)),
+		Settings:  db.Settings(),
+	}, nil
+}
+
+// writeBackupArchive writes a tar.gz archive with the manifest followed by the database snapshots found in dir
+func writeBackupArchive(w io.Writer, dir string, manifest *BackupManifest) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = tw.WriteHeader(&tar.Header{
+		Name:    BackupManifestName,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: manifest.Created,
+	}); err != nil {
+		return err
+	}
+	if _, err = tw.Write(data); err != nil {
+		return err
+	}
+	for _, bd := range manifest.Databases {
+		if err = addFileToArchive(tw, filepath.Join(dir, bd.File), bd.File, manifest.Created); err != nil {
+			return err
+		}
+	}
+	if err = tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func addFileToArchive(tw *tar.Writer, path string, name string, modTime time.Time) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err = tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    fi.Size(),
+		ModTime: modTime,
+	}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// backupChunkWriter sends everything written to it as backup chunks
+type backupChunkWriter struct {
+	stream schema.ImmuService_BackupServer
+}
+
+func (w *backupChunkWriter) Write(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		size := len(p) - n
+		if size > backupChunkSize {
+			size = backupChunkSize
+		}
+		content := make([]byte, size)
+		copy(content, p[n:n+size])
+		if err := w.stream.Send(&schema.BackupChunk{Content: content}); err != nil {
+			return n, err
+		}
+		n += size
+	}
+	return n, nil
+}
+
+// Backup takes a consistent snapshot of the requested databases, or of all the loaded ones, without stopping
+// the server and streams it as a tar.gz archive with a manifest containing the roots of the snapshots
+func (s *ImmuServer) Backup(r *schema.BackupRequest, stream schema.ImmuService_BackupServer) error {
+	s.Logger.Debugf("Backup %+v", *r)
+	if err := s.requireSysAdmin(stream.Context()); err != nil {
+		return err
+	}
+	dir, err := ioutil.TempDir("", "immudb_backup")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	manifest, err := s.backupDatabases(r.Databases, dir)
+	if err != nil {
+		s.Logger.Errorf("backup failed: %v", err)
+		return err
+	}
+	bw := bufio.NewWriterSize(&backupChunkWriter{stream}, backupChunkSize)
+	if err = writeBackupArchive(bw, dir, manifest); err != nil {
+		return err
+	}
+	if err = bw.Flush(); err != nil {
+		return err
+	}
+	s.Logger.Infof("Online backup of %d databases completed", len(manifest.Databases))
+	return nil
+}
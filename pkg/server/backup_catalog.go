@@ -0,0 +1,430 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/codenotary/immudb/pkg/store"
+)
+
+// BackupCatalogName name of the file, inside the backup directory, listing the backups taken by the server
+const BackupCatalogName = "catalog.json"
+
+// BackupCatalog lists the backups stored in a backup directory, oldest first
+type BackupCatalog struct {
+	Backups []BackupCatalogEntry `json:"backups"`
+}
+
+// BackupCatalogEntry describes a backup archive stored in the backup directory
+type BackupCatalogEntry struct {
+	ID        string           `json:"id"`
+	File      string           `json:"file"`
+	Created   time.Time        `json:"created"`
+	Size      int64            `json:"size"`
+	Checksum  string           `json:"checksum"`
+	Databases []BackupDatabase `json:"databases"`
+}
+
+// LoadBackupCatalog reads the catalog of a backup directory, which is empty if no backup has been taken yet
+func LoadBackupCatalog(dir string) (*BackupCatalog, error) {
+	catalog := &BackupCatalog{}
+	data, err := ioutil.ReadFile(filepath.Join(dir, BackupCatalogName))
+	if os.IsNotExist(err) {
+		return catalog, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, catalog); err != nil {
+		return nil, fmt.Errorf("invalid backup catalog %s: %v", filepath.Join(dir, BackupCatalogName), err)
+	}
+	return catalog, nil
+}
+
+func (c *BackupCatalog) save(dir string) error {
+	return writeJSONFile(dir, BackupCatalogName, c)
+}
+
+// Get returns the backup with the given id
+func (c *BackupCatalog) Get(id string) (*BackupCatalogEntry, error) {
+	for i := range c.Backups {
+		if c.Backups[i].ID == id {
+			return &c.Backups[i], nil
+		}
+	}
+	return nil, fmt.Errorf("backup %s not found in catalog", id)
+}
+
+// newBackupID returns an id, based on the creation time, not yet used in the catalog
+func (c *BackupCatalog) newBackupID(created time.Time) string {
+	base := created.UTC().Format("20060102T150405Z")
+	id := base
+	for n := 1; ; n++ {
+		if _, err := c.Get(id); err != nil {
+			return id
+		}
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+}
+
+// applyRetention drops from the catalog, and from the backup directory, the backups exceeding the retention count or age
+func (c *BackupCatalog) applyRetention(dir string, opts BackupOptions, now time.Time, log logger.Logger) {
+	sort.SliceStable(c.Backups, func(i, j int) bool { return c.Backups[i].Created.Before(c.Backups[j].Created) })
+	var kept []BackupCatalogEntry
+	for i, b := range c.Backups {
+		expired := opts.RetentionAge > 0 && now.Sub(b.Created) > opts.RetentionAge
+		exceeding := opts.RetentionCount > 0 && len(c.Backups)-i > opts.RetentionCount
+		if !expired && !exceeding {
+			kept = append(kept, b)
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, b.File)); err != nil && !os.IsNotExist(err) {
+			log.Errorf("unable to remove expired backup %s: %v", b.ID, err)
+			kept = append(kept, b)
+			continue
+		}
+		log.Infof("removed expired backup %s", b.ID)
+	}
+	c.Backups = kept
+}
+
+// takeBackup writes in the backup directory an archive of the configured databases and records it in the catalog
+func (s *ImmuServer) takeBackup() (*BackupCatalogEntry, error) {
+	s.backupLock.Lock()
+	defer s.backupLock.Unlock()
+	return s.writeBackup()
+}
+
+// writeBackup works as takeBackup, it must be called holding backupLock
+func (s *ImmuServer) writeBackup() (*BackupCatalogEntry, error) {
+	opts := s.Options.BackupOptions.WithDir(s.Options.GetBackupDir())
+	if err := os.MkdirAll(opts.Dir, 0755); err != nil {
+		return nil, err
+	}
+	catalog, err := LoadBackupCatalog(opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+	tmpDir, err := ioutil.TempDir(opts.Dir, ".backup")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+	manifest, err := s.backupDatabases(opts.Databases, tmpDir)
+	if err != nil {
+		return nil, err
+	}
+	entry := BackupCatalogEntry{
+		ID:        catalog.newBackupID(manifest.Created),
+		Created:   manifest.Created,
+		Databases: manifest.Databases,
+	}
+	entry.File = "immudb_bkp_" + entry.ID + ".tar.gz"
+	tmpArchive := filepath.Join(tmpDir, entry.File)
+	f, err := os.Create(tmpArchive)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	cw := &countingWriter{w: io.MultiWriter(f, h)}
+	err = writeBackupArchive(cw, tmpDir, manifest)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = os.Rename(tmpArchive, filepath.Join(opts.Dir, entry.File)); err != nil {
+		return nil, err
+	}
+	entry.Size = cw.n
+	entry.Checksum = hex.EncodeToString(h.Sum(nil))
+	catalog.Backups = append(catalog.Backups, entry)
+	catalog.applyRetention(opts.Dir, opts, time.Now().UTC(), s.Logger)
+	if err = catalog.save(opts.Dir); err != nil {
+		return nil, err
+	}
+	s.Logger.Infof("Backup %s of %d databases stored in %s", entry.ID, len(entry.Databases), opts.Dir)
+	return &entry, nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// RestoreFromCatalog restores in dataDir the databases of a backup listed in the catalog of backupDir.
+// The server must not be running. Each database is restored aside, its checksum and root are verified against
+// the recorded ones and only then it replaces the existing database, which is kept in the archive dir of dataDir
+// with a _bkp_before_restore_ suffix, so that it is not loaded as a new database at the next start.
+func RestoreFromCatalog(backupDir string, id string, dataDir string, log logger.Logger) (*BackupCatalogEntry, error) {
+	catalog, err := LoadBackupCatalog(backupDir)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := catalog.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	archive := filepath.Join(backupDir, entry.File)
+	checksum, err := fileChecksum(archive)
+	if err != nil {
+		return nil, err
+	}
+	if checksum != entry.Checksum {
+		return nil, fmt.Errorf("backup %s is corrupted: archive checksum %s does not match %s", id, checksum, entry.Checksum)
+	}
+	if err = restoreDatabases(archive, "backup "+id, entry.Databases, dataDir, log); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// ReadBackupManifest returns the manifest of a backup archive taken by the server, which is its first entry
+func ReadBackupManifest(archive string) (*BackupManifest, error) {
+	f, err := os.Open(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(gr)
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, err
+	}
+	if hdr.Name != BackupManifestName {
+		return nil, fmt.Errorf("%s is not a backup archive taken by the server: %s not found", archive, BackupManifestName)
+	}
+	manifest := &BackupManifest{}
+	if err = json.NewDecoder(tr).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("invalid backup manifest in %s: %v", archive, err)
+	}
+	return manifest, nil
+}
+
+// IsBackupArchive returns true if path is a backup archive taken by the server, starting with a manifest
+func IsBackupArchive(path string) bool {
+	_, err := ReadBackupManifest(path)
+	return err == nil
+}
+
+// RestoreBackupArchive restores in dataDir the databases of a backup archive downloaded from the server.
+// The server must not be running. As for RestoreFromCatalog, each database is verified against the checksum and
+// the root recorded in the manifest of the archive before it replaces the existing one.
+func RestoreBackupArchive(archive string, dataDir string, log logger.Logger) (*BackupManifest, error) {
+	manifest, err := ReadBackupManifest(archive)
+	if err != nil {
+		return nil, err
+	}
+	if err = restoreDatabases(archive, "backup "+filepath.Base(archive), manifest.Databases, dataDir, log); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// restoreDatabases restores aside the databases of archive and verifies them, then moves them into dataDir
+func restoreDatabases(archive string, name string, databases []BackupDatabase, dataDir string, log logger.Logger) error {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return err
+	}
+	restoreDir, err := ioutil.TempDir(dataDir, ".restore")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(restoreDir)
+	if err = restoreBackupArchive(archive, name, databases, restoreDir, log); err != nil {
+		return err
+	}
+	now := time.Now().Format("2006-01-02_15-04-05")
+	archiveDir := ArchiveDir(dataDir)
+	var moved []movedDatabase
+	for _, bd := range databases {
+		m := movedDatabase{dir: filepath.Join(dataDir, bd.Name)}
+		if _, err := os.Stat(m.dir); err == nil {
+			if err = os.MkdirAll(archiveDir, 0755); err != nil {
+				rollbackRestore(moved, log)
+				return err
+			}
+			previous := filepath.Join(archiveDir, bd.Name+"_bkp_before_restore_"+now)
+			if err = os.Rename(m.dir, previous); err != nil {
+				rollbackRestore(moved, log)
+				return err
+			}
+			m.previous = previous
+		}
+		if err = os.Rename(filepath.Join(restoreDir, bd.Name), m.dir); err != nil {
+			rollbackRestore(append(moved, m), log)
+			return err
+		}
+		m.restored = true
+		moved = append(moved, m)
+	}
+	return nil
+}
+
+// movedDatabase a database directory replaced by a restore: previous is where the replaced one has been moved, if any
+type movedDatabase struct {
+	dir      string
+	previous string
+	restored bool
+}
+
+// rollbackRestore puts back the database directories replaced by a restore which failed midway
+func rollbackRestore(moved []movedDatabase, log logger.Logger) {
+	for i := len(moved) - 1; i >= 0; i-- {
+		m := moved[i]
+		if m.restored {
+			if err := os.RemoveAll(m.dir); err != nil {
+				log.Errorf("unable to remove the partially restored database %s: %v", m.dir, err)
+				continue
+			}
+		}
+		if m.previous != "" {
+			if err := os.Rename(m.previous, m.dir); err != nil {
+				log.Errorf("unable to put back database %s, it has been kept in %s: %v", m.dir, m.previous, err)
+			}
+		}
+	}
+}
+
+// restoreBackupArchive restores every database of the archive in a subdirectory of dir and verifies its checksum and root
+func restoreBackupArchive(archive string, name string, databases []BackupDatabase, dir string, log logger.Logger) error {
+	for _, bd := range databases {
+		//the names come from the archive, they must not lead out of dir
+		if bd.Name == "" || filepath.Base(bd.Name) != bd.Name || bd.Name == ".." {
+			return fmt.Errorf("invalid database name %q in %s", bd.Name, name)
+		}
+	}
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(gr)
+	restored := make(map[string]bool)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Name == BackupManifestName {
+			continue
+		}
+		var bd *BackupDatabase
+		for i := range databases {
+			if databases[i].File == hdr.Name {
+				bd = &databases[i]
+			}
+		}
+		if bd == nil {
+			return fmt.Errorf("unexpected file %s in %s", hdr.Name, name)
+		}
+		if err = restoreDatabase(tr, bd, filepath.Join(dir, bd.Name), log); err != nil {
+			return fmt.Errorf("error restoring database %s: %v", bd.Name, err)
+		}
+		restored[bd.Name] = true
+	}
+	for _, bd := range databases {
+		if !restored[bd.Name] {
+			return fmt.Errorf("database %s is missing from %s", bd.Name, name)
+		}
+	}
+	return nil
+}
+
+// restoreDatabase restores in dbDir the snapshot read from r. The snapshot is checked against the recorded checksum
+// before being loaded, the store is not meant to load arbitrary data
+func restoreDatabase(r io.Reader, bd *BackupDatabase, dbDir string, log logger.Logger) error {
+	if err := os.MkdirAll(dbDir, 0755); err != nil {
+		return err
+	}
+	snapshot := dbDir + ".bak"
+	f, err := os.Create(snapshot)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(snapshot)
+	defer f.Close()
+	h := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(f, h), r); err != nil {
+		return err
+	}
+	if checksum := hex.EncodeToString(h.Sum(nil)); checksum != bd.Checksum {
+		return fmt.Errorf("checksum %s does not match %s", checksum, bd.Checksum)
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	opts, badgerOpts := store.DefaultOptions(dbDir, log)
+	root, err := store.Restore(bufio.NewReader(f), opts, badgerOpts)
+	if err != nil {
+		return err
+	}
+	if root.GetIndex() != bd.RootIndex || !bytes.Equal(root.GetRoot(), bd.Root) {
+		return fmt.Errorf("restored root %d:%x does not match the recorded root %d:%x",
+			root.GetIndex(), root.GetRoot(), bd.RootIndex, bd.Root)
+	}
+	return bd.Settings.save(dbDir)
+}
+
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
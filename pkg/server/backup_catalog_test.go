@@ -0,0 +1,199 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/store"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTakeBackupAndRestoreFromCatalog(t *testing.T) {
+	backupDir, err := ioutil.TempDir("", "immudb_backups")
+	assert.Nil(t, err)
+	defer os.RemoveAll(backupDir)
+	dataDir, err := ioutil.TempDir("", "immudb_restored")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dataDir)
+
+	s := newInmemoryAuthServer()
+	s.Options.BackupOptions = DefaultBackupOptions().
+		WithSchedule("@daily").
+		WithDir(backupDir).
+		WithRetentionCount(2)
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+
+	var entries []*BackupCatalogEntry
+	for i := 0; i < 3; i++ {
+		_, err = s.SafeSet(ctx, &schema.SafeSetOptions{Kv: &schema.KeyValue{Key: testKey, Value: testValue}})
+		assert.Nil(t, err)
+		entry, err := s.takeBackup()
+		assert.Nil(t, err)
+		entries = append(entries, entry)
+	}
+	root, err := s.CurrentRoot(ctx, nil)
+	assert.Nil(t, err)
+
+	catalog, err := LoadBackupCatalog(backupDir)
+	assert.Nil(t, err)
+	assert.Len(t, catalog.Backups, 2)
+	_, err = catalog.Get(entries[0].ID)
+	assert.Error(t, err)
+	_, err = os.Stat(filepath.Join(backupDir, entries[0].File))
+	assert.True(t, os.IsNotExist(err))
+	last, err := catalog.Get(entries[2].ID)
+	assert.Nil(t, err)
+	assert.Len(t, last.Databases, 2)
+
+	// an existing database is kept aside
+	assert.Nil(t, os.MkdirAll(filepath.Join(dataDir, DefaultdbName), 0755))
+	restored, err := RestoreFromCatalog(backupDir, last.ID, dataDir, s.Logger)
+	assert.Nil(t, err)
+	assert.Equal(t, last.ID, restored.ID)
+	matches, err := filepath.Glob(filepath.Join(ArchiveDir(dataDir), DefaultdbName+"_bkp_before_restore_*"))
+	assert.Nil(t, err)
+	assert.Len(t, matches, 1)
+
+	opts, badgerOpts := store.DefaultOptions(filepath.Join(dataDir, DefaultdbName), s.Logger)
+	st, err := store.Open(opts, badgerOpts)
+	assert.Nil(t, err)
+	restoredRoot, err := st.CurrentRoot()
+	assert.Nil(t, err)
+	assert.Nil(t, st.Close())
+	assert.Equal(t, root.Index, restoredRoot.Index)
+	assert.Equal(t, root.Root, restoredRoot.Root)
+	_, err = os.Stat(filepath.Join(dataDir, DefaultdbName, settingsFileName))
+	assert.Nil(t, err)
+
+	_, err = RestoreFromCatalog(backupDir, "nobackup", dataDir, s.Logger)
+	assert.Error(t, err)
+
+	// a tampered archive is refused
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(backupDir, last.File), []byte("tampered"), 0644))
+	_, err = RestoreFromCatalog(backupDir, last.ID, dataDir, s.Logger)
+	assert.Error(t, err)
+}
+
+func TestRestoreFromCatalogRollback(t *testing.T) {
+	backupDir, err := ioutil.TempDir("", "immudb_backups")
+	assert.Nil(t, err)
+	defer os.RemoveAll(backupDir)
+	dataDir, err := ioutil.TempDir("", "immudb_restored")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dataDir)
+
+	s := newInmemoryAuthServer()
+	s.Options.BackupOptions = DefaultBackupOptions().WithDir(backupDir)
+	entry, err := s.takeBackup()
+	assert.Nil(t, err)
+	assert.Len(t, entry.Databases, 2)
+
+	for _, bd := range entry.Databases {
+		assert.Nil(t, os.MkdirAll(filepath.Join(dataDir, bd.Name), 0755))
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dataDir, bd.Name, "previous"), nil, 0644))
+	}
+	//the last database can not be moved aside: the first one, already restored, is rolled back
+	last := filepath.Join(ArchiveDir(dataDir), entry.Databases[1].Name)
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		blocker := last + "_bkp_before_restore_" + now.Add(time.Duration(i)*time.Second).Format("2006-01-02_15-04-05")
+		assert.Nil(t, os.MkdirAll(filepath.Join(blocker, "blocker"), 0755))
+	}
+	_, err = RestoreFromCatalog(backupDir, entry.ID, dataDir, s.Logger)
+	assert.Error(t, err)
+	for _, bd := range entry.Databases {
+		_, err = os.Stat(filepath.Join(dataDir, bd.Name, "previous"))
+		assert.Nil(t, err)
+	}
+	matches, err := filepath.Glob(filepath.Join(ArchiveDir(dataDir), entry.Databases[0].Name+"_bkp_before_restore_*"))
+	assert.Nil(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestRestoreFromCatalogRestart(t *testing.T) {
+	defer os.RemoveAll(lifecycleDir)
+	backupDir, err := ioutil.TempDir("", "immudb_backups")
+	assert.Nil(t, err)
+	defer os.RemoveAll(backupDir)
+
+	s := newLifecycleServer()
+	s.Options.BackupOptions = DefaultBackupOptions().WithDir(backupDir)
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+	_, err = s.CreateDatabase(ctx, &schema.Database{Databasename: "restored"})
+	assert.Nil(t, err)
+	entry, err := s.takeBackup()
+	assert.Nil(t, err)
+	s.CloseDatabases()
+
+	_, err = RestoreFromCatalog(backupDir, entry.ID, lifecycleDir, s.Logger)
+	assert.Nil(t, err)
+	matches, err := filepath.Glob(filepath.Join(ArchiveDir(lifecycleDir), "restored_bkp_before_restore_*"))
+	assert.Nil(t, err)
+	assert.Len(t, matches, 1)
+
+	//the databases replaced by the restore are not loaded as new ones
+	s = newLifecycleServer()
+	defer s.CloseDatabases()
+	ctx, err = loginSysAdmin(s)
+	assert.Nil(t, err)
+	list, err := s.DatabaseList(ctx, &empty.Empty{})
+	assert.Nil(t, err)
+	var names []string
+	for _, db := range list.Databases {
+		names = append(names, db.Databasename)
+	}
+	assert.ElementsMatch(t, []string{DefaultdbName, "restored"}, names)
+}
+
+func TestBackupDir(t *testing.T) {
+	op := DefaultOptions().WithDir("data")
+	assert.Equal(t, filepath.Join("data", ".backups"), op.GetBackupDir())
+	op.BackupOptions = op.BackupOptions.WithDir("elsewhere")
+	assert.Equal(t, "elsewhere", op.GetBackupDir())
+}
+
+func TestBackupCatalogRetentionAge(t *testing.T) {
+	dir, err := ioutil.TempDir("", "immudb_backups")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	now := time.Now()
+	catalog := &BackupCatalog{Backups: []BackupCatalogEntry{
+		{ID: "old", File: "old.tar.gz", Created: now.Add(-48 * time.Hour)},
+		{ID: "new", File: "new.tar.gz", Created: now.Add(-time.Hour)},
+	}}
+	for _, b := range catalog.Backups {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, b.File), nil, 0644))
+	}
+	catalog.applyRetention(dir, DefaultBackupOptions().WithRetentionCount(0).WithRetentionAge(24*time.Hour), now, &mockLogger{})
+	assert.Len(t, catalog.Backups, 1)
+	assert.Equal(t, "new", catalog.Backups[0].ID)
+	_, err = os.Stat(filepath.Join(dir, "old.tar.gz"))
+	assert.True(t, os.IsNotExist(err))
+
+	id := catalog.newBackupID(now)
+	catalog.Backups = append(catalog.Backups, BackupCatalogEntry{ID: id})
+	assert.Equal(t, id+"-1", catalog.newBackupID(now))
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// backupDirName directory of the data dir where backups are stored by default, hidden so that it is not loaded as a database
+const backupDirName = ".backups"
+
+// BackupOptions schedule, destination and retention of the backups taken by the server
+type BackupOptions struct {
+	Schedule       string
+	Dir            string
+	Databases      []string
+	RetentionCount int
+	RetentionAge   time.Duration
+}
+
+// DefaultBackupOptions returns options with scheduled backups disabled
+func DefaultBackupOptions() BackupOptions {
+	return BackupOptions{
+		RetentionCount: 7,
+	}
+}
+
+// WithSchedule sets when backups are taken: a cron expression like "30 2 * * *", a descriptor like "@daily"
+// or an interval like "@every 6h". An empty schedule disables scheduled backups
+func (o BackupOptions) WithSchedule(schedule string) BackupOptions {
+	o.Schedule = schedule
+	return o
+}
+
+// WithDir sets the directory where the backup archives and the catalog are stored, none means a .backups
+// directory inside the data dir
+func (o BackupOptions) WithDir(dir string) BackupOptions {
+	o.Dir = dir
+	return o
+}
+
+// WithDatabases sets the databases to backup, none means all the loaded ones
+func (o BackupOptions) WithDatabases(databases []string) BackupOptions {
+	o.Databases = databases
+	return o
+}
+
+// WithRetentionCount sets how many backups are kept, 0 means no limit
+func (o BackupOptions) WithRetentionCount(count int) BackupOptions {
+	o.RetentionCount = count
+	return o
+}
+
+// WithRetentionAge sets how long backups are kept, 0 means no limit
+func (o BackupOptions) WithRetentionAge(age time.Duration) BackupOptions {
+	o.RetentionAge = age
+	return o
+}
+
+// Enabled returns true if backups are scheduled
+func (o BackupOptions) Enabled() bool {
+	return o.Schedule != ""
+}
+
+// Validate returns an error if the schedule cannot be parsed or the retention is negative
+func (o BackupOptions) Validate() error {
+	if !o.Enabled() {
+		return nil
+	}
+	if _, err := parseBackupSchedule(o.Schedule); err != nil {
+		return err
+	}
+	if o.RetentionCount < 0 || o.RetentionAge < 0 {
+		return fmt.Errorf("backup retention cannot be negative")
+	}
+	return nil
+}
+
+// GetBackupDir returns the directory where the backups taken by the server are stored
+func (o Options) GetBackupDir() string {
+	if o.BackupOptions.Dir != "" {
+		return o.BackupOptions.Dir
+	}
+	return filepath.Join(o.Dir, backupDirName)
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBackupSchedule(t *testing.T) {
+	from := time.Date(2020, time.May, 15, 10, 20, 30, 0, time.UTC) // a friday
+	tt := []struct {
+		spec string
+		next time.Time
+	}{
+		{"30 2 * * *", time.Date(2020, time.May, 16, 2, 30, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2020, time.May, 15, 10, 30, 0, 0, time.UTC)},
+		{"0 9-17 * * mon-fri", time.Date(2020, time.May, 15, 11, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2020, time.May, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1,15 * *", time.Date(2020, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2020, time.May, 15, 11, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2020, time.May, 17, 0, 0, 0, 0, time.UTC)},
+		{"@every 6h", from.Add(6 * time.Hour)},
+	}
+	for _, tc := range tt {
+		s, err := parseBackupSchedule(tc.spec)
+		assert.Nil(t, err, tc.spec)
+		assert.Equal(t, tc.next, s.Next(from), tc.spec)
+	}
+
+	s, err := parseBackupSchedule("0 0 30 2 *")
+	assert.Nil(t, err)
+	assert.True(t, s.Next(from).IsZero())
+
+	for _, spec := range []string{"* * * *", "60 * * * *", "5-1 * * * *", "*/0 * * * *", "x * * * *", "@every 1s", "@every x"} {
+		_, err := parseBackupSchedule(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestBackupOptionsValidate(t *testing.T) {
+	assert.Nil(t, DefaultBackupOptions().Validate())
+	assert.False(t, DefaultBackupOptions().Enabled())
+	assert.Nil(t, DefaultBackupOptions().WithSchedule("@daily").Validate())
+	assert.Error(t, DefaultBackupOptions().WithSchedule("daily").Validate())
+	//no directory means the default one inside the data dir
+	assert.Nil(t, DefaultBackupOptions().WithSchedule("@daily").WithDir("").Validate())
+	assert.Error(t, DefaultBackupOptions().WithSchedule("@daily").WithRetentionCount(-1).Validate())
+}
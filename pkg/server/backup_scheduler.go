@@ -0,0 +1,79 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"time"
+)
+
+// startBackupScheduler takes a backup each time the schedule fires, until the server is stopped
+func (s *ImmuServer) startBackupScheduler() error {
+	if !s.Options.BackupOptions.Enabled() {
+		return nil
+	}
+	schedule, err := parseBackupSchedule(s.Options.BackupOptions.Schedule)
+	if err != nil {
+		return err
+	}
+	quit := make(chan struct{})
+	s.backupQuit = quit
+	s.Logger.Infof("Scheduling backups %s in %s", s.Options.BackupOptions.Schedule, s.Options.GetBackupDir())
+	s.backupWg.Add(1)
+	go func() {
+		defer s.backupWg.Done()
+		for {
+			next := schedule.Next(time.Now())
+			if next.IsZero() {
+				s.Logger.Warningf("backup schedule %s never fires again", s.Options.BackupOptions.Schedule)
+				return
+			}
+			timer := time.NewTimer(time.Until(next))
+			select {
+			case <-quit:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			if _, err := s.takeScheduledBackup(quit); err != nil {
+				s.Logger.Errorf("scheduled backup failed: %v", err)
+			}
+		}
+	}()
+	return nil
+}
+
+// takeScheduledBackup takes a backup unless the scheduler has been stopped, possibly while waiting for the backup lock
+func (s *ImmuServer) takeScheduledBackup(quit chan struct{}) (*BackupCatalogEntry, error) {
+	s.backupLock.Lock()
+	defer s.backupLock.Unlock()
+	select {
+	case <-quit:
+		return nil, nil
+	default:
+	}
+	return s.writeBackup()
+}
+
+// stopBackupScheduler stops scheduling backups, waiting for a running one to complete
+func (s *ImmuServer) stopBackupScheduler() {
+	if s.backupQuit == nil {
+		return
+	}
+	close(s.backupQuit)
+	s.backupQuit = nil
+	s.backupWg.Wait()
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/fs"
+	"github.com/codenotary/immudb/pkg/store"
+	"github.com/stretchr/testify/assert"
+)
+
+type backupStream struct {
+	mockServerStream
+	buf bytes.Buffer
+}
+
+func (r *backupStream) Send(m *schema.BackupChunk) error {
+	_, err := r.buf.Write(m.Content)
+	return err
+}
+
+func readBackupArchive(t *testing.T, r io.Reader) (*BackupManifest, map[string][]byte) {
+	gr, err := gzip.NewReader(r)
+	assert.Nil(t, err)
+	tr := tar.NewReader(gr)
+	var manifest *BackupManifest
+	files := make(map[string][]byte)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		data, err := ioutil.ReadAll(tr)
+		assert.Nil(t, err)
+		if hdr.Name == BackupManifestName {
+			assert.Nil(t, manifest, "manifest must be the first entry")
+			manifest = &BackupManifest{}
+			assert.Nil(t, json.Unmarshal(data, manifest))
+			continue
+		}
+		assert.NotNil(t, manifest, "manifest must be the first entry")
+		files[hdr.Name] = data
+	}
+	return manifest, files
+}
+
+func TestBackup(t *testing.T) {
+	s := newInmemoryAuthServer()
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+	for i := 0; i < 3; i++ {
+		_, err = s.SafeSet(ctx, &schema.SafeSetOptions{Kv: &schema.KeyValue{Key: testKey, Value: testValue}})
+		assert.Nil(t, err)
+	}
+	root, err := s.CurrentRoot(ctx, nil)
+	assert.Nil(t, err)
+
+	stream := &backupStream{mockServerStream: mockServerStream{ctx: ctx}}
+	assert.Nil(t, s.Backup(&schema.BackupRequest{}, stream))
+	manifest, files := readBackupArchive(t, &stream.buf)
+	assert.Equal(t, backupManifestVersion, manifest.Version)
+	assert.Len(t, manifest.Databases, 2)
+	for _, bd := range manifest.Databases {
+		data, ok := files[bd.File]
+		assert.True(t, ok)
+		assert.Equal(t, bd.Size, int64(len(data)))
+		sum := sha256.Sum256(data)
+		assert.Equal(t, hex.EncodeToString(sum[:]), bd.Checksum)
+		if bd.Name == s.Options.GetDefaultDbName() {
+			assert.Equal(t, root.Index, bd.RootIndex)
+			assert.Equal(t, root.Root, bd.Root)
+		}
+	}
+
+	stream = &backupStream{mockServerStream: mockServerStream{ctx: ctx}}
+	assert.Nil(t, s.Backup(&schema.BackupRequest{Databases: []string{s.Options.GetDefaultDbName()}}, stream))
+	manifest, files = readBackupArchive(t, &stream.buf)
+	assert.Len(t, manifest.Databases, 1)
+	assert.Len(t, files, 1)
+
+	stream = &backupStream{mockServerStream: mockServerStream{ctx: ctx}}
+	assert.Error(t, s.Backup(&schema.BackupRequest{Databases: []string{"nodb"}}, stream))
+}
+
+func TestRestoreBackupArchive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "immudb_online_backup")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	s := newInmemoryAuthServer()
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+	_, err = s.SafeSet(ctx, &schema.SafeSetOptions{Kv: &schema.KeyValue{Key: testKey, Value: testValue}})
+	assert.Nil(t, err)
+	root, err := s.CurrentRoot(ctx, nil)
+	assert.Nil(t, err)
+
+	stream := &backupStream{mockServerStream: mockServerStream{ctx: ctx}}
+	assert.Nil(t, s.Backup(&schema.BackupRequest{Databases: []string{DefaultdbName}}, stream))
+	archive := filepath.Join(dir, "online.tar.gz")
+	assert.Nil(t, ioutil.WriteFile(archive, stream.buf.Bytes(), 0644))
+	assert.True(t, IsBackupArchive(archive))
+
+	dataDir := filepath.Join(dir, "data")
+	manifest, err := RestoreBackupArchive(archive, dataDir, s.Logger)
+	assert.Nil(t, err)
+	assert.Len(t, manifest.Databases, 1)
+	opts, badgerOpts := store.DefaultOptions(filepath.Join(dataDir, DefaultdbName), s.Logger)
+	st, err := store.Open(opts, badgerOpts)
+	assert.Nil(t, err)
+	restoredRoot, err := st.CurrentRoot()
+	assert.Nil(t, err)
+	assert.Nil(t, st.Close())
+	assert.Equal(t, root.Index, restoredRoot.Index)
+	assert.Equal(t, root.Root, restoredRoot.Root)
+
+	// an archive whose snapshot does not match the manifest is refused
+	var tampered bytes.Buffer
+	gw := gzip.NewWriter(&tampered)
+	tw := tar.NewWriter(gw)
+	data, err := json.Marshal(manifest)
+	assert.Nil(t, err)
+	assert.Nil(t, tw.WriteHeader(&tar.Header{Name: BackupManifestName, Mode: 0644, Size: int64(len(data))}))
+	_, err = tw.Write(data)
+	assert.Nil(t, err)
+	assert.Nil(t, tw.WriteHeader(&tar.Header{Name: manifest.Databases[0].File, Mode: 0644, Size: 8}))
+	_, err = tw.Write([]byte("tampered"))
+	assert.Nil(t, err)
+	assert.Nil(t, tw.Close())
+	assert.Nil(t, gw.Close())
+	assert.Nil(t, ioutil.WriteFile(archive, tampered.Bytes(), 0644))
+	_, err = RestoreBackupArchive(archive, dataDir, s.Logger)
+	assert.Error(t, err)
+
+	// a plain archive of a data directory is not a backup archive
+	plain := filepath.Join(dir, "data.tar.gz")
+	assert.Nil(t, fs.TarIt(dataDir, plain))
+	assert.False(t, IsBackupArchive(plain))
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/x509"
+
+	"github.com/codenotary/immudb/pkg/auth"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// DatabaseNameHeader request header used by certificate authenticated callers to select the database
+const DatabaseNameHeader = "databasename"
+
+// certIdentities returns the identities of the certificate for the configured field
+func certIdentities(cert *x509.Certificate, field string) []string {
+	switch field {
+	case CertIdentityCN:
+		if cert.Subject.CommonName != "" {
+			return []string{cert.Subject.CommonName}
+		}
+	case CertIdentitySAN:
+		identities := append([]string{}, cert.DNSNames...)
+		identities = append(identities, cert.EmailAddresses...)
+		for _, uri := range cert.URIs {
+			identities = append(identities, uri.String())
+		}
+		for _, ip := range cert.IPAddresses {
+			identities = append(identities, ip.String())
+		}
+		return identities
+	}
+	return nil
+}
+
+// peerCertificate returns the verified client certificate of the request, if any
+func peerCertificate(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p == nil {
+		return nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil
+	}
+	for _, chain := range tlsInfo.State.VerifiedChains {
+		if len(chain) > 0 {
+			return chain[0]
+		}
+	}
+	return nil
+}
+
+// getCertUserdataFromCtx authenticates the request through the client certificate when
+// identity mapping is enabled and returns the selected database index and the mapped user
+func (s *ImmuServer) getCertUserdataFromCtx(ctx context.Context) (int64, *auth.User, bool) {
+	if !s.Options.MTLs || s.Options.MTLsOptions.IdentityField == "" {
+		return -1, nil, false
+	}
+	cert := peerCertificate(ctx)
+	if cert == nil {
+		return -1, nil, false
+	}
+	for _, identity := range certIdentities(cert, s.Options.MTLsOptions.IdentityField) {
+		username, mapped := s.Options.MTLsOptions.IdentityMap[identity]
+		if !mapped {
+			//the system admin can only be reached through an explicit mapping
+			if identity == auth.SysAdminUsername {
+				continue
+			}
+			username = identity
+		}
+		u, err := s.getUser([]byte(username), false)
+		if err != nil {
+			continue
+		}
+		if u.Username == auth.SysAdminUsername {
+			u.IsSysAdmin = true
+		}
+		s.Logger.Debugf("certificate identity %s authenticated as %s", identity, u.Username)
+		return s.certDbIndex(ctx), u, true
+	}
+	return -1, nil, false
+}
+
+// certDbIndex returns the database selected through the DatabaseNameHeader, defaulting to the
+// same index a password login would get
+func (s *ImmuServer) certDbIndex(ctx context.Context) int64 {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if names := md.Get(DatabaseNameHeader); len(names) > 0 && names[0] != SystemdbName {
+			if ind, ok := s.getDbIndexByName(names[0]); ok {
+				return ind
+			}
+		}
+	}
+	if s.multidbmode {
+		return -1
+	}
+	return DefaultDbIndex
+}
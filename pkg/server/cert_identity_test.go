@@ -0,0 +1,99 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func certCtx(cert *x509.Certificate) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234},
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}},
+		},
+	})
+}
+
+func TestCertIdentities(t *testing.T) {
+	uri, _ := url.Parse("spiffe://mesh/ns/app")
+	cert := &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "app"},
+		DNSNames:       []string{"app.local"},
+		EmailAddresses: []string{"app@mesh"},
+		URIs:           []*url.URL{uri},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.1")},
+	}
+	assert.Equal(t, []string{"app"}, certIdentities(cert, CertIdentityCN))
+	assert.Equal(t,
+		[]string{"app.local", "app@mesh", "spiffe://mesh/ns/app", "10.0.0.1"},
+		certIdentities(cert, CertIdentitySAN))
+	assert.Nil(t, certIdentities(cert, ""))
+}
+
+func TestCertIdentityMapping(t *testing.T) {
+	s := newInmemoryAuthServer()
+	_, _, err := s.insertNewUser([]byte("app_user"), []byte("App@12345"), auth.PermissionRW, DefaultdbName, true, auth.SysAdminUsername)
+	assert.Nil(t, err)
+
+	uri, _ := url.Parse("spiffe://mesh/ns/app")
+	appCert := &x509.Certificate{Subject: pkix.Name{CommonName: "app_user"}, URIs: []*url.URL{uri}}
+	adminCert := &x509.Certificate{Subject: pkix.Name{CommonName: auth.SysAdminUsername}}
+
+	_, _, err = s.getLoggedInUserdataFromCtx(certCtx(appCert))
+	assert.Error(t, err, "certificate authentication must be disabled by default")
+
+	s.Options.MTLs = true
+	s.Options.MTLsOptions = DefaultMTLsOptions().WithIdentityField(CertIdentityCN)
+	ind, u, err := s.getLoggedInUserdataFromCtx(certCtx(appCert))
+	assert.Nil(t, err)
+	assert.Equal(t, "app_user", u.Username)
+	assert.Equal(t, int64(DefaultDbIndex), ind)
+	assert.Equal(t, uint32(auth.PermissionRW), u.WhichPermission(DefaultdbName))
+
+	_, _, err = s.getLoggedInUserdataFromCtx(certCtx(adminCert))
+	assert.Error(t, err, "system admin must not be reachable without an explicit mapping")
+
+	s.Options.MTLsOptions = s.Options.MTLsOptions.
+		WithIdentityField(CertIdentitySAN).
+		WithIdentityMap(map[string]string{"spiffe://mesh/ns/app": "app_user"})
+	ctx := metadata.NewIncomingContext(certCtx(appCert), metadata.Pairs(DatabaseNameHeader, SystemdbName))
+	ind, u, err = s.getLoggedInUserdataFromCtx(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "app_user", u.Username)
+	assert.Equal(t, int64(DefaultDbIndex), ind, "the system database can not be selected")
+
+	adminCtx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+	_, err = s.SetActiveUser(adminCtx, &schema.SetActiveUserRequest{Active: false, Username: "app_user"})
+	assert.Nil(t, err)
+	_, _, err = s.getLoggedInUserdataFromCtx(certCtx(appCert))
+	assert.Error(t, err, "deactivated users must not be authenticated")
+}
@@ -20,12 +20,13 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/binary"
+	"fmt"
+	"math"
 	mrand "math/rand"
 	"sync"
 	"time"
 
 	"github.com/codenotary/immudb/pkg/api/schema"
-	"github.com/codenotary/immudb/pkg/auth"
 	"github.com/codenotary/immudb/pkg/logger"
 	"github.com/codenotary/immudb/pkg/store"
 )
@@ -37,6 +38,9 @@ type CCOptions struct {
 	singleiteration    bool
 	iterationSleepTime time.Duration
 	frequencySleepTime time.Duration
+	mode               string
+	batchSize          uint64
+	sampleSize         uint64
 }
 
 type corruptionChecker struct {
@@ -72,7 +76,25 @@ func NewCorruptionChecker(opt CCOptions, d DatabaseList, l logger.Logger) Corrup
 // Start start the trust checker loop
 func (s *corruptionChecker) Start(ctx context.Context) (err error) {
 	s.Logger.Debugf("Start scanning ...")
-	return s.checkLevel0(ctx)
+	s.Wg.Add(1)
+	defer s.Wg.Done()
+	for !s.Exit {
+		for s.currentDbIndex = 0; s.currentDbIndex < s.dbList.Length() && !s.Exit; s.currentDbIndex++ {
+			db := s.dbList.GetByIndex(int64(s.currentDbIndex))
+			if db == nil {
+				//unloaded database, move on to the next one
+				continue
+			}
+			if err = s.checkDb(db); err != nil {
+				s.Logger.Errorf("Error checking database %s: %s", db.options.dbName, err)
+			}
+		}
+		if s.options.singleiteration {
+			return nil
+		}
+		s.sleep()
+	}
+	return nil
 }
 
 // Stop stop the trust checker loop
@@ -87,68 +109,145 @@ func (s *corruptionChecker) GetStatus(ctx context.Context) bool {
 	return s.Trusted
 }
 
-func (s *corruptionChecker) checkLevel0(ctx context.Context) (err error) {
-	s.Wg.Add(1)
+// checkDb verifies the next range, or a random sample, of the entries of a database against its current root
+func (s *corruptionChecker) checkDb(db *Db) error {
 	s.Logger.Debugf("Retrieving a fresh root ...")
-	if s.currentDbIndex == s.dbList.Length() {
-		s.currentDbIndex = 0
+	if db.acquire() != nil {
+		//the database has been unloaded meanwhile
+		return nil
+	}
+	r, err := db.Store.CurrentRoot()
+	db.release()
+	if err != nil {
+		return fmt.Errorf("error retrieving root: %v", err)
 	}
-	db := s.dbList.GetByIndex(int64(s.currentDbIndex))
-	s.currentDbIndex++
-	var r *schema.Root
-	if r, err = db.Store.CurrentRoot(); err != nil {
-		s.Logger.Errorf("Error retrieving root: %s", err)
-		return
+	if !db.corruptionCheckDue(time.Now()) {
+		s.Logger.Debugf("Database %s was checked recently, skipping it", db.options.dbName)
+		return nil
 	}
 	if r.Root == nil {
 		s.Logger.Debugf("Immudb is empty ...")
+		return nil
+	}
+	progress := db.CorruptionCheckProgress()
+	var next func() (uint64, bool)
+	total, alreadyChecked := s.options.sampleSize, uint64(0)
+	if s.options.mode == CorruptionCheckSample {
+		next = s.sample(r.Index)
+		s.Logger.Debugf("Start sampling %d elements", s.options.sampleSize)
 	} else {
-		// create a range with all index presents in immudb
-		ids := makeRange(0, r.Index)
-		rn := mrand.New(newCryptoRandSource())
-		// shuffle indexes
-		rn.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
-		s.Logger.Debugf("Start scanning %d elements", len(ids))
-		for _, id := range ids {
-			if s.Exit {
-				s.Wg.Done()
-				return
-			}
-			var item *schema.SafeItem
-			if item, err = db.Store.BySafeIndex(schema.SafeIndexOptions{
-				Index: id,
-				RootIndex: &schema.Index{
-					Index: r.Index,
-				},
-			}); err != nil {
-				if err == store.ErrInconsistentDigest {
-					auth.IsTampered = true
-					s.Logger.Errorf("insertion order index %d was tampered", id)
-					s.Wg.Done()
-					return
-				}
-				s.Logger.Errorf("Error retrieving element at index %d: %s", id, err)
+		if progress.NextIndex > r.Index {
+			//the entries have changed since the progress was saved
+			progress.NextIndex = 0
+			progress.ScanFailed = false
+		}
+		total, alreadyChecked = r.Index+1, progress.NextIndex
+		next = s.scan(&progress.NextIndex, r.Index)
+		s.Logger.Debugf("Start scanning from index %d up to %d", progress.NextIndex, r.Index)
+	}
+	Metrics.CorruptionCheckerGauges.WithLabelValues(db.options.dbName, "total").Set(float64(total))
+	checked := Metrics.CorruptionCheckerGauges.WithLabelValues(db.options.dbName, "checked")
+	checked.Set(float64(alreadyChecked))
+	//exiting is checked before taking the next index, so that the progress never skips an entry
+	for !s.Exit {
+		id, ok := next()
+		if !ok {
+			break
+		}
+		//the database is acquired for one entry at a time, so that unloading it does not wait for the whole batch
+		if db.acquire() != nil {
+			return nil
+		}
+		tampered, err := s.verify(db, id, r)
+		db.release()
+		if err != nil {
+			progress.Failures = append(progress.Failures, CorruptionCheckFailure{Index: id, Time: time.Now(), Error: err.Error()})
+			progress.ScanFailed = true
+			if tampered {
+				break
 			}
-			verified := item.Proof.Verify(item.Proof.Leaf, *r)
-			s.Logger.Debugf("Item index %d, value %s, verified %t", item.Item.Index, item.Item.Value, verified)
-			if !verified {
-				s.Trusted = false
-				auth.IsTampered = true
-				s.Logger.Errorf(ErrConsistencyFail, item.Item.Index)
-				s.Wg.Done()
-				return
+			continue
+		}
+		if s.options.mode == CorruptionCheckSample {
+			progress.SampledEntries++
+		}
+		checked.Inc()
+		time.Sleep(s.options.frequencySleepTime)
+	}
+	progress.LastRun = time.Now()
+	if s.options.mode != CorruptionCheckSample && progress.NextIndex > r.Index {
+		//a full scan completed: every entry up to r has been checked, the root is recorded only if all passed
+		if !progress.ScanFailed {
+			progress.RootIndex = r.Index
+			progress.Root = r.Root
+		}
+		progress.CompletedScans++
+		progress.NextIndex = 0
+		progress.ScanFailed = false
+	}
+	return db.setCorruptionCheckProgress(progress)
+}
+
+// verify checks the entry at index id against the root r, it returns true if the database has been tampered
+func (s *corruptionChecker) verify(db *Db, id uint64, r *schema.Root) (bool, error) {
+	item, err := db.Store.BySafeIndex(schema.SafeIndexOptions{
+		Index: id,
+		RootIndex: &schema.Index{
+			Index: r.Index,
+		},
+	})
+	if err != nil {
+		if err == store.ErrInconsistentDigest {
+			s.Trusted = false
+			if serr := db.SetTampered(id); serr != nil {
+				s.Logger.Errorf("unable to persist the tamper state of database %s: %s", db.options.dbName, serr)
 			}
-			time.Sleep(s.options.frequencySleepTime)
+			s.Logger.Errorf("insertion order index %d of database %s was tampered", id, db.options.dbName)
+			return true, err
 		}
+		s.Logger.Errorf("Error retrieving element at index %d: %s", id, err)
+		return false, err
 	}
-	s.Wg.Done()
-	s.sleep()
-	if !s.Exit && !s.options.singleiteration {
-		if err = s.checkLevel0(ctx); err != nil {
-			return err
+	verified := item.Proof.Verify(item.Proof.Leaf, *r)
+	s.Logger.Debugf("Item index %d, value %s, verified %t", item.Item.Index, item.Item.Value, verified)
+	if !verified {
+		s.Trusted = false
+		if serr := db.SetTampered(item.Item.Index); serr != nil {
+			s.Logger.Errorf("unable to persist the tamper state of database %s: %s", db.options.dbName, serr)
 		}
+		s.Logger.Errorf(ErrConsistencyFail+" of database %s", item.Item.Index, db.options.dbName)
+		return true, fmt.Errorf(ErrConsistencyFail, item.Item.Index)
+	}
+	return false, nil
+}
+
+// scan returns the indexes from *from up to rootIndex, at most batchSize of them, advancing *from
+func (s *corruptionChecker) scan(from *uint64, rootIndex uint64) func() (uint64, bool) {
+	var n uint64
+	return func() (uint64, bool) {
+		if *from > rootIndex || (s.options.batchSize > 0 && n >= s.options.batchSize) {
+			return 0, false
+		}
+		n++
+		*from++
+		return *from - 1, true
+	}
+}
+
+// sample returns sampleSize random indexes up to rootIndex, without materialising the whole range
+func (s *corruptionChecker) sample(rootIndex uint64) func() (uint64, bool) {
+	rn := mrand.New(newCryptoRandSource())
+	var n uint64
+	return func() (uint64, bool) {
+		if n >= s.options.sampleSize {
+			return 0, false
+		}
+		n++
+		if rootIndex >= math.MaxInt64 {
+			return rn.Uint64() % (rootIndex + 1), true
+		}
+		return uint64(rn.Int63n(int64(rootIndex) + 1)), true
 	}
-	return nil
 }
 
 func (s *corruptionChecker) sleep() {
@@ -158,14 +257,6 @@ func (s *corruptionChecker) sleep() {
 	}
 }
 
-func makeRange(min, max uint64) []uint64 {
-	a := make([]uint64, max-min+1)
-	var i uint64
-	for i = min; i <= max; i++ {
-		a[i] = i
-	}
-	return a
-}
 func (s *corruptionChecker) Wait() {
 	s.Wg.Wait()
 }
@@ -0,0 +1,103 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"time"
+)
+
+// corruption checker modes
+const (
+	CorruptionCheckScan   = "scan"
+	CorruptionCheckSample = "sample"
+)
+
+// CorruptionCheckerOptions strategy and I/O budget of the corruption checker
+type CorruptionCheckerOptions struct {
+	Mode             string
+	EntriesPerSecond int
+	BatchSize        uint64
+	SampleSize       uint64
+	Interval         time.Duration
+}
+
+// DefaultCorruptionCheckerOptions ...
+func DefaultCorruptionCheckerOptions() CorruptionCheckerOptions {
+	return CorruptionCheckerOptions{
+		Mode:             CorruptionCheckScan,
+		EntriesPerSecond: 2,
+		BatchSize:        1000,
+		SampleSize:       100,
+		Interval:         5 * time.Second,
+	}
+}
+
+// WithMode sets how entries are picked: a deterministic scan of index ranges or a random sample of each database
+func (o CorruptionCheckerOptions) WithMode(mode string) CorruptionCheckerOptions {
+	o.Mode = mode
+	return o
+}
+
+// WithEntriesPerSecond sets the maximum number of entries verified per second, 0 means no limit
+func (o CorruptionCheckerOptions) WithEntriesPerSecond(entriesPerSecond int) CorruptionCheckerOptions {
+	o.EntriesPerSecond = entriesPerSecond
+	return o
+}
+
+// WithBatchSize sets the number of entries scanned per database in each iteration, 0 scans up to the current root
+func (o CorruptionCheckerOptions) WithBatchSize(batchSize uint64) CorruptionCheckerOptions {
+	o.BatchSize = batchSize
+	return o
+}
+
+// WithSampleSize sets the number of random entries verified per database in each iteration of the sample mode
+func (o CorruptionCheckerOptions) WithSampleSize(sampleSize uint64) CorruptionCheckerOptions {
+	o.SampleSize = sampleSize
+	return o
+}
+
+// WithInterval sets the pause between two iterations over all databases
+func (o CorruptionCheckerOptions) WithInterval(interval time.Duration) CorruptionCheckerOptions {
+	o.Interval = interval
+	return o
+}
+
+// Validate returns an error if the mode is unknown
+func (o CorruptionCheckerOptions) Validate() error {
+	if o.Mode != "" && o.Mode != CorruptionCheckScan && o.Mode != CorruptionCheckSample {
+		return fmt.Errorf("invalid consistency check mode %s: allowed values are %s and %s",
+			o.Mode, CorruptionCheckScan, CorruptionCheckSample)
+	}
+	return nil
+}
+
+// pause returns the delay between two verified entries
+func (o CorruptionCheckerOptions) pause() time.Duration {
+	if o.EntriesPerSecond <= 0 {
+		return 0
+	}
+	return time.Second / time.Duration(o.EntriesPerSecond)
+}
+
+// interval returns the pause between two iterations, falling back to the default one
+func (o CorruptionCheckerOptions) interval() time.Duration {
+	if o.Interval <= 0 {
+		return DefaultCorruptionCheckerOptions().Interval
+	}
+	return o.Interval
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+const corruptionCheckFileName = "corruption_check.json"
+
+// maxCorruptionCheckFailures bounds the failures kept for each database
+const maxCorruptionCheckFailures = 100
+
+// CorruptionCheckFailure an entry which could not be verified
+type CorruptionCheckFailure struct {
+	Index uint64    `json:"index"`
+	Time  time.Time `json:"time"`
+	Error string    `json:"error"`
+}
+
+// CorruptionCheckProgress progress and results of the corruption checker on a database, persisted in its directory
+type CorruptionCheckProgress struct {
+	NextIndex      uint64                   `json:"nextIndex"`
+	CompletedScans uint64                   `json:"completedScans"`
+	ScanFailed     bool                     `json:"scanFailed,omitempty"`
+	SampledEntries uint64                   `json:"sampledEntries"`
+	RootIndex      uint64                   `json:"rootIndex"`
+	Root           []byte                   `json:"root,omitempty"`
+	LastRun        time.Time                `json:"lastRun,omitempty"`
+	Failures       []CorruptionCheckFailure `json:"failures,omitempty"`
+}
+
+// corruptionCheckState corruption checker progress of a database
+type corruptionCheckState struct {
+	progress CorruptionCheckProgress
+	sync.RWMutex
+}
+
+// loadCorruptionCheckProgress reads the progress of the corruption checker on the database in dbDir
+func loadCorruptionCheckProgress(dbDir string) (CorruptionCheckProgress, error) {
+	var progress CorruptionCheckProgress
+	data, err := ioutil.ReadFile(filepath.Join(dbDir, corruptionCheckFileName))
+	if os.IsNotExist(err) {
+		return progress, nil
+	}
+	if err != nil {
+		return progress, err
+	}
+	if err = json.Unmarshal(data, &progress); err != nil {
+		return CorruptionCheckProgress{}, fmt.Errorf("corrupted consistency check progress of database in %s: %v", dbDir, err)
+	}
+	return progress, nil
+}
+
+// CorruptionCheckProgress returns the progress of the corruption checker on the database
+func (d *Db) CorruptionCheckProgress() CorruptionCheckProgress {
+	d.ccState.RLock()
+	defer d.ccState.RUnlock()
+	progress := d.ccState.progress
+	progress.Failures = append([]CorruptionCheckFailure(nil), progress.Failures...)
+	return progress
+}
+
+// setCorruptionCheckProgress replaces the progress of the corruption checker and persists it, in memory databases excluded
+func (d *Db) setCorruptionCheckProgress(progress CorruptionCheckProgress) error {
+	if n := len(progress.Failures); n > maxCorruptionCheckFailures {
+		progress.Failures = progress.Failures[n-maxCorruptionCheckFailures:]
+	}
+	d.ccState.Lock()
+	defer d.ccState.Unlock()
+	d.ccState.progress = progress
+	if d.options.GetInMemoryStore() {
+		return nil
+	}
+	return writeJSONFile(filepath.Join(d.options.GetDbRootPath(), d.options.GetDbName()), corruptionCheckFileName, progress)
+}
+
+// writeJSONFile atomically replaces the file name in dir with the json encoding of v
+func writeJSONFile(dir string, name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(dir, name+".tmp")
+	if err = ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(dir, name))
+}
+
+// CorruptionCheckerStatus returns the progress and the failures of the corruption checker on a database, or on all of them
+func (s *ImmuServer) CorruptionCheckerStatus(ctx context.Context, r *schema.CorruptionCheckerStatusRequest) (*schema.CorruptionCheckerStatusResponse, error) {
+	s.Logger.Debugf("CorruptionCheckerStatus %+v", *r)
+	if err := s.requireSysAdmin(ctx); err != nil {
+		return nil, err
+	}
+	mode := s.Options.CorruptionCheckerOptions.Mode
+	if mode == "" {
+		mode = CorruptionCheckScan
+	}
+	resp := &schema.CorruptionCheckerStatusResponse{
+		Enabled: s.Options.CorruptionCheck,
+		Mode:    mode,
+	}
+	if r.Databasename != "" {
+		ind, ok := s.getDbIndexByName(r.Databasename)
+		if !ok {
+			return nil, fmt.Errorf("%s does not exist", r.Databasename)
+		}
+		db, err := s.acquireDb(ind)
+		if err != nil {
+			return nil, err
+		}
+		defer db.release()
+		resp.Databases = append(resp.Databases, corruptionCheckStatusToProto(db))
+		return resp, nil
+	}
+	for i := 0; i < s.dbList.Length(); i++ {
+		if db, err := s.acquireDb(int64(i)); err == nil {
+			resp.Databases = append(resp.Databases, corruptionCheckStatusToProto(db))
+			db.release()
+		}
+	}
+	return resp, nil
+}
+
+// corruptionCheckStatusToProto returns the corruption checker status of an acquired database
+func corruptionCheckStatusToProto(db *Db) *schema.DatabaseCorruptionCheckerStatus {
+	progress := db.CorruptionCheckProgress()
+	status := &schema.DatabaseCorruptionCheckerStatus{
+		Databasename:   db.options.GetDbName(),
+		TotalEntries:   db.Store.EntriesCount(),
+		ScannedEntries: progress.NextIndex,
+		CompletedScans: progress.CompletedScans,
+		SampledEntries: progress.SampledEntries,
+	}
+	if progress.Root != nil {
+		status.LastVerifiedRoot = &schema.Root{Index: progress.RootIndex, Root: progress.Root}
+	}
+	if !progress.LastRun.IsZero() {
+		status.LastRun = progress.LastRun.Unix()
+	}
+	for _, f := range progress.Failures {
+		status.Failures = append(status.Failures, &schema.CorruptionCheckerFailure{
+			Index:     f.Index,
+			Timestamp: f.Time.Unix(),
+			Error:     f.Error,
+		})
+	}
+	return status
+}
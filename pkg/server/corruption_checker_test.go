@@ -18,6 +18,8 @@ package server
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
 	"strconv"
 	"testing"
 	"time"
@@ -78,6 +80,95 @@ func TestCorruptionChecker(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestCorruptionCheckerResumesScan(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cc_test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	options := DefaultOption().WithDbRootPath(dir).WithDbName("ccdb").WithCorruptionChecker(false)
+	db, err := NewDb(options, &mockLogger{})
+	assert.Nil(t, err)
+	for i := 0; i < 5; i++ {
+		_, err = db.SafeSet(&schema.SafeSetOptions{Kv: &schema.KeyValue{Key: []byte(strconv.Itoa(i)), Value: []byte("value")}})
+		assert.Nil(t, err)
+	}
+	dbList := NewDatabaseList()
+	dbList.Append(db)
+
+	cco := CCOptions{singleiteration: true, batchSize: 2}
+	assert.Nil(t, NewCorruptionChecker(cco, dbList, &mockLogger{}).Start(context.TODO()))
+	assert.Equal(t, uint64(2), db.CorruptionCheckProgress().NextIndex)
+	assert.Nil(t, NewCorruptionChecker(cco, dbList, &mockLogger{}).Start(context.TODO()))
+	progress := db.CorruptionCheckProgress()
+	assert.Equal(t, uint64(4), progress.NextIndex)
+	//the root is recorded only once the whole scan has completed
+	assert.Nil(t, progress.Root)
+	assert.Nil(t, db.Store.Close())
+
+	//the scan resumes after a restart
+	db, err = OpenDb(options, &mockLogger{})
+	assert.Nil(t, err)
+	defer db.Store.Close()
+	assert.Equal(t, uint64(4), db.CorruptionCheckProgress().NextIndex)
+	dbList = NewDatabaseList()
+	dbList.Append(db)
+	assert.Nil(t, NewCorruptionChecker(cco, dbList, &mockLogger{}).Start(context.TODO()))
+	progress = db.CorruptionCheckProgress()
+	assert.Equal(t, uint64(0), progress.NextIndex)
+	assert.Equal(t, uint64(1), progress.CompletedScans)
+	assert.Equal(t, uint64(4), progress.RootIndex)
+	assert.NotNil(t, progress.Root)
+	assert.Nil(t, NewCorruptionChecker(cco, dbList, &mockLogger{}).Start(context.TODO()))
+	progress = db.CorruptionCheckProgress()
+	assert.Equal(t, uint64(2), progress.NextIndex)
+	assert.Equal(t, uint64(1), progress.CompletedScans)
+	assert.Empty(t, progress.Failures)
+}
+
+func TestCorruptionCheckerSample(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+	for i := 0; i < 3; i++ {
+		_, err := db.SafeSet(&schema.SafeSetOptions{Kv: &schema.KeyValue{Key: []byte(strconv.Itoa(i)), Value: []byte("value")}})
+		assert.Nil(t, err)
+	}
+	dbList := NewDatabaseList()
+	dbList.Append(db)
+
+	cco := CCOptions{singleiteration: true, mode: CorruptionCheckSample, sampleSize: 10}
+	assert.Nil(t, NewCorruptionChecker(cco, dbList, &mockLogger{}).Start(context.TODO()))
+	progress := db.CorruptionCheckProgress()
+	assert.Equal(t, uint64(10), progress.SampledEntries)
+	assert.Equal(t, uint64(0), progress.NextIndex)
+	//a sample does not verify the whole root
+	assert.Nil(t, progress.Root)
+}
+
+func TestCorruptionCheckerStatus(t *testing.T) {
+	s := newInmemoryAuthServer()
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+	_, err = s.SafeSet(ctx, &schema.SafeSetOptions{Kv: &schema.KeyValue{Key: testKey, Value: testValue}})
+	assert.Nil(t, err)
+	cco := CCOptions{singleiteration: true}
+	assert.Nil(t, NewCorruptionChecker(cco, s.dbList, &mockLogger{}).Start(context.TODO()))
+
+	resp, err := s.CorruptionCheckerStatus(ctx, &schema.CorruptionCheckerStatusRequest{Databasename: DefaultdbName})
+	assert.Nil(t, err)
+	assert.Equal(t, CorruptionCheckScan, resp.Mode)
+	assert.Len(t, resp.Databases, 1)
+	assert.Equal(t, DefaultdbName, resp.Databases[0].Databasename)
+	assert.Equal(t, uint64(1), resp.Databases[0].TotalEntries)
+	assert.Equal(t, uint64(1), resp.Databases[0].CompletedScans)
+	assert.NotNil(t, resp.Databases[0].LastVerifiedRoot)
+	assert.NotZero(t, resp.Databases[0].LastRun)
+
+	resp, err = s.CorruptionCheckerStatus(ctx, &schema.CorruptionCheckerStatusRequest{})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Databases, 2)
+	_, err = s.CorruptionCheckerStatus(ctx, &schema.CorruptionCheckerStatusRequest{Databasename: "nodb"})
+	assert.Error(t, err)
+}
+
 type mockLogger struct{}
 
 func (l *mockLogger) Errorf(f string, v ...interface{}) {}
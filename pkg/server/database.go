@@ -31,9 +31,15 @@ import (
 
 //Db database instance
 type Db struct {
-	Store   *store.Store
-	Logger  logger.Logger
-	options *DbOptions
+	Store    *store.Store
+	Logger   logger.Logger
+	options  *DbOptions
+	tamper   tamperState
+	settings dbSettingsState
+	ccState  corruptionCheckState
+	limiter  writeLimiter
+	usage    dbUsage
+	writes   uint64
 }
 
 // OpenDb Opens an existing Database from disk
@@ -48,7 +54,20 @@ func OpenDb(op *DbOptions, log logger.Logger) (*Db, error) {
 	if os.IsNotExist(dbErr) {
 		return nil, fmt.Errorf("Missing database directories")
 	}
-	db.Store, err = store.Open(store.DefaultOptions(dbDir, db.Logger))
+	settings, err := loadDbSettings(dbDir)
+	if err != nil {
+		return nil, err
+	}
+	op.settings = settings
+	db.settings.settings = settings
+	//a tampered database must not become available because its tamper state can not be read
+	if db.tamper.state, err = loadTamperState(dbDir); err != nil {
+		return nil, err
+	}
+	if db.ccState.progress, err = loadCorruptionCheckProgress(dbDir); err != nil {
+		db.Logger.Warningf("Consistency check of database %s will restart: %s", op.GetDbName(), err)
+	}
+	db.Store, err = store.Open(settings.storeOptions(dbDir, db.Logger))
 	if err != nil {
 		db.Logger.Errorf("Unable to open store: %s", err)
 		return nil, err
@@ -63,9 +82,10 @@ func NewDb(op *DbOptions, log logger.Logger) (*Db, error) {
 		Logger:  log,
 		options: op,
 	}
+	db.settings.settings = op.GetSettings()
 	if op.GetInMemoryStore() {
 		db.Logger.Infof("Starting with in memory store")
-		storeOpts, badgerOpts := store.DefaultOptions("", db.Logger)
+		storeOpts, badgerOpts := op.GetSettings().storeOptions("", db.Logger)
 		badgerOpts = badgerOpts.WithInMemory(true)
 		db.Store, err = store.Open(storeOpts, badgerOpts)
 		if err != nil {
@@ -81,7 +101,11 @@ func NewDb(op *DbOptions, log logger.Logger) (*Db, error) {
 			db.Logger.Errorf("Unable to create data folder: %s", err)
 			return nil, err
 		}
-		db.Store, err = store.Open(store.DefaultOptions(dbDir, db.Logger))
+		if err = op.GetSettings().save(dbDir); err != nil {
+			db.Logger.Errorf("Unable to save database settings: %s", err)
+			return nil, err
+		}
+		db.Store, err = store.Open(op.GetSettings().storeOptions(dbDir, db.Logger))
 		if err != nil {
 			db.Logger.Errorf("Unable to open store: %s", err)
 			return nil, err
@@ -92,6 +116,9 @@ func NewDb(op *DbOptions, log logger.Logger) (*Db, error) {
 
 //Set ...
 func (d *Db) Set(kv *schema.KeyValue) (*schema.Index, error) {
+	if err := d.checkWritable(); err != nil {
+		return nil, err
+	}
 	return d.Store.Set(*kv)
 }
 
@@ -135,6 +162,9 @@ func (d *Db) GetSV(k *schema.Key) (*schema.StructuredItem, error) {
 
 //SafeSet ...
 func (d *Db) SafeSet(opts *schema.SafeSetOptions) (*schema.Proof, error) {
+	if err := d.checkWritable(); err != nil {
+		return nil, err
+	}
 	return d.Store.SafeSet(*opts)
 }
 
@@ -168,6 +198,9 @@ func (d *Db) SafeGetSV(opts *schema.SafeGetOptions) (*schema.SafeStructuredItem,
 
 // SetBatch ...
 func (d *Db) SetBatch(kvl *schema.KVList) (*schema.Index, error) {
+	if err := d.checkWritable(); err != nil {
+		return nil, err
+	}
 	return d.Store.SetBatch(*kvl)
 }
 
@@ -280,6 +313,9 @@ func (d *Db) Health(*empty.Empty) (*schema.HealthResponse, error) {
 
 //Reference ...
 func (d *Db) Reference(refOpts *schema.ReferenceOptions) (index *schema.Index, err error) {
+	if err := d.checkWritable(); err != nil {
+		return nil, err
+	}
 	index, err = d.Store.Reference(refOpts)
 	if err != nil {
 		return nil, err
@@ -290,11 +326,17 @@ func (d *Db) Reference(refOpts *schema.ReferenceOptions) (index *schema.Index, e
 
 //SafeReference ...
 func (d *Db) SafeReference(safeRefOpts *schema.SafeReferenceOptions) (proof *schema.Proof, err error) {
+	if err := d.checkWritable(); err != nil {
+		return nil, err
+	}
 	return d.Store.SafeReference(*safeRefOpts)
 }
 
 //ZAdd ...
 func (d *Db) ZAdd(opts *schema.ZAddOptions) (*schema.Index, error) {
+	if err := d.checkWritable(); err != nil {
+		return nil, err
+	}
 	return d.Store.ZAdd(*opts)
 }
 
@@ -314,6 +356,9 @@ func (d *Db) ZScanSV(opts *schema.ZScanOptions) (*schema.StructuredItemList, err
 
 //SafeZAdd ...
 func (d *Db) SafeZAdd(opts *schema.SafeZAddOptions) (*schema.Proof, error) {
+	if err := d.checkWritable(); err != nil {
+		return nil, err
+	}
 	return d.Store.SafeZAdd(*opts)
 }
 
@@ -36,6 +36,16 @@ func (d *databaseList) Append(database *Db) {
 	defer d.Unlock()
 	d.databases = append(d.databases, database)
 }
+
+// Replace swaps the database at index. A nil database leaves an empty slot, so that
+// the indexes of the other databases, which are embedded in tokens, do not change
+func (d *databaseList) Replace(index int64, database *Db) {
+	d.Lock()
+	defer d.Unlock()
+	d.databases[index] = database
+}
+
+// GetByIndex returns the database at index or nil if it has been unloaded
 func (d *databaseList) GetByIndex(index int64) *Db {
 	d.RLock()
 	defer d.RUnlock()
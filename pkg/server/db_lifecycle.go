@@ -0,0 +1,473 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/fs"
+	"github.com/codenotary/immudb/pkg/store/sysstore"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// archiveDirName directory inside the data dir where dropped databases are archived
+const archiveDirName = ".archive"
+
+// ArchiveDir returns the directory inside dataDir where dropped databases and the ones replaced by a restore are kept
+func ArchiveDir(dataDir string) string {
+	return filepath.Join(dataDir, archiveDirName)
+}
+
+// unloadedMarker file which keeps an unloaded database from being loaded at startup
+const unloadedMarker = ".unloaded"
+
+// ErrDatabaseNotLoaded is returned to the requests using a database which has been unloaded or dropped meanwhile
+const ErrDatabaseNotLoaded = "the selected database is no longer loaded, please select a database again"
+
+// dbUsage keeps the store of a database from being closed or replaced while requests are using it
+type dbUsage struct {
+	closed bool
+	sync.RWMutex
+}
+
+// acquire keeps the store of the database open and in place until release is called.
+// It fails if the database has been closed meanwhile
+func (d *Db) acquire() error {
+	d.usage.RLock()
+	if d.usage.closed {
+		d.usage.RUnlock()
+		return status.Error(codes.FailedPrecondition, ErrDatabaseNotLoaded)
+	}
+	return nil
+}
+
+// release ends a use of the database started with acquire
+func (d *Db) release() {
+	d.usage.RUnlock()
+}
+
+// drain waits for the requests using the database to complete and holds the new ones until undrain is called,
+// so that the store can be replaced
+func (d *Db) drain() {
+	d.usage.Lock()
+}
+
+// undrain lets the requests held by drain go on
+func (d *Db) undrain() {
+	d.usage.Unlock()
+}
+
+// close closes the store once the requests using it have completed, the requests which follow fail
+func (d *Db) close() error {
+	d.usage.Lock()
+	defer d.usage.Unlock()
+	if d.usage.closed {
+		return nil
+	}
+	d.usage.closed = true
+	return d.Store.Close()
+}
+
+// acquireDb returns the database at index, acquired, or an error if it is not loaded anymore
+func (s *ImmuServer) acquireDb(ind int64) (*Db, error) {
+	db := s.dbList.GetByIndex(ind)
+	if db == nil {
+		return nil, status.Error(codes.FailedPrecondition, ErrDatabaseNotLoaded)
+	}
+	if err := db.acquire(); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// getDbIndexByName returns the index of a loaded database
+func (s *ImmuServer) getDbIndexByName(name string) (int64, bool) {
+	s.dbNamesLock.RLock()
+	defer s.dbNamesLock.RUnlock()
+	ind, ok := s.databasenameToIndex[name]
+	return ind, ok
+}
+
+// addDatabase appends the database to the list and associates its name to its index
+func (s *ImmuServer) addDatabase(name string, db *Db) {
+	s.dbNamesLock.Lock()
+	defer s.dbNamesLock.Unlock()
+	s.databasenameToIndex[name] = int64(s.dbList.Length())
+	s.dbList.Append(db)
+}
+
+// setDbName associates the name to the database at index
+func (s *ImmuServer) setDbName(name string, ind int64) {
+	s.dbNamesLock.Lock()
+	defer s.dbNamesLock.Unlock()
+	s.databasenameToIndex[name] = ind
+}
+
+// The operations which load, unload, rename, drop or reconfigure databases are serialized by dbManageLock.
+// They hold dbNamesLock only while updating the names, never while waiting for the requests using a database
+// to complete: those requests may need to look a name up.
+
+// requireSysAdmin returns an error unless the caller is the system admin or the server is in maintenance mode
+func (s *ImmuServer) requireSysAdmin(ctx context.Context) error {
+	if s.Options.GetMaintenance() {
+		return nil
+	}
+	if !s.Options.GetAuth() {
+		return fmt.Errorf("this command is available only with authentication on")
+	}
+	_, user, err := s.getLoggedInUserdataFromCtx(ctx)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "please login first")
+	}
+	if !user.IsSysAdmin {
+		return fmt.Errorf("Logged In user does not have permissions for this operation")
+	}
+	return nil
+}
+
+// checkManageableDb returns an error for the databases which can not be unloaded, renamed or dropped
+func (s *ImmuServer) checkManageableDb(name string) error {
+	if name == s.Options.GetSystemAdminDbName() || name == s.Options.GetDefaultDbName() {
+		return fmt.Errorf("database %s is reserved and can not be managed", name)
+	}
+	return IsAllowedDbName(name)
+}
+
+// UnloadDatabase closes a database, which stays on disk and is not loaded anymore at startup
+func (s *ImmuServer) UnloadDatabase(ctx context.Context, r *schema.Database) (*empty.Empty, error) {
+	s.Logger.Debugf("UnloadDatabase %+v", *r)
+	if err := s.requireSysAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.checkManageableDb(r.Databasename); err != nil {
+		return nil, err
+	}
+	if s.Options.GetInMemoryStore() {
+		return nil, fmt.Errorf("databases can not be unloaded when using the in memory store")
+	}
+	s.dbManageLock.Lock()
+	defer s.dbManageLock.Unlock()
+	if err := s.unloadDatabase(r.Databasename); err != nil {
+		return nil, err
+	}
+	s.Logger.Infof("database %s unloaded", r.Databasename)
+	return new(empty.Empty), nil
+}
+
+// unloadDatabase closes the database and frees its slot, must be called holding dbManageLock
+func (s *ImmuServer) unloadDatabase(name string) error {
+	db, err := s.detachDatabase(name)
+	if err != nil {
+		return err
+	}
+	//the store is closed as soon as the requests already using it complete
+	if err = db.close(); err != nil {
+		s.Logger.Errorf("error closing database %s: %v", name, err)
+	}
+	return nil
+}
+
+// detachDatabase marks the database as unloaded and frees its name and its slot,
+// so that no new request picks it. It returns the database, still open
+func (s *ImmuServer) detachDatabase(name string) (*Db, error) {
+	s.dbNamesLock.Lock()
+	defer s.dbNamesLock.Unlock()
+	ind, ok := s.databasenameToIndex[name]
+	if !ok {
+		return nil, fmt.Errorf("database %s is not loaded", name)
+	}
+	if !s.Options.GetInMemoryStore() {
+		marker := filepath.Join(s.Options.Dir, name, unloadedMarker)
+		if err := ioutil.WriteFile(marker, []byte(time.Now().Format(time.RFC3339)), 0644); err != nil {
+			return nil, err
+		}
+	}
+	db := s.dbList.GetByIndex(ind)
+	delete(s.databasenameToIndex, name)
+	s.dbList.Replace(ind, nil)
+	return db, nil
+}
+
+// LoadDatabase opens a database which was unloaded or copied into the data dir
+func (s *ImmuServer) LoadDatabase(ctx context.Context, r *schema.Database) (*empty.Empty, error) {
+	s.Logger.Debugf("LoadDatabase %+v", *r)
+	if err := s.requireSysAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.checkManageableDb(r.Databasename); err != nil {
+		return nil, err
+	}
+	if s.Options.GetInMemoryStore() {
+		return nil, fmt.Errorf("databases can not be loaded when using the in memory store")
+	}
+	s.dbManageLock.Lock()
+	defer s.dbManageLock.Unlock()
+	if _, ok := s.getDbIndexByName(r.Databasename); ok {
+		return nil, fmt.Errorf("database %s is already loaded", r.Databasename)
+	}
+	op := DefaultOption().
+		WithDbName(r.Databasename).
+		WithCorruptionChecker(s.Options.CorruptionCheck).
+		WithDbRootPath(s.Options.Dir).
+		WithReadOnly(s.Options.GetReadOnly())
+	db, err := OpenDb(op, s.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("database %s can not be loaded: %v", r.Databasename, err)
+	}
+	marker := filepath.Join(s.Options.Dir, r.Databasename, unloadedMarker)
+	if err = os.Remove(marker); err != nil && !os.IsNotExist(err) {
+		db.Store.Close()
+		return nil, err
+	}
+	//a new index is used so that tokens issued for the previous instance are not reused
+	s.addDatabase(r.Databasename, db)
+	s.Logger.Infof("database %s loaded", r.Databasename)
+	return new(empty.Empty), nil
+}
+
+// RenameDatabase renames a loaded database along with the permissions and roles referring to it
+func (s *ImmuServer) RenameDatabase(ctx context.Context, r *schema.RenameDatabaseRequest) (*empty.Empty, error) {
+	s.Logger.Debugf("RenameDatabase %+v", *r)
+	if err := s.requireSysAdmin(ctx); err != nil {
+		return nil, err
+	}
+	newName := strings.ToLower(r.NewDatabasename)
+	if err := s.checkManageableDb(r.Databasename); err != nil {
+		return nil, err
+	}
+	if err := s.checkManageableDb(newName); err != nil {
+		return nil, err
+	}
+	s.dbManageLock.Lock()
+	defer s.dbManageLock.Unlock()
+	if _, ok := s.getDbIndexByName(newName); ok {
+		return nil, fmt.Errorf("database %s already exists", newName)
+	}
+	newDir := filepath.Join(s.Options.Dir, newName)
+	if !s.Options.GetInMemoryStore() {
+		if _, err := os.Stat(newDir); !os.IsNotExist(err) {
+			return nil, fmt.Errorf("database %s already exists", newName)
+		}
+	}
+	//the old name is freed before waiting for the requests using the database,
+	//it is associated again to the database if the rename fails
+	ind, err := s.takeDbName(r.Databasename)
+	if err != nil {
+		return nil, err
+	}
+	db := s.dbList.GetByIndex(ind)
+	if !s.Options.GetInMemoryStore() {
+		if err = s.moveDb(db, r.Databasename, newName); err != nil {
+			s.setDbName(r.Databasename, ind)
+			return nil, err
+		}
+	} else {
+		db.drain()
+		db.options.dbName = newName
+		db.undrain()
+	}
+	//the index does not change, so tokens selecting this database keep working
+	s.setDbName(newName, ind)
+	if err := s.renameDatabaseGrants(r.Databasename, newName); err != nil {
+		return nil, err
+	}
+	s.Logger.Infof("database %s renamed to %s", r.Databasename, newName)
+	return new(empty.Empty), nil
+}
+
+// takeDbName dissociates the name from the loaded database it refers to, returning its index
+func (s *ImmuServer) takeDbName(name string) (int64, error) {
+	s.dbNamesLock.Lock()
+	defer s.dbNamesLock.Unlock()
+	ind, ok := s.databasenameToIndex[name]
+	if !ok {
+		return 0, fmt.Errorf("database %s is not loaded", name)
+	}
+	delete(s.databasenameToIndex, name)
+	return ind, nil
+}
+
+// moveDb moves the store of db to the directory of newName, waiting for the requests using it.
+// If the directory can not be renamed, the store is reopened in place
+func (s *ImmuServer) moveDb(db *Db, oldName string, newName string) error {
+	db.drain()
+	defer db.undrain()
+	if err := db.Store.Close(); err != nil {
+		return err
+	}
+	op := DefaultOption().
+		WithDbName(newName).
+		WithCorruptionChecker(s.Options.CorruptionCheck).
+		WithDbRootPath(s.Options.Dir).
+		WithReadOnly(s.Options.GetReadOnly())
+	if err := os.Rename(filepath.Join(s.Options.Dir, oldName), filepath.Join(s.Options.Dir, newName)); err != nil {
+		//bring the database back under its previous name
+		s.reopenDb(db, op.WithDbName(oldName))
+		return fmt.Errorf("error renaming database %s: %v", oldName, err)
+	}
+	return s.reopenDb(db, op)
+}
+
+// reopenDb reopens the store of db in place, preserving its tamper state. It must be called holding db drained
+func (s *ImmuServer) reopenDb(db *Db, op *DbOptions) error {
+	reopened, err := OpenDb(op, s.Logger)
+	if err != nil {
+		s.Logger.Errorf("unable to reopen database %s: %v", op.GetDbName(), err)
+		//the requests which follow fail instead of using the closed store
+		db.usage.closed = true
+		return err
+	}
+	db.usage.closed = false
+	db.Store = reopened.Store
+	db.options = op
+	return nil
+}
+
+// DropDatabase archives a database into the data dir and removes it along with the permissions and roles referring to it
+func (s *ImmuServer) DropDatabase(ctx context.Context, r *schema.Database) (*schema.DropDatabaseReply, error) {
+	s.Logger.Debugf("DropDatabase %+v", *r)
+	if err := s.requireSysAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.checkManageableDb(r.Databasename); err != nil {
+		return nil, err
+	}
+	s.dbManageLock.Lock()
+	defer s.dbManageLock.Unlock()
+	dbDir := filepath.Join(s.Options.Dir, r.Databasename)
+	if _, ok := s.getDbIndexByName(r.Databasename); ok {
+		if err := s.unloadDatabase(r.Databasename); err != nil {
+			return nil, err
+		}
+	} else if _, err := os.Stat(dbDir); s.Options.GetInMemoryStore() || os.IsNotExist(err) {
+		return nil, fmt.Errorf("database %s does not exist", r.Databasename)
+	}
+	reply := &schema.DropDatabaseReply{}
+	if !s.Options.GetInMemoryStore() {
+		archiveDir := ArchiveDir(s.Options.Dir)
+		if err := os.MkdirAll(archiveDir, os.ModePerm); err != nil {
+			return nil, err
+		}
+		reply.Archive = filepath.Join(archiveDir, fmt.Sprintf("%s_%d.tar.gz", r.Databasename, time.Now().Unix()))
+		//the database stays unloaded on disk if archiving fails
+		if err := fs.TarIt(dbDir, reply.Archive); err != nil {
+			return nil, fmt.Errorf("error archiving database %s: %v", r.Databasename, err)
+		}
+		if err := os.RemoveAll(dbDir); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.renameDatabaseGrants(r.Databasename, ""); err != nil {
+		return nil, err
+	}
+	s.Logger.Infof("database %s dropped, archived to %s", r.Databasename, reply.Archive)
+	return reply, nil
+}
+
+// renameDatabaseGrants moves the user permissions and the role permissions from oldName to newName,
+// an empty newName removes them
+func (s *ImmuServer) renameDatabaseGrants(oldName string, newName string) error {
+	sysDb := s.dbList.GetByIndex(SystemDbIndex)
+	users, err := sysDb.Scan(&schema.ScanOptions{Prefix: []byte{sysstore.KeyPrefixUser}})
+	if err != nil {
+		return err
+	}
+	for _, item := range users.Items {
+		var user auth.User
+		if err = json.Unmarshal(item.Value, &user); err != nil {
+			return err
+		}
+		var changed bool
+		user.Permissions, changed = renamePermissions(user.Permissions, oldName, newName)
+		if !changed {
+			continue
+		}
+		if err = s.saveUser(&user); err != nil {
+			return err
+		}
+		s.removeUserFromLoginList(user.Username)
+	}
+	roles, err := sysDb.Scan(&schema.ScanOptions{Prefix: []byte{sysstore.KeyPrefixRole}})
+	if err != nil {
+		return err
+	}
+	for _, item := range roles.Items {
+		var role auth.Role
+		if err = json.Unmarshal(item.Value, &role); err != nil {
+			return err
+		}
+		var changed bool
+		role.Permissions, changed = renamePermissions(role.Permissions, oldName, newName)
+		if !changed {
+			continue
+		}
+		if err = s.saveRole(&role); err != nil {
+			return err
+		}
+		s.removeRoleHoldersFromLoginList(role.Name)
+	}
+	return nil
+}
+
+func renamePermissions(permissions []auth.Permission, oldName string, newName string) ([]auth.Permission, bool) {
+	var renamed []auth.Permission
+	changed := false
+	for _, val := range permissions {
+		if val.Database == oldName {
+			changed = true
+			if newName == "" {
+				continue
+			}
+			val.Database = newName
+		}
+		renamed = append(renamed, val)
+	}
+	return renamed, changed
+}
+
+// unloadedDatabases returns the databases found in the data dir which have been unloaded
+func (s *ImmuServer) unloadedDatabases() []*schema.Database {
+	if s.Options.GetInMemoryStore() {
+		return nil
+	}
+	entries, err := ioutil.ReadDir(s.Options.Dir)
+	if err != nil {
+		return nil
+	}
+	var unloaded []*schema.Database
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(s.Options.Dir, entry.Name(), unloadedMarker)); err == nil {
+			unloaded = append(unloaded, &schema.Database{Databasename: entry.Name(), Unloaded: true})
+		}
+	}
+	return unloaded
+}
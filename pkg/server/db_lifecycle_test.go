@@ -0,0 +1,256 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const lifecycleDir = "lifecycle_test_data"
+
+func newLifecycleServer() *ImmuServer {
+	s := DefaultServer()
+	s = s.WithOptions(s.Options.WithAuth(true).WithDir(lifecycleDir).WithCorruptionCheck(false))
+	if err := s.loadDefaultDatabase(lifecycleDir); err != nil {
+		log.Fatal(err)
+	}
+	if err := s.loadSystemDatabase(lifecycleDir); err != nil {
+		log.Fatal(err)
+	}
+	if err := s.loadUserDatabases(lifecycleDir); err != nil {
+		log.Fatal(err)
+	}
+	return s
+}
+
+func useDatabase(t *testing.T, s *ImmuServer, ctx context.Context, name string) context.Context {
+	reply, err := s.UseDatabase(ctx, &schema.Database{Databasename: name})
+	assert.Nil(t, err)
+	return newAuthorizedCtx(ctx, reply.Token)
+}
+
+func TestDatabaseLifecycle(t *testing.T) {
+	defer os.RemoveAll(lifecycleDir)
+	s := newLifecycleServer()
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+	_, err = s.CreateDatabase(ctx, &schema.Database{Databasename: "lifecycle"})
+	assert.Nil(t, err)
+	_, err = s.CreateUser(ctx, &schema.CreateUserRequest{
+		User:       []byte("lifecycleuser"),
+		Password:   []byte("Lifecycle1!"),
+		Permission: auth.PermissionRW,
+		Database:   "lifecycle",
+	})
+	assert.Nil(t, err)
+
+	dbCtx := useDatabase(t, s, ctx, "lifecycle")
+	kv := &schema.KeyValue{Key: testKey, Value: testValue}
+	_, err = s.Set(dbCtx, kv)
+	assert.Nil(t, err)
+
+	for _, reserved := range []string{SystemdbName, DefaultdbName} {
+		_, err = s.UnloadDatabase(ctx, &schema.Database{Databasename: reserved})
+		assert.Error(t, err)
+		_, err = s.DropDatabase(ctx, &schema.Database{Databasename: reserved})
+		assert.Error(t, err)
+	}
+
+	//unloading keeps the data and invalidates the selection held by tokens
+	_, err = s.UnloadDatabase(ctx, &schema.Database{Databasename: "lifecycle"})
+	assert.Nil(t, err)
+	_, err = s.Get(dbCtx, &schema.Key{Key: testKey})
+	assert.Error(t, err)
+	dbs, err := s.DatabaseList(ctx, &empty.Empty{})
+	assert.Nil(t, err)
+	assert.Contains(t, dbs.Databases, &schema.Database{Databasename: "lifecycle", Unloaded: true})
+
+	//unloaded databases are not loaded at startup
+	s.CloseDatabases()
+	s = newLifecycleServer()
+	_, ok := s.getDbIndexByName("lifecycle")
+	assert.False(t, ok)
+	ctx, err = loginSysAdmin(s)
+	assert.Nil(t, err)
+
+	_, err = s.LoadDatabase(ctx, &schema.Database{Databasename: "lifecycle"})
+	assert.Nil(t, err)
+	_, err = s.LoadDatabase(ctx, &schema.Database{Databasename: "lifecycle"})
+	assert.Error(t, err)
+	dbCtx = useDatabase(t, s, ctx, "lifecycle")
+	item, err := s.Get(dbCtx, &schema.Key{Key: testKey})
+	assert.Nil(t, err)
+	assert.Equal(t, testValue, item.Value)
+
+	//renaming keeps the index, so the selection held by tokens still works
+	_, err = s.RenameDatabase(ctx, &schema.RenameDatabaseRequest{Databasename: "lifecycle", NewDatabasename: DefaultdbName})
+	assert.Error(t, err)
+	_, err = s.RenameDatabase(ctx, &schema.RenameDatabaseRequest{Databasename: "lifecycle", NewDatabasename: "renamed"})
+	assert.Nil(t, err)
+	item, err = s.Get(dbCtx, &schema.Key{Key: testKey})
+	assert.Nil(t, err)
+	assert.Equal(t, testValue, item.Value)
+	_, ok = s.getDbIndexByName("lifecycle")
+	assert.False(t, ok)
+	user, err := s.getUser([]byte("lifecycleuser"), false)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(auth.PermissionRW), user.WhichPermission("renamed"))
+
+	reply, err := s.DropDatabase(ctx, &schema.Database{Databasename: "renamed"})
+	assert.Nil(t, err)
+	assert.FileExists(t, reply.Archive)
+	assert.Equal(t, filepath.Join(lifecycleDir, archiveDirName), filepath.Dir(reply.Archive))
+	_, err = os.Stat(filepath.Join(lifecycleDir, "renamed"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = s.Get(dbCtx, &schema.Key{Key: testKey})
+	assert.Error(t, err)
+	user, err = s.getUser([]byte("lifecycleuser"), false)
+	assert.Nil(t, err)
+	assert.Empty(t, user.Permissions)
+	_, err = s.DropDatabase(ctx, &schema.Database{Databasename: "renamed"})
+	assert.Error(t, err)
+
+	//the archive dir is not mistaken for a database
+	s.CloseDatabases()
+	s = newLifecycleServer()
+	assert.Equal(t, 2, s.dbList.Length())
+	s.CloseDatabases()
+}
+
+func TestDatabaseLifecycleRequiresSysAdmin(t *testing.T) {
+	s := newInmemoryAuthServer()
+	_, err := s.UnloadDatabase(context.Background(), &schema.Database{Databasename: "lifecycle"})
+	assert.Error(t, err)
+	_, err = s.DropDatabase(context.Background(), &schema.Database{Databasename: "lifecycle"})
+	assert.Error(t, err)
+}
+
+func TestUnloadDatabaseWhileReading(t *testing.T) {
+	defer os.RemoveAll(lifecycleDir)
+	s := newLifecycleServer()
+	defer s.CloseDatabases()
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+	_, err = s.CreateDatabase(ctx, &schema.Database{Databasename: "lifecycle"})
+	assert.Nil(t, err)
+	dbCtx := useDatabase(t, s, ctx, "lifecycle")
+	_, err = s.Set(dbCtx, &schema.KeyValue{Key: testKey, Value: testValue})
+	assert.Nil(t, err)
+
+	//a request in progress holds the unload until it completes
+	ind, ok := s.getDbIndexByName("lifecycle")
+	assert.True(t, ok)
+	db, err := s.acquireDb(ind)
+	assert.Nil(t, err)
+	unloaded := make(chan error)
+	go func() {
+		_, err := s.UnloadDatabase(ctx, &schema.Database{Databasename: "lifecycle"})
+		unloaded <- err
+	}()
+	select {
+	case <-unloaded:
+		t.Fatal("database unloaded while in use")
+	case <-time.After(100 * time.Millisecond):
+	}
+	_, err = db.Get(&schema.Key{Key: testKey})
+	assert.Nil(t, err)
+	db.release()
+	assert.Nil(t, <-unloaded)
+	_, err = s.acquireDb(ind)
+	assert.Error(t, err)
+	assert.Error(t, db.acquire())
+
+	//concurrent reads either complete or fail, they never use the closed store
+	_, err = s.LoadDatabase(ctx, &schema.Database{Databasename: "lifecycle"})
+	assert.Nil(t, err)
+	dbCtx = useDatabase(t, s, ctx, "lifecycle")
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				item, err := s.Get(dbCtx, &schema.Key{Key: testKey})
+				if err != nil {
+					assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+					return
+				}
+				assert.Equal(t, testValue, item.Value)
+			}
+		}()
+	}
+	_, err = s.UnloadDatabase(ctx, &schema.Database{Databasename: "lifecycle"})
+	assert.Nil(t, err)
+	wg.Wait()
+}
+
+func TestUnloadDatabaseDoesNotBlockLookups(t *testing.T) {
+	defer os.RemoveAll(lifecycleDir)
+	s := newLifecycleServer()
+	defer s.CloseDatabases()
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+	_, err = s.CreateDatabase(ctx, &schema.Database{Databasename: "lifecycle"})
+	assert.Nil(t, err)
+
+	ind, ok := s.getDbIndexByName("lifecycle")
+	assert.True(t, ok)
+	db, err := s.acquireDb(ind)
+	assert.Nil(t, err)
+	unloaded := make(chan error)
+	go func() {
+		_, err := s.UnloadDatabase(ctx, &schema.Database{Databasename: "lifecycle"})
+		unloaded <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	//the request holding the database, and every other one, can still look names up while the unload waits
+	looked := make(chan bool)
+	go func() {
+		_, ok := s.getDbIndexByName("lifecycle")
+		_, err := s.Health(ctx, &empty.Empty{})
+		looked <- !ok && err == nil
+	}()
+	select {
+	case ok := <-looked:
+		assert.True(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("name lookups blocked by the unload")
+	}
+	select {
+	case <-unloaded:
+		t.Fatal("database unloaded while in use")
+	default:
+	}
+	db.release()
+	assert.Nil(t, <-unloaded)
+	_, ok = s.getDbIndexByName("lifecycle")
+	assert.False(t, ok)
+}
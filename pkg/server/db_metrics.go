@@ -0,0 +1,72 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// databaseNameFromCtx returns the name of the database selected by the caller, or an empty string
+func (s *ImmuServer) databaseNameFromCtx(ctx context.Context) string {
+	ind := int64(DefaultDbIndex)
+	if s.Options.auth || s.multidbmode {
+		var err error
+		if ind, _, err = s.getLoggedInUserdataFromCtx(ctx); err != nil {
+			return ""
+		}
+	}
+	if ind < 0 || ind >= int64(s.dbList.Length()) {
+		return ""
+	}
+	db := s.dbList.GetByIndex(ind)
+	if db == nil {
+		return ""
+	}
+	return db.options.dbName
+}
+
+// observeRequest records the outcome and the duration of a call
+func (s *ImmuServer) observeRequest(database string, fullMethod string, start time.Time, err error) {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	Metrics.DatabaseRequestsCounters.WithLabelValues(database, method, status.Code(err).String()).Inc()
+	if !s.Options.NoHistograms {
+		Metrics.DatabaseRequestDurations.WithLabelValues(database, method).Observe(time.Since(start).Seconds())
+	}
+}
+
+// DatabaseMetricsUnaryInterceptor records the unary calls by database, method and result code
+func (s *ImmuServer) DatabaseMetricsUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	database := s.databaseNameFromCtx(ctx)
+	resp, err := handler(ctx, req)
+	s.observeRequest(database, info.FullMethod, start, err)
+	return resp, err
+}
+
+// DatabaseMetricsStreamInterceptor records the streams by database, method and result code
+func (s *ImmuServer) DatabaseMetricsStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	database := s.databaseNameFromCtx(ss.Context())
+	err := handler(srv, ss)
+	s.observeRequest(database, info.FullMethod, start, err)
+	return err
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDatabaseMetricsUnaryInterceptor(t *testing.T) {
+	s := newInmemoryAuthServer()
+	assert.Equal(t, "", s.databaseNameFromCtx(context.Background()))
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultdbName, s.databaseNameFromCtx(ctx))
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/immudb.schema.ImmuService/MetricsTest"}
+	ok := Metrics.DatabaseRequestsCounters.WithLabelValues(DefaultdbName, "MetricsTest", codes.OK.String())
+	notFound := Metrics.DatabaseRequestsCounters.WithLabelValues(DefaultdbName, "MetricsTest", codes.NotFound.String())
+	okBefore, notFoundBefore := testutil.ToFloat64(ok), testutil.ToFloat64(notFound)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+	_, err = s.DatabaseMetricsUnaryInterceptor(ctx, nil, info, handler)
+	assert.Nil(t, err)
+	failing := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	_, err = s.DatabaseMetricsUnaryInterceptor(ctx, nil, info, failing)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	assert.Equal(t, okBefore+1, testutil.ToFloat64(ok))
+	assert.Equal(t, notFoundBefore+1, testutil.ToFloat64(notFound))
+}
+
+func TestDatabaseUsageTreeStats(t *testing.T) {
+	s := newInmemoryAuthServer()
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+	_, err = s.Set(ctx, &schema.KeyValue{Key: testKey, Value: testValue})
+	assert.Nil(t, err)
+
+	usage := s.databaseUsage()
+	assert.Len(t, usage, 1)
+	//the entry is not flushed yet, it may still be waiting to be added to the tree
+	assert.Equal(t, uint64(1), usage[0].PendingTreeEntries)
+	assert.LessOrEqual(t, usage[0].TreeWidth, uint64(1))
+}
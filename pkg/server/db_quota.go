@@ -0,0 +1,154 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// quotas reported by the rejection metrics
+const (
+	quotaEntries         = "entries"
+	quotaBytes           = "bytes"
+	quotaWritesPerSecond = "writes_per_second"
+)
+
+// writeLimiter token bucket refilled at the allowed writes per second, bursts are bounded to one second worth of writes
+type writeLimiter struct {
+	tokens float64
+	last   time.Time
+	sync.Mutex
+}
+
+// allow takes n tokens, batches larger than the rate only need a full bucket
+func (l *writeLimiter) allow(n int, rate uint32, now time.Time) bool {
+	if rate == 0 {
+		return true
+	}
+	l.Lock()
+	defer l.Unlock()
+	if l.last.IsZero() {
+		l.tokens = float64(rate)
+	} else {
+		l.tokens += now.Sub(l.last).Seconds() * float64(rate)
+		if l.tokens > float64(rate) {
+			l.tokens = float64(rate)
+		}
+	}
+	l.last = now
+	cost := float64(n)
+	if cost > float64(rate) {
+		cost = float64(rate)
+	}
+	if l.tokens < cost {
+		return false
+	}
+	l.tokens -= cost
+	return true
+}
+
+// checkQuota returns a ResourceExhausted error if writing the given number of entries exceeds the quotas of the database.
+// Writes to a read-only database are refused before any quota is checked.
+// The size reported by badger is refreshed periodically, so the bytes quota can be slightly overrun.
+func (d *Db) checkQuota(entries int) error {
+	if err := d.checkWritable(); err != nil {
+		return err
+	}
+	settings := d.Settings()
+	if settings.MaxEntries > 0 && d.Store.EntriesCount()+uint64(entries) > settings.MaxEntries {
+		Metrics.QuotaRejectionsCounters.WithLabelValues(d.options.dbName, quotaEntries).Inc()
+		return status.Errorf(codes.ResourceExhausted, "database %s reached its quota of %d entries", d.options.dbName, settings.MaxEntries)
+	}
+	if settings.MaxBytes > 0 && d.size() >= settings.MaxBytes {
+		Metrics.QuotaRejectionsCounters.WithLabelValues(d.options.dbName, quotaBytes).Inc()
+		return status.Errorf(codes.ResourceExhausted, "database %s reached its quota of %d bytes", d.options.dbName, settings.MaxBytes)
+	}
+	if !d.limiter.allow(entries, settings.MaxWritesPerSecond, time.Now()) {
+		Metrics.QuotaRejectionsCounters.WithLabelValues(d.options.dbName, quotaWritesPerSecond).Inc()
+		return status.Errorf(codes.ResourceExhausted, "database %s exceeded its quota of %d writes per second", d.options.dbName, settings.MaxWritesPerSecond)
+	}
+	return nil
+}
+
+// countWrites adds the entries written successfully to the writes reported in the database usage
+func (d *Db) countWrites(entries int) {
+	atomic.AddUint64(&d.writes, uint64(entries))
+}
+
+// size returns the bytes used by the lsm tree and the value log
+func (d *Db) size() uint64 {
+	lsm, vlog := d.Store.DbSize()
+	return uint64(lsm + vlog)
+}
+
+// DatabaseUsage current usage, quotas and store statistics of a database
+type DatabaseUsage struct {
+	Name               string
+	Entries            uint64
+	Bytes              uint64
+	Writes             uint64
+	TreeWidth          uint64
+	PendingTreeEntries uint64
+	LsmBytes           uint64
+	VlogBytes          uint64
+	MaxEntries         uint64
+	MaxBytes           uint64
+	MaxWritesPerSecond uint32
+}
+
+// databaseUsage returns the usage of the loaded databases, the system database excluded
+func (s *ImmuServer) databaseUsage() []DatabaseUsage {
+	var usage []DatabaseUsage
+	for i := 0; i < s.dbList.Length(); i++ {
+		db := s.dbList.GetByIndex(int64(i))
+		if db == nil || db.options.dbName == s.Options.GetSystemAdminDbName() {
+			continue
+		}
+		if db.acquire() != nil {
+			//unloaded meanwhile
+			continue
+		}
+		usage = append(usage, db.usageStats())
+		db.release()
+	}
+	return usage
+}
+
+// usageStats returns the usage of an acquired database
+func (d *Db) usageStats() DatabaseUsage {
+	settings := d.Settings()
+	lsm, vlog := d.Store.DbSize()
+	width, pending := d.Store.TreeStats()
+	return DatabaseUsage{
+		Name:               d.options.dbName,
+		Entries:            d.Store.EntriesCount(),
+		Bytes:              uint64(lsm + vlog),
+		Writes:             atomic.LoadUint64(&d.writes),
+		TreeWidth:          width,
+		PendingTreeEntries: pending,
+		LsmBytes:           uint64(lsm),
+		VlogBytes:          uint64(vlog),
+		MaxEntries:         settings.MaxEntries,
+		MaxBytes:           settings.MaxBytes,
+		MaxWritesPerSecond: settings.MaxWritesPerSecond,
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWriteLimiter(t *testing.T) {
+	var l writeLimiter
+	now := time.Now()
+	assert.True(t, l.allow(100, 0, now))
+
+	assert.True(t, l.allow(2, 2, now))
+	assert.False(t, l.allow(1, 2, now))
+	assert.True(t, l.allow(1, 2, now.Add(500*time.Millisecond)))
+	assert.False(t, l.allow(1, 2, now.Add(500*time.Millisecond)))
+
+	//batches larger than the rate need a full bucket
+	assert.False(t, l.allow(5, 2, now.Add(time.Second)))
+	assert.True(t, l.allow(5, 2, now.Add(2*time.Second)))
+}
+
+func TestDatabaseQuotas(t *testing.T) {
+	s := newInmemoryAuthServer()
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+	_, err = s.UpdateDatabaseSettings(ctx, &schema.UpdateDatabaseSettingsRequest{
+		Databasename: DefaultdbName,
+		Settings:     &schema.DatabaseSettings{MaxEntries: 3},
+	})
+	assert.Nil(t, err)
+
+	kv := &schema.KeyValue{Key: testKey, Value: testValue}
+	_, err = s.Set(ctx, kv)
+	assert.Nil(t, err)
+	_, err = s.SetBatch(ctx, &schema.KVList{KVs: []*schema.KeyValue{kv, kv, kv}})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	_, err = s.SetBatch(ctx, &schema.KVList{KVs: []*schema.KeyValue{kv, kv}})
+	assert.Nil(t, err)
+	_, err = s.ZAdd(ctx, &schema.ZAddOptions{Set: []byte("set"), Key: testKey})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	usage := s.databaseUsage()
+	assert.Len(t, usage, 1)
+	assert.Equal(t, DefaultdbName, usage[0].Name)
+	assert.Equal(t, uint64(3), usage[0].Entries)
+	assert.Equal(t, uint64(3), usage[0].Writes)
+	assert.Equal(t, uint64(3), usage[0].MaxEntries)
+
+	_, err = s.UpdateDatabaseSettings(ctx, &schema.UpdateDatabaseSettingsRequest{
+		Databasename: DefaultdbName,
+		Settings:     &schema.DatabaseSettings{MaxWritesPerSecond: 1},
+	})
+	assert.Nil(t, err)
+	_, err = s.Set(ctx, kv)
+	assert.Nil(t, err)
+	_, err = s.Set(ctx, kv)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	//writes to a read-only database are refused before checking the quotas, and they are not counted
+	_, err = s.UpdateDatabaseSettings(ctx, &schema.UpdateDatabaseSettingsRequest{
+		Databasename: DefaultdbName,
+		Settings:     &schema.DatabaseSettings{MaxEntries: 1, ReadOnly: true},
+	})
+	assert.Nil(t, err)
+	_, err = s.Set(ctx, kv)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = s.SafeZAdd(ctx, &schema.SafeZAddOptions{Zopts: &schema.ZAddOptions{Set: []byte("set"), Key: testKey}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, uint64(4), s.databaseUsage()[0].Writes)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrDatabaseReadOnly happens when writing to a database which has been frozen or to a server started in read-only mode
+const ErrDatabaseReadOnly = "database %s is read-only"
+
+// IsReadOnly returns true if the database does not accept writes
+func (d *Db) IsReadOnly() bool {
+	return d.options.GetReadOnly() || d.Settings().ReadOnly
+}
+
+// checkWritable returns an error if the database does not accept writes
+func (d *Db) checkWritable() error {
+	if d.IsReadOnly() {
+		return status.Errorf(codes.FailedPrecondition, ErrDatabaseReadOnly, d.options.dbName)
+	}
+	return nil
+}
+
+// SetDatabaseReadOnly freezes a database, or makes it writable again. The state is persisted in the database settings
+func (s *ImmuServer) SetDatabaseReadOnly(ctx context.Context, r *schema.SetDatabaseReadOnlyRequest) (*empty.Empty, error) {
+	s.Logger.Debugf("SetDatabaseReadOnly %+v", *r)
+	if err := s.requireSysAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if r.Databasename == s.Options.GetSystemAdminDbName() {
+		return nil, fmt.Errorf("database %s can not be made read-only", r.Databasename)
+	}
+	if !r.ReadOnly && s.Options.GetReadOnly() {
+		return nil, fmt.Errorf("the server has been started in read-only mode")
+	}
+	s.dbManageLock.Lock()
+	defer s.dbManageLock.Unlock()
+	ind, ok := s.getDbIndexByName(r.Databasename)
+	if !ok {
+		return nil, fmt.Errorf("database %s is not loaded", r.Databasename)
+	}
+	db := s.dbList.GetByIndex(ind)
+	settings := db.Settings()
+	settings.ReadOnly = r.ReadOnly
+	if !s.Options.GetInMemoryStore() {
+		if err := settings.save(filepath.Join(s.Options.Dir, r.Databasename)); err != nil {
+			return nil, err
+		}
+	}
+	db.setSettings(settings)
+	if r.ReadOnly {
+		s.Logger.Infof("database %s is now read-only", r.Databasename)
+	} else {
+		s.Logger.Infof("database %s is now writable", r.Databasename)
+	}
+	return new(empty.Empty), nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func assertWritesRefused(t *testing.T, s *ImmuServer, ctx context.Context) {
+	kv := &schema.KeyValue{Key: testKey, Value: testValue}
+	_, err := s.Set(ctx, kv)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = s.SafeSet(ctx, &schema.SafeSetOptions{Kv: kv})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = s.SetBatch(ctx, &schema.KVList{KVs: []*schema.KeyValue{kv}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = s.Reference(ctx, &schema.ReferenceOptions{Reference: []byte("ref"), Key: testKey})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = s.ZAdd(ctx, &schema.ZAddOptions{Set: []byte("set"), Key: testKey})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = s.SafeZAdd(ctx, &schema.SafeZAddOptions{Zopts: &schema.ZAddOptions{Set: []byte("set"), Key: testKey}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestSetDatabaseReadOnly(t *testing.T) {
+	s := newInmemoryAuthServer()
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+	kv := &schema.KeyValue{Key: testKey, Value: testValue}
+	index, err := s.Set(ctx, kv)
+	assert.Nil(t, err)
+
+	_, err = s.SetDatabaseReadOnly(ctx, &schema.SetDatabaseReadOnlyRequest{Databasename: SystemdbName, ReadOnly: true})
+	assert.Error(t, err)
+	_, err = s.SetDatabaseReadOnly(ctx, &schema.SetDatabaseReadOnlyRequest{Databasename: DefaultdbName, ReadOnly: true})
+	assert.Nil(t, err)
+	assertWritesRefused(t, s, ctx)
+
+	//reads and proofs keep working
+	item, err := s.Get(ctx, &schema.Key{Key: testKey})
+	assert.Nil(t, err)
+	assert.Equal(t, testValue, item.Value)
+	_, err = s.SafeGet(ctx, &schema.SafeGetOptions{Key: testKey})
+	assert.Nil(t, err)
+	_, err = s.Inclusion(ctx, index)
+	assert.Nil(t, err)
+
+	dbs, err := s.DatabaseList(ctx, &empty.Empty{})
+	assert.Nil(t, err)
+	for _, db := range dbs.Databases {
+		assert.Equal(t, db.Databasename == DefaultdbName, db.ReadOnly)
+	}
+
+	_, err = s.SetDatabaseReadOnly(ctx, &schema.SetDatabaseReadOnlyRequest{Databasename: DefaultdbName})
+	assert.Nil(t, err)
+	_, err = s.Set(ctx, kv)
+	assert.Nil(t, err)
+}
+
+func TestServerReadOnly(t *testing.T) {
+	s := DefaultServer()
+	s = s.WithOptions(s.Options.WithAuth(true).WithInMemoryStore(true).WithReadOnly(true))
+	dbRootpath := DefaultOption().GetDbRootPath()
+	assert.Nil(t, s.loadDefaultDatabase(dbRootpath))
+	assert.Nil(t, s.loadSystemDatabase(dbRootpath))
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+
+	assertWritesRefused(t, s, ctx)
+	_, err = s.SetDatabaseReadOnly(ctx, &schema.SetDatabaseReadOnlyRequest{Databasename: DefaultdbName})
+	assert.Error(t, err)
+
+	//user management keeps working
+	_, err = s.CreateUser(ctx, &schema.CreateUserRequest{
+		User:       []byte("readonlyuser"),
+		Password:   []byte("Readonly1!"),
+		Permission: auth.PermissionR,
+		Database:   DefaultdbName,
+	})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/codenotary/immudb/pkg/store"
+	"github.com/dgraph-io/badger/v2"
+	"github.com/dgraph-io/badger/v2/options"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+// settingsFileName file inside the database directory where its settings are persisted
+const settingsFileName = "settings.json"
+
+// maxTreeCacheSize upper bound of the tree cache, which is allocated upfront
+const maxTreeCacheSize = 10_000_000
+
+// supported compression algorithms
+const (
+	CompressionNone   = "none"
+	CompressionSnappy = "snappy"
+	CompressionZSTD   = "zstd"
+)
+
+// DbSettings settings chosen when a database is created, zero values mean the server defaults
+type DbSettings struct {
+	TreeCacheSize           uint64        `json:"treeCacheSize,omitempty"`
+	SyncWrites              bool          `json:"syncWrites"`
+	Compression             string        `json:"compression,omitempty"`
+	CorruptionCheckInterval time.Duration `json:"corruptionCheckInterval,omitempty"`
+	ReadOnly                bool          `json:"readOnly"`
+	MaxEntries              uint64        `json:"maxEntries,omitempty"`
+	MaxBytes                uint64        `json:"maxBytes,omitempty"`
+	MaxWritesPerSecond      uint32        `json:"maxWritesPerSecond,omitempty"`
+}
+
+// dbSettingsFromProto validates the settings received in a request
+func dbSettingsFromProto(s *schema.DatabaseSettings) (DbSettings, error) {
+	if s == nil {
+		return DbSettings{}, nil
+	}
+	settings := DbSettings{
+		TreeCacheSize:           s.TreeCacheSize,
+		SyncWrites:              s.SyncWrites,
+		Compression:             s.Compression,
+		CorruptionCheckInterval: time.Duration(s.CorruptionCheckInterval) * time.Second,
+		ReadOnly:                s.ReadOnly,
+		MaxEntries:              s.MaxEntries,
+		MaxBytes:                s.MaxBytes,
+		MaxWritesPerSecond:      s.MaxWritesPerSecond,
+	}
+	if settings.TreeCacheSize > maxTreeCacheSize {
+		return DbSettings{}, fmt.Errorf("tree cache size can not exceed %d", maxTreeCacheSize)
+	}
+	switch settings.Compression {
+	case "", CompressionNone, CompressionSnappy, CompressionZSTD:
+	default:
+		return DbSettings{}, fmt.Errorf("unknown compression %s, supported are %s, %s and %s",
+			settings.Compression, CompressionNone, CompressionSnappy, CompressionZSTD)
+	}
+	return settings, nil
+}
+
+// toProto returns the settings as sent to clients
+func (s DbSettings) toProto() *schema.DatabaseSettings {
+	return &schema.DatabaseSettings{
+		TreeCacheSize:           s.TreeCacheSize,
+		SyncWrites:              s.SyncWrites,
+		Compression:             s.Compression,
+		CorruptionCheckInterval: uint32(s.CorruptionCheckInterval / time.Second),
+		ReadOnly:                s.ReadOnly,
+		MaxEntries:              s.MaxEntries,
+		MaxBytes:                s.MaxBytes,
+		MaxWritesPerSecond:      s.MaxWritesPerSecond,
+	}
+}
+
+// storeOptions returns the store options of a database residing in dir
+func (s DbSettings) storeOptions(dir string, log logger.Logger) (store.Options, badger.Options) {
+	storeOpts, badgerOpts := store.DefaultOptions(dir, log)
+	if s.TreeCacheSize > 0 {
+		storeOpts = storeOpts.WithTreeCacheSize(s.TreeCacheSize)
+	}
+	badgerOpts = badgerOpts.WithSyncWrites(s.SyncWrites)
+	switch s.Compression {
+	case CompressionSnappy:
+		badgerOpts = badgerOpts.WithCompression(options.Snappy)
+	case CompressionZSTD:
+		badgerOpts = badgerOpts.WithCompression(options.ZSTD)
+	default:
+		badgerOpts = badgerOpts.WithCompression(options.None)
+	}
+	return storeOpts, badgerOpts
+}
+
+// sameStore returns true if the store does not need to be reopened to switch to the other settings
+func (s DbSettings) sameStore(other DbSettings) bool {
+	return s.TreeCacheSize == other.TreeCacheSize &&
+		s.SyncWrites == other.SyncWrites &&
+		s.Compression == other.Compression
+}
+
+// loadDbSettings reads the settings of the database in dbDir, databases created without settings get the defaults
+func loadDbSettings(dbDir string) (DbSettings, error) {
+	var settings DbSettings
+	data, err := ioutil.ReadFile(filepath.Join(dbDir, settingsFileName))
+	if os.IsNotExist(err) {
+		return settings, nil
+	}
+	if err != nil {
+		return settings, err
+	}
+	if err = json.Unmarshal(data, &settings); err != nil {
+		return settings, fmt.Errorf("corrupted settings of database in %s: %v", dbDir, err)
+	}
+	return settings, nil
+}
+
+// save writes the settings of the database in dbDir
+func (s DbSettings) save(dbDir string) error {
+	return writeJSONFile(dbDir, settingsFileName, s)
+}
+
+// dbSettingsState settings in use by a database, they can change while the database is serving requests
+type dbSettingsState struct {
+	settings            DbSettings
+	lastCorruptionCheck time.Time
+	sync.RWMutex
+}
+
+// Settings returns the settings in use
+func (d *Db) Settings() DbSettings {
+	d.settings.RLock()
+	defer d.settings.RUnlock()
+	return d.settings.settings
+}
+
+func (d *Db) setSettings(settings DbSettings) {
+	d.settings.Lock()
+	defer d.settings.Unlock()
+	d.settings.settings = settings
+}
+
+// corruptionCheckDue returns true, and restarts the interval, if the database has to be checked for corruption
+func (d *Db) corruptionCheckDue(now time.Time) bool {
+	d.settings.Lock()
+	defer d.settings.Unlock()
+	interval := d.settings.settings.CorruptionCheckInterval
+	if interval > 0 && now.Sub(d.settings.lastCorruptionCheck) < interval {
+		return false
+	}
+	d.settings.lastCorruptionCheck = now
+	return true
+}
+
+// UpdateDatabaseSettings replaces the settings of a database, reopening its store when needed
+func (s *ImmuServer) UpdateDatabaseSettings(ctx context.Context, r *schema.UpdateDatabaseSettingsRequest) (*empty.Empty, error) {
+	s.Logger.Debugf("UpdateDatabaseSettings %+v", *r)
+	if err := s.requireSysAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if r.Databasename == s.Options.GetSystemAdminDbName() {
+		return nil, fmt.Errorf("settings of database %s can not be changed", r.Databasename)
+	}
+	settings, err := dbSettingsFromProto(r.Settings)
+	if err != nil {
+		return nil, err
+	}
+	s.dbManageLock.Lock()
+	defer s.dbManageLock.Unlock()
+	ind, ok := s.getDbIndexByName(r.Databasename)
+	if !ok {
+		return nil, fmt.Errorf("database %s is not loaded", r.Databasename)
+	}
+	db := s.dbList.GetByIndex(ind)
+	sameStore := db.Settings().sameStore(settings)
+	if s.Options.GetInMemoryStore() {
+		if !sameStore {
+			return nil, fmt.Errorf("store settings can not be changed when using the in memory store")
+		}
+		db.setSettings(settings)
+		return new(empty.Empty), nil
+	}
+	dbDir := filepath.Join(s.Options.Dir, r.Databasename)
+	if sameStore {
+		if err = settings.save(dbDir); err != nil {
+			return nil, err
+		}
+	} else if err = s.reopenDbWithSettings(db, settings); err != nil {
+		return nil, err
+	}
+	db.setSettings(settings)
+	s.Logger.Infof("settings of database %s updated", r.Databasename)
+	return new(empty.Empty), nil
+}
+
+// reopenDbWithSettings switches the store of db to the new settings, waiting for the requests using it.
+// If the store can not be reopened with them, the old settings are restored and the old store is reopened
+func (s *ImmuServer) reopenDbWithSettings(db *Db, settings DbSettings) error {
+	dbDir := filepath.Join(s.Options.Dir, db.options.GetDbName())
+	old := db.Settings()
+	//the store is opened with the settings persisted in its directory
+	if err := settings.save(dbDir); err != nil {
+		return err
+	}
+	db.drain()
+	defer db.undrain()
+	err := db.Store.Close()
+	if err == nil {
+		if err = s.reopenDb(db, db.options); err == nil {
+			return nil
+		}
+	}
+	if serr := old.save(dbDir); serr != nil {
+		return fmt.Errorf("%v, the previous settings of database %s could not be restored: %v", err, db.options.GetDbName(), serr)
+	}
+	if db.usage.closed {
+		if rerr := s.reopenDb(db, db.options); rerr != nil {
+			return fmt.Errorf("%v, database %s could not be reopened either: %v", err, db.options.GetDbName(), rerr)
+		}
+	}
+	return err
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDatabaseSettings(t *testing.T) {
+	defer os.RemoveAll(lifecycleDir)
+	s := newLifecycleServer()
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+
+	_, err = s.CreateDatabase(ctx, &schema.Database{
+		Databasename: "settings",
+		Settings:     &schema.DatabaseSettings{Compression: "lz4"},
+	})
+	assert.Error(t, err)
+	_, err = s.CreateDatabase(ctx, &schema.Database{
+		Databasename: "settings",
+		Settings:     &schema.DatabaseSettings{TreeCacheSize: maxTreeCacheSize + 1},
+	})
+	assert.Error(t, err)
+
+	created := &schema.DatabaseSettings{
+		TreeCacheSize:           1000,
+		SyncWrites:              true,
+		Compression:             CompressionZSTD,
+		CorruptionCheckInterval: 3600,
+	}
+	_, err = s.CreateDatabase(ctx, &schema.Database{Databasename: "settings", Settings: created})
+	assert.Nil(t, err)
+	assert.FileExists(t, filepath.Join(lifecycleDir, "settings", settingsFileName))
+
+	dbs, err := s.DatabaseList(ctx, &empty.Empty{})
+	assert.Nil(t, err)
+	for _, db := range dbs.Databases {
+		if db.Databasename == "settings" {
+			assert.Equal(t, created, db.Settings)
+		}
+	}
+
+	dbCtx := useDatabase(t, s, ctx, "settings")
+	kv := &schema.KeyValue{Key: testKey, Value: testValue}
+	_, err = s.Set(dbCtx, kv)
+	assert.Nil(t, err)
+
+	readOnly := *created
+	readOnly.ReadOnly = true
+	_, err = s.UpdateDatabaseSettings(ctx, &schema.UpdateDatabaseSettingsRequest{Databasename: "settings", Settings: &readOnly})
+	assert.Nil(t, err)
+	_, err = s.Set(dbCtx, kv)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = s.ZAdd(dbCtx, &schema.ZAddOptions{Set: []byte("set"), Key: testKey})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	item, err := s.Get(dbCtx, &schema.Key{Key: testKey})
+	assert.Nil(t, err)
+	assert.Equal(t, testValue, item.Value)
+
+	//store settings reopen the store, the data is still there
+	_, err = s.UpdateDatabaseSettings(ctx, &schema.UpdateDatabaseSettingsRequest{
+		Databasename: "settings",
+		Settings:     &schema.DatabaseSettings{Compression: CompressionSnappy},
+	})
+	assert.Nil(t, err)
+	_, err = s.Set(dbCtx, kv)
+	assert.Nil(t, err)
+	item, err = s.Get(dbCtx, &schema.Key{Key: testKey})
+	assert.Nil(t, err)
+	assert.Equal(t, testValue, item.Value)
+
+	_, err = s.UpdateDatabaseSettings(ctx, &schema.UpdateDatabaseSettingsRequest{Databasename: SystemdbName, Settings: &readOnly})
+	assert.Error(t, err)
+	_, err = s.UpdateDatabaseSettings(ctx, &schema.UpdateDatabaseSettingsRequest{Databasename: "missing", Settings: &readOnly})
+	assert.Error(t, err)
+
+	//settings are applied when the database is loaded again
+	s.CloseDatabases()
+	s = newLifecycleServer()
+	ind, ok := s.getDbIndexByName("settings")
+	assert.True(t, ok)
+	assert.Equal(t, DbSettings{Compression: CompressionSnappy}, s.dbList.GetByIndex(ind).Settings())
+	s.CloseDatabases()
+}
+
+func TestDatabaseSettingsRollback(t *testing.T) {
+	defer os.RemoveAll(lifecycleDir)
+	s := newLifecycleServer()
+	defer s.CloseDatabases()
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+	_, err = s.CreateDatabase(ctx, &schema.Database{Databasename: "rollback"})
+	assert.Nil(t, err)
+	dbCtx := useDatabase(t, s, ctx, "rollback")
+	_, err = s.Set(dbCtx, &schema.KeyValue{Key: testKey, Value: testValue})
+	assert.Nil(t, err)
+
+	//the new settings can not be persisted: the store keeps the old ones
+	settingsFile := filepath.Join(lifecycleDir, "rollback", settingsFileName)
+	assert.Nil(t, os.Remove(settingsFile))
+	assert.Nil(t, os.MkdirAll(filepath.Join(settingsFile, "blocker"), 0755))
+	_, err = s.UpdateDatabaseSettings(ctx, &schema.UpdateDatabaseSettingsRequest{
+		Databasename: "rollback",
+		Settings:     &schema.DatabaseSettings{Compression: CompressionSnappy},
+	})
+	assert.Error(t, err)
+	ind, ok := s.getDbIndexByName("rollback")
+	assert.True(t, ok)
+	assert.Equal(t, DbSettings{}, s.dbList.GetByIndex(ind).Settings())
+	item, err := s.Get(dbCtx, &schema.Key{Key: testKey})
+	assert.Nil(t, err)
+	assert.Equal(t, testValue, item.Value)
+}
+
+func TestDbSettingsCorruptionCheckInterval(t *testing.T) {
+	db := &Db{}
+	now := time.Now()
+	assert.True(t, db.corruptionCheckDue(now))
+	assert.True(t, db.corruptionCheckDue(now))
+
+	db.setSettings(DbSettings{CorruptionCheckInterval: time.Hour})
+	assert.True(t, db.corruptionCheckDue(now.Add(time.Hour)))
+	assert.False(t, db.corruptionCheckDue(now.Add(time.Hour+time.Minute)))
+	assert.True(t, db.corruptionCheckDue(now.Add(2*time.Hour)))
+}
+
+func TestDbSettingsInMemory(t *testing.T) {
+	s := newInmemoryAuthServer()
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+	_, err = s.UpdateDatabaseSettings(ctx, &schema.UpdateDatabaseSettingsRequest{
+		Databasename: DefaultdbName,
+		Settings:     &schema.DatabaseSettings{SyncWrites: true},
+	})
+	assert.Error(t, err)
+	_, err = s.UpdateDatabaseSettings(ctx, &schema.UpdateDatabaseSettingsRequest{
+		Databasename: DefaultdbName,
+		Settings:     &schema.DatabaseSettings{ReadOnly: true},
+	})
+	assert.Nil(t, err)
+	_, err = s.Set(ctx, &schema.KeyValue{Key: testKey, Value: testValue})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
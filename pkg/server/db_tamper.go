@@ -0,0 +1,190 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// tamperFileName file inside the database directory where its tamper state is persisted
+const tamperFileName = "tamper.json"
+
+// TamperState outcome of the consistency checks on a database, persisted in its directory
+type TamperState struct {
+	Tampered    bool   `json:"tampered"`
+	Index       uint64 `json:"index,omitempty"`
+	Quarantined bool   `json:"quarantined"`
+}
+
+// tamperState tracks the outcome of the consistency checks on a database
+type tamperState struct {
+	state TamperState
+	sync.RWMutex
+}
+
+// loadTamperState reads the tamper state of the database in dbDir
+func loadTamperState(dbDir string) (TamperState, error) {
+	var state TamperState
+	data, err := ioutil.ReadFile(filepath.Join(dbDir, tamperFileName))
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	if err = json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("corrupted tamper state of database in %s: %v", dbDir, err)
+	}
+	return state, nil
+}
+
+// setTamperState replaces the tamper state and persists it, in memory databases excluded.
+// The new state is in effect even if it can not be persisted
+func (d *Db) setTamperState(state TamperState) error {
+	d.tamper.Lock()
+	defer d.tamper.Unlock()
+	d.tamper.state = state
+	if d.options.GetInMemoryStore() {
+		return nil
+	}
+	return writeJSONFile(filepath.Join(d.options.GetDbRootPath(), d.options.GetDbName()), tamperFileName, state)
+}
+
+// SetTampered marks the database as tampered at the given insertion order index
+func (d *Db) SetTampered(index uint64) error {
+	state := d.getTamperState()
+	state.Tampered = true
+	state.Index = index
+	return d.setTamperState(state)
+}
+
+// Acknowledge clears the tamper state after a manual inspection, making the database available again
+func (d *Db) Acknowledge() error {
+	return d.setTamperState(TamperState{})
+}
+
+// Quarantine keeps the database unavailable until it is acknowledged
+func (d *Db) Quarantine() error {
+	state := d.getTamperState()
+	state.Quarantined = true
+	return d.setTamperState(state)
+}
+
+// IsQuarantined returns true if the database was quarantined by an admin
+func (d *Db) IsQuarantined() bool {
+	return d.getTamperState().Quarantined
+}
+
+func (d *Db) getTamperState() TamperState {
+	d.tamper.RLock()
+	defer d.tamper.RUnlock()
+	return d.tamper.state
+}
+
+// TamperStatus fills the tamper fields of the database description
+func (d *Db) TamperStatus() *schema.Database {
+	state := d.getTamperState()
+	return &schema.Database{
+		Databasename:  d.options.dbName,
+		Tampered:      state.Tampered,
+		TamperedIndex: state.Index,
+		Quarantined:   state.Quarantined,
+	}
+}
+
+// checkTampered returns a DataLoss error if the database is tampered or quarantined
+func (d *Db) checkTampered() error {
+	state := d.getTamperState()
+	if state.Quarantined {
+		return status.Errorf(
+			codes.DataLoss, "database %s has been quarantined after possible tampering", d.options.dbName)
+	}
+	if state.Tampered {
+		return status.Errorf(
+			codes.DataLoss,
+			"database %s should be checked manually as we detected possible tampering at index %d",
+			d.options.dbName, state.Index)
+	}
+	return nil
+}
+
+// ResolveTampering acknowledges or quarantines a tampered database after a manual inspection
+func (s *ImmuServer) ResolveTampering(ctx context.Context, r *schema.ResolveTamperingRequest) (*empty.Empty, error) {
+	s.Logger.Debugf("ResolveTampering %+v", *r)
+	if err := s.requireSysAdmin(ctx); err != nil {
+		return nil, err
+	}
+	ind, ok := s.getDbIndexByName(r.Databasename)
+	if !ok {
+		return nil, fmt.Errorf("%s does not exist", r.Databasename)
+	}
+	db := s.dbList.GetByIndex(ind)
+	if db == nil {
+		return nil, fmt.Errorf("database %s is not loaded", r.Databasename)
+	}
+	if r.Quarantine {
+		if err := db.Quarantine(); err != nil {
+			return nil, fmt.Errorf("unable to persist the quarantine of database %s: %v", r.Databasename, err)
+		}
+		s.Logger.Warningf("database %s has been quarantined", r.Databasename)
+	} else {
+		if err := db.Acknowledge(); err != nil {
+			return nil, fmt.Errorf("unable to persist the acknowledgement of database %s: %v", r.Databasename, err)
+		}
+		s.Logger.Infof("tampering of database %s has been acknowledged", r.Databasename)
+	}
+	return new(empty.Empty), nil
+}
+
+// tamperedDatabases returns the tampered or quarantined databases visible to the caller
+func (s *ImmuServer) tamperedDatabases(ctx context.Context) []*schema.Database {
+	all := !s.Options.GetAuth() || s.Options.GetMaintenance()
+	var visible map[string]bool
+	if !all {
+		_, user, err := s.getLoggedInUserdataFromCtx(ctx)
+		if err != nil {
+			return nil
+		}
+		all = user.IsSysAdmin
+		visible = make(map[string]bool)
+		for _, val := range user.EffectivePermissions() {
+			visible[val.Database] = true
+		}
+	}
+	var tampered []*schema.Database
+	for i := 0; i < s.dbList.Length(); i++ {
+		db := s.dbList.GetByIndex(int64(i))
+		if db == nil || (!all && !visible[db.options.dbName]) {
+			continue
+		}
+		if st := db.TamperStatus(); st.Tampered || st.Quarantined {
+			tampered = append(tampered, st)
+		}
+	}
+	return tampered
+}
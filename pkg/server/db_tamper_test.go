@@ -0,0 +1,125 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newAuthorizedCtx(ctx context.Context, token string) context.Context {
+	return metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestDatabaseTampering(t *testing.T) {
+	s := newInmemoryAuthServer()
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+	_, err = s.CreateDatabase(ctx, &schema.Database{Databasename: testDatabase})
+	assert.Nil(t, err)
+	reply, err := s.UseDatabase(ctx, &schema.Database{Databasename: DefaultdbName})
+	assert.Nil(t, err)
+	ctx = newAuthorizedCtx(ctx, reply.Token)
+
+	kv := &schema.KeyValue{Key: testKey, Value: testValue}
+	_, err = s.Set(ctx, kv)
+	assert.Nil(t, err)
+
+	s.dbList.GetByIndex(s.databasenameToIndex[DefaultdbName]).SetTampered(3)
+
+	_, err = s.Set(ctx, kv)
+	assert.Equal(t, codes.DataLoss, status.Code(err))
+
+	h, err := s.Health(ctx, &empty.Empty{})
+	assert.Nil(t, err)
+	assert.False(t, h.Status)
+	assert.Len(t, h.Tampered, 1)
+	assert.Equal(t, DefaultdbName, h.Tampered[0].Databasename)
+	assert.Equal(t, uint64(3), h.Tampered[0].TamperedIndex)
+
+	dbs, err := s.DatabaseList(ctx, &empty.Empty{})
+	assert.Nil(t, err)
+	for _, db := range dbs.Databases {
+		assert.Equal(t, db.Databasename == DefaultdbName, db.Tampered)
+	}
+
+	//other databases are still available
+	other, err := s.UseDatabase(ctx, &schema.Database{Databasename: testDatabase})
+	assert.Nil(t, err)
+	_, err = s.Set(newAuthorizedCtx(ctx, other.Token), kv)
+	assert.Nil(t, err)
+
+	_, err = s.ResolveTampering(ctx, &schema.ResolveTamperingRequest{Databasename: DefaultdbName, Quarantine: true})
+	assert.Nil(t, err)
+	_, err = s.Set(ctx, kv)
+	assert.Equal(t, codes.DataLoss, status.Code(err))
+
+	_, err = s.ResolveTampering(ctx, &schema.ResolveTamperingRequest{Databasename: DefaultdbName})
+	assert.Nil(t, err)
+	_, err = s.Set(ctx, kv)
+	assert.Nil(t, err)
+	h, err = s.Health(ctx, &empty.Empty{})
+	assert.Nil(t, err)
+	assert.True(t, h.Status)
+	assert.Empty(t, h.Tampered)
+
+	_, err = s.ResolveTampering(ctx, &schema.ResolveTamperingRequest{Databasename: "missing"})
+	assert.Error(t, err)
+}
+
+func TestTamperStatePersisted(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tamper_test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	options := DefaultOption().WithDbRootPath(dir).WithDbName("tamperdb").WithCorruptionChecker(false)
+	db, err := NewDb(options, &mockLogger{})
+	assert.Nil(t, err)
+	assert.Nil(t, db.SetTampered(3))
+	assert.Nil(t, db.Quarantine())
+	assert.Nil(t, db.Store.Close())
+
+	//the database stays unavailable after a restart
+	db, err = OpenDb(options, &mockLogger{})
+	assert.Nil(t, err)
+	st := db.TamperStatus()
+	assert.True(t, st.Tampered)
+	assert.Equal(t, uint64(3), st.TamperedIndex)
+	assert.True(t, st.Quarantined)
+	assert.Equal(t, codes.DataLoss, status.Code(db.checkTampered()))
+	assert.Nil(t, db.Acknowledge())
+	assert.Nil(t, db.Store.Close())
+
+	db, err = OpenDb(options, &mockLogger{})
+	assert.Nil(t, err)
+	assert.Nil(t, db.checkTampered())
+	assert.Nil(t, db.Store.Close())
+
+	//an unreadable tamper state does not make the database available
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "tamperdb", tamperFileName), []byte("{"), 0644))
+	_, err = OpenDb(options, &mockLogger{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/store"
+)
+
+// verifyProgressInterval number of entries verified between two progress messages
+const verifyProgressInterval = 1000
+
+// VerifyDatabase rebuilds the whole tree of a database out of the stored entries and compares it with the persisted one,
+// streaming the progress and the mismatches found
+func (s *ImmuServer) VerifyDatabase(r *schema.VerifyDatabaseRequest, stream schema.ImmuService_VerifyDatabaseServer) error {
+	s.Logger.Debugf("VerifyDatabase %+v", *r)
+	ctx := stream.Context()
+	if err := s.requireSysAdmin(ctx); err != nil {
+		return err
+	}
+	var ind int64
+	if r.Databasename == "" {
+		var err error
+		//a database marked as tampered has to be verifiable, so its tampering is not checked
+		if ind, err = s.getSelectedDbIndexFromCtx(ctx, "VerifyDatabase"); err != nil {
+			return err
+		}
+	} else {
+		var ok bool
+		if ind, ok = s.getDbIndexByName(r.Databasename); !ok {
+			return fmt.Errorf("%s does not exist", r.Databasename)
+		}
+	}
+	db, err := s.acquireDb(ind)
+	if err != nil {
+		return err
+	}
+	defer db.release()
+	s.Logger.Infof("Verifying database %s", db.options.dbName)
+
+	var mismatches []*schema.TreeMismatch
+	var verified, total uint64
+	onMismatch := func(m store.TreeMismatch) error {
+		s.Logger.Errorf("database %s verification failed at %s", db.options.dbName, m)
+		pm := &schema.TreeMismatch{
+			Layer:    uint32(m.Layer),
+			Index:    m.Index,
+			Expected: m.Expected,
+			Actual:   m.Actual,
+		}
+		if m.Err != nil {
+			pm.Error = m.Err.Error()
+		}
+		mismatches = append(mismatches, pm)
+		return nil
+	}
+	onProgress := func(v uint64, t uint64) error {
+		verified, total = v, t
+		if len(mismatches) == 0 && v%verifyProgressInterval != 0 {
+			return nil
+		}
+		err := stream.Send(&schema.VerifyDatabaseProgress{Verified: v, Total: t, Mismatches: mismatches})
+		mismatches = nil
+		return err
+	}
+	root, consistent, err := db.Store.VerifyTree(onProgress, onMismatch)
+	if err != nil {
+		return err
+	}
+	s.Logger.Infof("Verification of database %s completed, consistent: %t", db.options.dbName, consistent)
+	return stream.Send(&schema.VerifyDatabaseProgress{
+		Verified:   verified,
+		Total:      total,
+		Mismatches: mismatches,
+		Done:       true,
+		Consistent: consistent,
+		Root:       root,
+	})
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type verifyDatabaseStream struct {
+	mockServerStream
+	sent []*schema.VerifyDatabaseProgress
+}
+
+func (r *verifyDatabaseStream) Send(m *schema.VerifyDatabaseProgress) error {
+	r.sent = append(r.sent, m)
+	return nil
+}
+
+func TestVerifyDatabase(t *testing.T) {
+	s := newInmemoryAuthServer()
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+	for i := 0; i < 3; i++ {
+		_, err = s.SafeSet(ctx, &schema.SafeSetOptions{Kv: &schema.KeyValue{Key: testKey, Value: testValue}})
+		assert.Nil(t, err)
+	}
+
+	stream := &verifyDatabaseStream{mockServerStream: mockServerStream{ctx: ctx}}
+	assert.Nil(t, s.VerifyDatabase(&schema.VerifyDatabaseRequest{}, stream))
+	last := stream.sent[len(stream.sent)-1]
+	assert.True(t, last.Done)
+	assert.True(t, last.Consistent)
+	assert.Empty(t, last.Mismatches)
+	assert.Equal(t, uint64(3), last.Verified)
+	assert.Equal(t, uint64(2), last.Root.Index)
+
+	stream = &verifyDatabaseStream{mockServerStream: mockServerStream{ctx: ctx}}
+	assert.Nil(t, s.VerifyDatabase(&schema.VerifyDatabaseRequest{Databasename: s.Options.GetSystemAdminDbName()}, stream))
+	assert.True(t, stream.sent[len(stream.sent)-1].Consistent)
+
+	assert.Error(t, s.VerifyDatabase(&schema.VerifyDatabaseRequest{Databasename: "nodb"}, stream))
+}
+
+func TestVerifyTamperedDatabase(t *testing.T) {
+	s := newInmemoryAuthServer()
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+	_, err = s.SafeSet(ctx, &schema.SafeSetOptions{Kv: &schema.KeyValue{Key: testKey, Value: testValue}})
+	assert.Nil(t, err)
+	s.dbList.GetByIndex(s.databasenameToIndex[DefaultdbName]).SetTampered(0)
+
+	//the database in use is refused to every other call, but it can still be verified
+	_, err = s.Get(ctx, &schema.Key{Key: testKey})
+	assert.Equal(t, codes.DataLoss, status.Code(err))
+	stream := &verifyDatabaseStream{mockServerStream: mockServerStream{ctx: ctx}}
+	assert.Nil(t, s.VerifyDatabase(&schema.VerifyDatabaseRequest{}, stream))
+	last := stream.sent[len(stream.sent)-1]
+	assert.True(t, last.Done)
+	assert.Equal(t, uint64(1), last.Verified)
+}
@@ -23,6 +23,8 @@ type DbOptions struct {
 	dbRootPath        string
 	corruptionChecker bool
 	inMemoryStore     bool
+	settings          DbSettings
+	readOnly          bool
 }
 
 // DefaultOption Initialise Db Optionts to default values
@@ -79,3 +81,25 @@ func (o *DbOptions) WithInMemoryStore(inmemory bool) *DbOptions {
 func (o *DbOptions) GetInMemoryStore() bool {
 	return o.inMemoryStore
 }
+
+// WithSettings sets the settings a new database is created with
+func (o *DbOptions) WithSettings(settings DbSettings) *DbOptions {
+	o.settings = settings
+	return o
+}
+
+// GetSettings returns the settings a new database is created with
+func (o *DbOptions) GetSettings() DbSettings {
+	return o.settings
+}
+
+// WithReadOnly refuses writes to this database instance regardless of its settings
+func (o *DbOptions) WithReadOnly(readOnly bool) *DbOptions {
+	o.readOnly = readOnly
+	return o
+}
+
+// GetReadOnly returns if writes to this database instance are refused regardless of its settings
+func (o *DbOptions) GetReadOnly() bool {
+	return o.readOnly
+}
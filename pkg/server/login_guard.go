@@ -0,0 +1,180 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// maxTrackedLogins bounds the entries kept before stale ones are pruned
+const maxTrackedLogins = 20000
+
+// failedLogins keeps track of the consecutive failed logins of a user from a source address, or of a source address
+type failedLogins struct {
+	count       int
+	last        time.Time
+	lockedUntil time.Time
+}
+
+// loginSource identifies the attempts of a user from an ip, or of an ip when user is empty
+type loginSource struct {
+	user string
+	ip   string
+}
+
+// loginGuard throttles Login calls after failed attempts, per user and source ip pair and per source ip.
+// A user is locked out only from the address the failed attempts came from, so that guessing the password of
+// a user, the sysadmin included, from one address does not lock that user out of the others.
+// Requests without a known peer address are tracked per user only.
+type loginGuard struct {
+	attempts map[loginSource]*failedLogins
+	sync.Mutex
+}
+
+func newLoginGuard() *loginGuard {
+	return &loginGuard{
+		attempts: make(map[loginSource]*failedLogins),
+	}
+}
+
+// sources returns the entries tracking the attempts of username from ip
+func sources(username string, ip string) []loginSource {
+	if ip == "" {
+		return []loginSource{{user: username}}
+	}
+	return []loginSource{{user: username, ip: ip}, {ip: ip}}
+}
+
+// check returns an error if the user from the ip, or the ip, are locked out or still have to wait before retrying
+func (g *loginGuard) check(username string, ip string, opts LoginGuardOptions) error {
+	if !opts.Enabled() {
+		return nil
+	}
+	g.Lock()
+	defer g.Unlock()
+	now := time.Now()
+	for _, src := range sources(username, ip) {
+		f := g.attempts[src]
+		if f == nil {
+			continue
+		}
+		if now.Before(f.lockedUntil) {
+			return status.Errorf(
+				codes.ResourceExhausted,
+				"too many failed login attempts: locked out for %s",
+				f.lockedUntil.Sub(now).Round(time.Second))
+		}
+		if retryAt := f.last.Add(opts.backoff(f.count)); now.Before(retryAt) {
+			return status.Errorf(
+				codes.ResourceExhausted,
+				"too many failed login attempts: retry in %s",
+				retryAt.Sub(now).Round(time.Millisecond))
+		}
+	}
+	return nil
+}
+
+// failed records a failed attempt and locks the user from the ip, and the ip, when the limit is reached
+func (g *loginGuard) failed(username string, ip string, opts LoginGuardOptions) {
+	if !opts.Enabled() {
+		return
+	}
+	g.Lock()
+	defer g.Unlock()
+	now := time.Now()
+	for _, src := range sources(username, ip) {
+		g.attempts[src] = record(g.attempts[src], now, opts)
+	}
+	if len(g.attempts) > maxTrackedLogins {
+		g.prune(now, opts)
+	}
+}
+
+// prune drops the entries which are not locked out and have not failed for a whole lockout period
+func (g *loginGuard) prune(now time.Time, opts LoginGuardOptions) {
+	for k, f := range g.attempts {
+		if now.After(f.lockedUntil) && now.Sub(f.last) > opts.LockoutDuration {
+			delete(g.attempts, k)
+		}
+	}
+}
+
+func record(f *failedLogins, now time.Time, opts LoginGuardOptions) *failedLogins {
+	if f == nil || (!f.lockedUntil.IsZero() && now.After(f.lockedUntil)) {
+		f = &failedLogins{}
+	}
+	f.count++
+	f.last = now
+	if f.count >= opts.MaxAttempts {
+		f.lockedUntil = now.Add(opts.LockoutDuration)
+	}
+	return f
+}
+
+// succeeded clears the failed attempts of the user from the ip and of the ip
+func (g *loginGuard) succeeded(username string, ip string) {
+	g.Lock()
+	defer g.Unlock()
+	for _, src := range sources(username, ip) {
+		delete(g.attempts, src)
+	}
+}
+
+// unlock clears the failed attempts of the user from every ip
+func (g *loginGuard) unlock(username string) {
+	g.Lock()
+	defer g.Unlock()
+	for src := range g.attempts {
+		if src.user == username {
+			delete(g.attempts, src)
+		}
+	}
+}
+
+// isLocked returns true if the user is currently locked out from any ip
+func (g *loginGuard) isLocked(username string) bool {
+	g.Lock()
+	defer g.Unlock()
+	now := time.Now()
+	for src, f := range g.attempts {
+		if src.user == username && now.Before(f.lockedUntil) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIPFromCtx returns the ip of the peer which sent the request
+func clientIPFromCtx(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p == nil || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		//addresses without a port, such as the ones of unix sockets
+		return p.Addr.String()
+	}
+	return host
+}
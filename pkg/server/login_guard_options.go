@@ -0,0 +1,84 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import "time"
+
+// LoginGuardOptions brute-force protection settings for Login
+type LoginGuardOptions struct {
+	MaxAttempts     int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	LockoutDuration time.Duration
+}
+
+// DefaultLoginGuardOptions ...
+func DefaultLoginGuardOptions() LoginGuardOptions {
+	return LoginGuardOptions{
+		MaxAttempts:     5,
+		BaseDelay:       time.Second,
+		MaxDelay:        30 * time.Second,
+		LockoutDuration: 15 * time.Minute,
+	}
+}
+
+// WithMaxAttempts sets the number of consecutive failed logins after which the user or ip are locked out, 0 disables the guard
+func (o LoginGuardOptions) WithMaxAttempts(maxAttempts int) LoginGuardOptions {
+	o.MaxAttempts = maxAttempts
+	return o
+}
+
+// WithBaseDelay sets the delay enforced after the first failed login, doubled at each further failure
+func (o LoginGuardOptions) WithBaseDelay(baseDelay time.Duration) LoginGuardOptions {
+	o.BaseDelay = baseDelay
+	return o
+}
+
+// WithMaxDelay sets the upper bound of the delay between failed logins
+func (o LoginGuardOptions) WithMaxDelay(maxDelay time.Duration) LoginGuardOptions {
+	o.MaxDelay = maxDelay
+	return o
+}
+
+// WithLockoutDuration sets how long a user or ip stay locked out
+func (o LoginGuardOptions) WithLockoutDuration(lockoutDuration time.Duration) LoginGuardOptions {
+	o.LockoutDuration = lockoutDuration
+	return o
+}
+
+// Enabled returns true if failed logins are throttled
+func (o LoginGuardOptions) Enabled() bool {
+	return o.MaxAttempts > 0
+}
+
+// backoff returns the delay to wait after the given number of consecutive failures
+func (o LoginGuardOptions) backoff(failures int) time.Duration {
+	if failures <= 0 || o.BaseDelay <= 0 {
+		return 0
+	}
+	d := o.BaseDelay
+	for i := 1; i < failures; i++ {
+		d *= 2
+		if o.MaxDelay > 0 && d >= o.MaxDelay {
+			return o.MaxDelay
+		}
+	}
+	if o.MaxDelay > 0 && d > o.MaxDelay {
+		return o.MaxDelay
+	}
+	return d
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoginGuardOptions(t *testing.T) {
+	op := DefaultLoginGuardOptions().
+		WithMaxAttempts(3).
+		WithBaseDelay(time.Second).
+		WithMaxDelay(5 * time.Second).
+		WithLockoutDuration(time.Minute)
+	assert.Equal(t, 3, op.MaxAttempts)
+	assert.Equal(t, time.Minute, op.LockoutDuration)
+	assert.True(t, op.Enabled())
+	assert.False(t, op.WithMaxAttempts(0).Enabled())
+	assert.Equal(t, time.Duration(0), op.backoff(0))
+	assert.Equal(t, time.Second, op.backoff(1))
+	assert.Equal(t, 2*time.Second, op.backoff(2))
+	assert.Equal(t, 4*time.Second, op.backoff(3))
+	assert.Equal(t, 5*time.Second, op.backoff(4))
+	assert.Equal(t, 5*time.Second, op.backoff(100))
+}
+
+func TestLoginGuard(t *testing.T) {
+	op := DefaultLoginGuardOptions().
+		WithMaxAttempts(3).
+		WithBaseDelay(0).
+		WithLockoutDuration(time.Hour)
+	g := newLoginGuard()
+
+	assert.Nil(t, g.check("user", "1.1.1.1", op))
+	g.failed("user", "1.1.1.1", op)
+	g.failed("user", "1.1.1.1", op)
+	assert.Nil(t, g.check("user", "1.1.1.1", op))
+	assert.False(t, g.isLocked("user"))
+
+	g.failed("user", "1.1.1.1", op)
+	assert.True(t, g.isLocked("user"))
+	err := g.check("user", "1.1.1.1", op)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	err = g.check("other", "1.1.1.1", op)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	//the user is locked out only from the address the attempts came from
+	assert.Nil(t, g.check("user", "2.2.2.2", op))
+	assert.Nil(t, g.check("other", "2.2.2.2", op))
+
+	g.unlock("user")
+	assert.False(t, g.isLocked("user"))
+	err = g.check("user", "1.1.1.1", op)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	g.succeeded("user", "1.1.1.1")
+	assert.Nil(t, g.check("user", "1.1.1.1", op))
+
+	for i := 0; i < 3; i++ {
+		g.failed("user", "", op)
+	}
+	assert.True(t, g.isLocked("user"))
+	err = g.check("user", "", op)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	g.unlock("user")
+
+	disabled := op.WithMaxAttempts(0)
+	for i := 0; i < 10; i++ {
+		g.failed("user", "1.1.1.1", disabled)
+	}
+	assert.Nil(t, g.check("user", "1.1.1.1", disabled))
+	assert.False(t, g.isLocked("user"))
+}
+
+func TestLoginGuardBackoff(t *testing.T) {
+	op := DefaultLoginGuardOptions().
+		WithBaseDelay(time.Hour).
+		WithMaxDelay(time.Hour)
+	g := newLoginGuard()
+	g.failed("user", "1.1.1.1", op)
+	err := g.check("user", "1.1.1.1", op)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.False(t, g.isLocked("user"))
+}
+
+func TestClientIPFromCtx(t *testing.T) {
+	assert.Equal(t, "", clientIPFromCtx(context.Background()))
+	for addr, ip := range map[string]string{
+		"10.0.0.1:3322":        "10.0.0.1",
+		"[2001:db8::1]:3322":   "2001:db8::1",
+		"[::1]:51000":          "::1",
+		"/var/run/immudb.sock": "/var/run/immudb.sock",
+	} {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: testAddr(addr)})
+		assert.Equal(t, ip, clientIPFromCtx(ctx))
+	}
+}
+
+type testAddr string
+
+func (a testAddr) Network() string { return "tcp" }
+func (a testAddr) String() string  { return string(a) }
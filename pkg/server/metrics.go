@@ -21,6 +21,7 @@ import (
 	"expvar"
 	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"google.golang.org/grpc/peer"
@@ -35,7 +36,13 @@ type MetricsCollection struct {
 	RecordsCounter               prometheus.CounterFunc
 	UptimeCounter                prometheus.CounterFunc
 	RPCsPerClientCounters        *prometheus.CounterVec
+	LoginsCounters               *prometheus.CounterVec
 	LastMessageAtPerClientGauges *prometheus.GaugeVec
+	QuotaRejectionsCounters      *prometheus.CounterVec
+	DatabaseRequestsCounters     *prometheus.CounterVec
+	DatabaseRequestDurations     *prometheus.HistogramVec
+	CorruptionCheckerGauges      *prometheus.GaugeVec
+	DatabaseUsage                *databaseUsageCollector
 }
 
 var metricsNamespace = "immudb"
@@ -64,6 +71,78 @@ func (mc *MetricsCollection) WithUptimeCounter(f func() float64) {
 	)
 }
 
+// WithDatabaseUsage exports the usage and the quotas of each database, as returned by f when metrics are scraped
+func (mc *MetricsCollection) WithDatabaseUsage(f func() []DatabaseUsage) {
+	mc.DatabaseUsage.Lock()
+	defer mc.DatabaseUsage.Unlock()
+	mc.DatabaseUsage.usage = f
+}
+
+var (
+	databaseEntriesDesc = prometheus.NewDesc(
+		"immudb_database_entries", "Number of entries stored by the database.", []string{"database"}, nil)
+	databaseSizeDesc = prometheus.NewDesc(
+		"immudb_database_size_bytes", "LSM and value log size of the database in bytes.", []string{"database"}, nil)
+	databaseWritesDesc = prometheus.NewDesc(
+		"immudb_database_writes_total", "Number of entries written to the database since the server started.", []string{"database"}, nil)
+	databaseTreeWidthDesc = prometheus.NewDesc(
+		"immudb_database_tree_width", "Number of entries added to the merkle tree of the database.", []string{"database"}, nil)
+	databasePendingTreeEntriesDesc = prometheus.NewDesc(
+		"immudb_database_pending_tree_entries", "Number of entries not yet added to the merkle tree or flushed to disk.", []string{"database"}, nil)
+	databaseLsmSizeDesc = prometheus.NewDesc(
+		"immudb_database_lsm_size_bytes", "LSM size of the database in bytes.", []string{"database"}, nil)
+	databaseVlogSizeDesc = prometheus.NewDesc(
+		"immudb_database_vlog_size_bytes", "Value log size of the database in bytes.", []string{"database"}, nil)
+	databaseQuotaEntriesDesc = prometheus.NewDesc(
+		"immudb_database_quota_entries", "Maximum number of entries of the database, 0 means unlimited.", []string{"database"}, nil)
+	databaseQuotaBytesDesc = prometheus.NewDesc(
+		"immudb_database_quota_bytes", "Maximum size of the database in bytes, 0 means unlimited.", []string{"database"}, nil)
+	databaseQuotaWritesDesc = prometheus.NewDesc(
+		"immudb_database_quota_writes_per_second", "Maximum writes per second to the database, 0 means unlimited.", []string{"database"}, nil)
+)
+
+// databaseUsageCollector collects the usage of the databases when metrics are scraped
+type databaseUsageCollector struct {
+	usage func() []DatabaseUsage
+	sync.Mutex
+}
+
+// Describe implements prometheus.Collector
+func (c *databaseUsageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- databaseEntriesDesc
+	ch <- databaseSizeDesc
+	ch <- databaseWritesDesc
+	ch <- databaseTreeWidthDesc
+	ch <- databasePendingTreeEntriesDesc
+	ch <- databaseLsmSizeDesc
+	ch <- databaseVlogSizeDesc
+	ch <- databaseQuotaEntriesDesc
+	ch <- databaseQuotaBytesDesc
+	ch <- databaseQuotaWritesDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *databaseUsageCollector) Collect(ch chan<- prometheus.Metric) {
+	c.Lock()
+	usage := c.usage
+	c.Unlock()
+	if usage == nil {
+		return
+	}
+	for _, u := range usage() {
+		ch <- prometheus.MustNewConstMetric(databaseEntriesDesc, prometheus.GaugeValue, float64(u.Entries), u.Name)
+		ch <- prometheus.MustNewConstMetric(databaseSizeDesc, prometheus.GaugeValue, float64(u.Bytes), u.Name)
+		ch <- prometheus.MustNewConstMetric(databaseWritesDesc, prometheus.CounterValue, float64(u.Writes), u.Name)
+		ch <- prometheus.MustNewConstMetric(databaseTreeWidthDesc, prometheus.GaugeValue, float64(u.TreeWidth), u.Name)
+		ch <- prometheus.MustNewConstMetric(databasePendingTreeEntriesDesc, prometheus.GaugeValue, float64(u.PendingTreeEntries), u.Name)
+		ch <- prometheus.MustNewConstMetric(databaseLsmSizeDesc, prometheus.GaugeValue, float64(u.LsmBytes), u.Name)
+		ch <- prometheus.MustNewConstMetric(databaseVlogSizeDesc, prometheus.GaugeValue, float64(u.VlogBytes), u.Name)
+		ch <- prometheus.MustNewConstMetric(databaseQuotaEntriesDesc, prometheus.GaugeValue, float64(u.MaxEntries), u.Name)
+		ch <- prometheus.MustNewConstMetric(databaseQuotaBytesDesc, prometheus.GaugeValue, float64(u.MaxBytes), u.Name)
+		ch <- prometheus.MustNewConstMetric(databaseQuotaWritesDesc, prometheus.GaugeValue, float64(u.MaxWritesPerSecond), u.Name)
+	}
+}
+
 // UpdateClientMetrics ...
 func (mc *MetricsCollection) UpdateClientMetrics(ctx context.Context) {
 	p, ok := peer.FromContext(ctx)
@@ -94,6 +173,48 @@ var Metrics = MetricsCollection{
 		},
 		[]string{"ip"},
 	),
+	LoginsCounters: promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "login_attempts_total",
+			Help:      "Number of login attempts by result (success, failure, locked).",
+		},
+		[]string{"result"},
+	),
+	QuotaRejectionsCounters: promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "database_quota_rejections_total",
+			Help:      "Number of writes rejected by database and exceeded quota (entries, bytes, writes_per_second).",
+		},
+		[]string{"database", "quota"},
+	),
+	DatabaseRequestsCounters: promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "database_requests_total",
+			Help:      "Number of handled RPCs by database, method and gRPC code.",
+		},
+		[]string{"database", "method", "code"},
+	),
+	DatabaseRequestDurations: promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "database_request_duration_seconds",
+			Help:      "Duration of the handled RPCs by database and method.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"database", "method"},
+	),
+	CorruptionCheckerGauges: promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "corruption_checker_entries",
+			Help:      "Progress of the corruption checker by database: entries to check in the current pass (total) and entries checked so far (checked).",
+		},
+		[]string{"database", "progress"},
+	),
+	DatabaseUsage: &databaseUsageCollector{},
 }
 
 func init() {
@@ -114,6 +235,7 @@ func init() {
 		"badger_lsm_level_gets_total": prometheus.NewDesc("immudb_lsm_level_gets_total", "LSM Level Gets", []string{"level"}, nil),
 	})
 	prometheus.MustRegister(expvarCollector)
+	prometheus.MustRegister(Metrics.DatabaseUsage)
 }
 
 // StartMetrics listens and servers the HTTP metrics server in a new goroutine.
@@ -16,11 +16,23 @@ limitations under the License.
 
 package server
 
+// Client certificate fields which can be mapped to immudb users
+const (
+	CertIdentityCN  = "cn"
+	CertIdentitySAN = "san"
+)
+
 // MTLsOptions ...
 type MTLsOptions struct {
 	Pkey        string
 	Certificate string
 	ClientCAs   string
+	// IdentityField selects the client certificate field (CertIdentityCN or CertIdentitySAN)
+	// used to authenticate calls without a token, empty disables certificate authentication
+	IdentityField string
+	// IdentityMap maps certificate identities to immudb usernames. Identities without
+	// an entry are used as usernames, except for the system admin which must be mapped explicitly
+	IdentityMap map[string]string
 }
 
 // DefaultMTLsOptions ...
@@ -49,3 +61,15 @@ func (o MTLsOptions) WithClientCAs(ClientCAs string) MTLsOptions {
 	o.ClientCAs = ClientCAs
 	return o
 }
+
+// WithIdentityField ...
+func (o MTLsOptions) WithIdentityField(IdentityField string) MTLsOptions {
+	o.IdentityField = IdentityField
+	return o
+}
+
+// WithIdentityMap ...
+func (o MTLsOptions) WithIdentityMap(IdentityMap map[string]string) MTLsOptions {
+	o.IdentityMap = IdentityMap
+	return o
+}
@@ -30,55 +30,66 @@ const DefaultdbName = "defaultdb"
 
 // Options server options list
 type Options struct {
-	Dir                 string
-	Network             string
-	Address             string
-	Port                int
-	MetricsPort         int
-	Config              string
-	Pidfile             string
-	Logfile             string
-	MTLs                bool
-	MTLsOptions         MTLsOptions
-	auth                bool
-	NoHistograms        bool
-	Detached            bool
-	CorruptionCheck     bool
-	MetricsServer       bool
-	DevMode             bool
-	AdminPassword       string `json:"-"`
-	systemAdminDbName   string
-	defaultDbName       string
-	inMemoryStore       bool
-	listener            net.Listener
-	usingCustomListener bool
-	maintenance         bool
+	Dir                      string
+	Network                  string
+	Address                  string
+	Port                     int
+	MetricsPort              int
+	Config                   string
+	Pidfile                  string
+	Logfile                  string
+	MTLs                     bool
+	MTLsOptions              MTLsOptions
+	LoginGuardOptions        LoginGuardOptions
+	PasswordPolicy           auth.PasswordPolicy
+	auth                     bool
+	NoHistograms             bool
+	Detached                 bool
+	CorruptionCheck          bool
+	CorruptionCheckerOptions CorruptionCheckerOptions
+	BackupOptions            BackupOptions
+	SigningKey               string
+	MetricsServer            bool
+	DevMode                  bool
+	AdminPassword            string `json:"-"`
+	systemAdminDbName        string
+	defaultDbName            string
+	inMemoryStore            bool
+	listener                 net.Listener
+	usingCustomListener      bool
+	maintenance              bool
+	readOnly                 bool
 }
 
 // DefaultOptions returns default server options
 func DefaultOptions() Options {
 	return Options{
-		Dir:                 "./data",
-		Network:             "tcp",
-		Address:             "0.0.0.0",
-		Port:                3322,
-		MetricsPort:         9497,
-		Config:              "configs/immudb.toml",
-		Pidfile:             "",
-		Logfile:             "",
-		MTLs:                false,
-		auth:                true,
-		NoHistograms:        false,
-		Detached:            false,
-		CorruptionCheck:     true,
-		MetricsServer:       true,
-		DevMode:             false,
-		AdminPassword:       auth.SysAdminPassword,
-		systemAdminDbName:   SystemdbName,
-		defaultDbName:       DefaultdbName,
-		inMemoryStore:       false,
-		usingCustomListener: false,
-		maintenance:         false,
+		Dir:                      "./data",
+		Network:                  "tcp",
+		Address:                  "0.0.0.0",
+		Port:                     3322,
+		MetricsPort:              9497,
+		Config:                   "configs/immudb.toml",
+		Pidfile:                  "",
+		Logfile:                  "",
+		MTLs:                     false,
+		LoginGuardOptions:        DefaultLoginGuardOptions(),
+		PasswordPolicy:           auth.DefaultPasswordPolicy(),
+		auth:                     true,
+		NoHistograms:             false,
+		Detached:                 false,
+		CorruptionCheck:          true,
+		CorruptionCheckerOptions: DefaultCorruptionCheckerOptions(),
+		BackupOptions:            DefaultBackupOptions(),
+		MetricsServer:            true,
+		DevMode:                  false,
+		AdminPassword:            auth.SysAdminPassword,
+		systemAdminDbName:        SystemdbName,
+		defaultDbName:            DefaultdbName,
+		inMemoryStore:            false,
+		usingCustomListener:      false,
+		maintenance:              false,
+		readOnly:                 false,
 	}
 }
 
@@ -138,6 +149,26 @@ func (o Options) WithMTLsOptions(MTLsOptions MTLsOptions) Options {
 	return o
 }
 
+// WithPasswordPolicy sets the password strength and rotation requirements
+func (o Options) WithPasswordPolicy(passwordPolicy auth.PasswordPolicy) Options {
+	o.PasswordPolicy = passwordPolicy
+	return o
+}
+
+// GetPasswordPolicy returns the password policy, falling back to the default one when none is set
+func (o Options) GetPasswordPolicy() auth.PasswordPolicy {
+	if o.PasswordPolicy.MaxLength == 0 {
+		return auth.DefaultPasswordPolicy()
+	}
+	return o.PasswordPolicy
+}
+
+// WithLoginGuardOptions sets the brute-force protection settings for Login
+func (o Options) WithLoginGuardOptions(loginGuardOptions LoginGuardOptions) Options {
+	o.LoginGuardOptions = loginGuardOptions
+	return o
+}
+
 // WithAuth sets auth
 func (o Options) WithAuth(authEnabled bool) Options {
 	o.auth = authEnabled
@@ -170,6 +201,24 @@ func (o Options) WithCorruptionCheck(corruptionCheck bool) Options {
 	return o
 }
 
+// WithCorruptionCheckerOptions sets the strategy and the I/O budget of the corruption checker
+func (o Options) WithCorruptionCheckerOptions(corruptionCheckerOptions CorruptionCheckerOptions) Options {
+	o.CorruptionCheckerOptions = corruptionCheckerOptions
+	return o
+}
+
+// WithSigningKey sets the path of the PEM encoded ed25519 or ECDSA private key used to sign the roots returned to clients
+func (o Options) WithSigningKey(signingKey string) Options {
+	o.SigningKey = signingKey
+	return o
+}
+
+// WithBackupOptions sets the schedule, the destination and the retention of the backups taken by the server
+func (o Options) WithBackupOptions(backupOptions BackupOptions) Options {
+	o.BackupOptions = backupOptions
+	return o
+}
+
 // Bind returns bind address
 func (o Options) Bind() string {
 	return o.Address + ":" + strconv.Itoa(o.Port)
@@ -206,6 +255,14 @@ func (o Options) String() string {
 	opts = append(opts, rightPad("Dev mode", o.DevMode))
 	opts = append(opts, rightPad("Default database", o.defaultDbName))
 	opts = append(opts, rightPad("Maintenance mode", o.maintenance))
+	opts = append(opts, rightPad("Read-only mode", o.readOnly))
+	if o.SigningKey != "" {
+		opts = append(opts, rightPad("Signing key", o.SigningKey))
+	}
+	if o.BackupOptions.Enabled() {
+		opts = append(opts, rightPad("Backup schedule", o.BackupOptions.Schedule))
+		opts = append(opts, rightPad("Backup dir", o.GetBackupDir()))
+	}
 	opts = append(opts, "----------------------------------------")
 	opts = append(opts, "Superadmin default credentials")
 	opts = append(opts, rightPad("   Username", auth.SysAdminUsername))
@@ -270,3 +327,14 @@ func (o Options) WithMaintenance(m bool) Options {
 func (o Options) GetMaintenance() bool {
 	return o.maintenance
 }
+
+// WithReadOnly sets read-only mode, refusing writes to every database but the system one
+func (o Options) WithReadOnly(readOnly bool) Options {
+	o.readOnly = readOnly
+	return o
+}
+
+// GetReadOnly gets read-only mode
+func (o Options) GetReadOnly() bool {
+	return o.readOnly
+}
@@ -27,6 +27,7 @@ func TestOptions(t *testing.T) {
 	if op.GetAuth() != true ||
 		op.GetInMemoryStore() != false ||
 		op.GetMaintenance() != false ||
+		op.GetReadOnly() != false ||
 		op.GetDefaultDbName() != DefaultdbName ||
 		op.GetSystemAdminDbName() != SystemdbName ||
 		op.CorruptionCheck != true ||
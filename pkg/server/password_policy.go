@@ -0,0 +1,84 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// methods which users with an expired password are still allowed to call
+var passwordExpiryExemptMethods = map[string]struct{}{
+	"/immudb.schema.ImmuService/Login":          {},
+	"/immudb.schema.ImmuService/Logout":         {},
+	"/immudb.schema.ImmuService/ChangePassword": {},
+}
+
+// checkPasswordExpiry flags the user if the password is past its maximum age and returns
+// the warning to be sent back at login, if any
+func (s *ImmuServer) checkPasswordExpiry(u *auth.User) string {
+	policy := s.Options.GetPasswordPolicy()
+	if policy.MaxAge <= 0 {
+		return ""
+	}
+	expiresAt := u.PasswordExpiresAt(policy.MaxAge)
+	now := time.Now()
+	if !now.Before(expiresAt) {
+		u.PasswordExpired = true
+		return auth.WarnPasswordExpired
+	}
+	if now.Add(policy.ExpiryWarning).After(expiresAt) {
+		return fmt.Sprintf("password expires on %s: please change it", expiresAt.Format(time.RFC1123))
+	}
+	return ""
+}
+
+// passwordExpired returns an error if the logged in user must change the password before calling the method
+func (s *ImmuServer) passwordExpired(ctx context.Context, method string) error {
+	if !s.Options.GetAuth() || s.Options.GetPasswordPolicy().MaxAge <= 0 {
+		return nil
+	}
+	if _, ok := passwordExpiryExemptMethods[method]; ok {
+		return nil
+	}
+	if _, u, err := s.getLoggedInUserdataFromCtx(ctx); err == nil && u.PasswordExpired {
+		return status.Error(codes.PermissionDenied, auth.WarnPasswordExpired)
+	}
+	return nil
+}
+
+// PasswordExpiryUnaryInterceptor refuses unary calls of users whose password has expired
+func (s *ImmuServer) PasswordExpiryUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := s.passwordExpired(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// PasswordExpiryStreamInterceptor refuses streams of users whose password has expired
+func (s *ImmuServer) PasswordExpiryStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.passwordExpired(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
@@ -0,0 +1,344 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/store"
+	"github.com/codenotary/immudb/pkg/store/sysstore"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateRole creates a new role grouping permissions across databases
+func (s *ImmuServer) CreateRole(ctx context.Context, r *schema.CreateRoleRequest) (*empty.Empty, error) {
+	s.Logger.Debugf("CreateRole %+v", *r)
+	if err := s.requireSysAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if !auth.IsValidRoleName(r.Name) {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"role name can only contain letters, digits and underscores")
+	}
+	if len(r.Permissions) == 0 {
+		return nil, fmt.Errorf("role must grant at least one permission")
+	}
+	if _, err := s.getRole(r.Name); err == nil {
+		return nil, fmt.Errorf("role %s already exists", r.Name)
+	}
+
+	permissions, err := s.rolePermissions(r.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	role := &auth.Role{
+		Name:        r.Name,
+		Permissions: permissions,
+		CreatedAt:   time.Now(),
+	}
+	//there is no logged in user in maintenance mode
+	if _, user, err := s.getLoggedInUserdataFromCtx(ctx); err == nil {
+		role.CreatedBy = user.Username
+	}
+	if err := s.saveRole(role); err != nil {
+		return nil, err
+	}
+	return new(empty.Empty), nil
+}
+
+// UpdateRole replaces the permissions granted by a role, the users holding it have to login again
+func (s *ImmuServer) UpdateRole(ctx context.Context, r *schema.UpdateRoleRequest) (*empty.Empty, error) {
+	s.Logger.Debugf("UpdateRole %+v", *r)
+	if err := s.requireSysAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if len(r.Permissions) == 0 {
+		return nil, fmt.Errorf("role must grant at least one permission")
+	}
+	role, err := s.getRole(r.Name)
+	if err != nil {
+		return nil, fmt.Errorf("role %s not found", r.Name)
+	}
+	if role.Permissions, err = s.rolePermissions(r.Permissions); err != nil {
+		return nil, err
+	}
+	if err = s.saveRole(role); err != nil {
+		return nil, err
+	}
+	s.removeRoleHoldersFromLoginList(role.Name)
+	return new(empty.Empty), nil
+}
+
+// DeleteRole removes a role from the users holding it and marks it as deleted
+func (s *ImmuServer) DeleteRole(ctx context.Context, r *schema.DeleteRoleRequest) (*empty.Empty, error) {
+	s.Logger.Debugf("DeleteRole %+v", *r)
+	if err := s.requireSysAdmin(ctx); err != nil {
+		return nil, err
+	}
+	role, err := s.getRole(r.Name)
+	if err != nil {
+		return nil, fmt.Errorf("role %s not found", r.Name)
+	}
+	//the role is revoked first, so that a role created later with the same name is not granted to its former holders
+	users, err := s.dbList.GetByIndex(SystemDbIndex).Scan(&schema.ScanOptions{Prefix: []byte{sysstore.KeyPrefixUser}})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range users.Items {
+		var user auth.User
+		if err = json.Unmarshal(item.Value, &user); err != nil {
+			return nil, err
+		}
+		if !user.RevokeRole(role.Name) {
+			continue
+		}
+		if err = s.saveUser(&user); err != nil {
+			return nil, err
+		}
+	}
+	role.Deleted = true
+	if err = s.saveRole(role); err != nil {
+		return nil, err
+	}
+	s.removeRoleHoldersFromLoginList(role.Name)
+	return new(empty.Empty), nil
+}
+
+// rolePermissions validates the permissions granted by a role
+func (s *ImmuServer) rolePermissions(requested []*schema.Permission) ([]auth.Permission, error) {
+	var permissions []auth.Permission
+	for _, val := range requested {
+		if val.Database == SystemdbName {
+			return nil, fmt.Errorf("this database can not be assigned")
+		}
+		if _, ok := s.getDbIndexByName(val.Database); !ok {
+			return nil, fmt.Errorf("database %s does not exist", val.Database)
+		}
+		if (val.Permission == auth.PermissionNone) ||
+			((val.Permission > auth.PermissionRW) &&
+				(val.Permission != auth.PermissionAdmin)) {
+			return nil, fmt.Errorf("unrecognized permission")
+		}
+		permissions = append(permissions, auth.Permission{
+			Database:   val.Database,
+			Permission: val.Permission,
+		})
+	}
+	return permissions, nil
+}
+
+// ListRoles returns all the roles defined in the system database
+func (s *ImmuServer) ListRoles(ctx context.Context, req *empty.Empty) (*schema.RoleList, error) {
+	s.Logger.Debugf("ListRoles")
+	if _, err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	itemList, err := s.dbList.GetByIndex(SystemDbIndex).Scan(&schema.ScanOptions{
+		Prefix: []byte{sysstore.KeyPrefixRole},
+	})
+	if err != nil {
+		s.Logger.Errorf("error getting roles: %v", err)
+		return nil, err
+	}
+	rolelist := &schema.RoleList{}
+	for _, item := range itemList.Items {
+		var role auth.Role
+		if err = json.Unmarshal(item.Value, &role); err != nil {
+			return nil, err
+		}
+		if role.Deleted {
+			continue
+		}
+		permissions := []*schema.Permission{}
+		for _, val := range role.Permissions {
+			permissions = append(permissions, &schema.Permission{
+				Database:   val.Database,
+				Permission: val.Permission,
+			})
+		}
+		rolelist.Roles = append(rolelist.Roles, &schema.Role{
+			Name:        role.Name,
+			Permissions: permissions,
+			Createdby:   role.CreatedBy,
+			Createdat:   role.CreatedAt.String(),
+		})
+	}
+	return rolelist, nil
+}
+
+// GrantRole assigns a role to a user
+func (s *ImmuServer) GrantRole(ctx context.Context, r *schema.RoleRequest) (*schema.Error, error) {
+	s.Logger.Debugf("GrantRole %+v", *r)
+	return s.changeRole(ctx, r, true)
+}
+
+// RevokeRole removes a role from a user
+func (s *ImmuServer) RevokeRole(ctx context.Context, r *schema.RoleRequest) (*schema.Error, error) {
+	s.Logger.Debugf("RevokeRole %+v", *r)
+	return s.changeRole(ctx, r, false)
+}
+
+func (s *ImmuServer) changeRole(ctx context.Context, r *schema.RoleRequest, grant bool) (*schema.Error, error) {
+	user, err := s.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Username) == 0 {
+		return nil, fmt.Errorf("username can not be empty")
+	}
+	if len(r.Role) == 0 {
+		return nil, fmt.Errorf("role can not be empty")
+	}
+	//do not allow to change own roles, user can lock itsself out
+	if r.Username == user.Username {
+		return nil, fmt.Errorf("changing you own roles is not allowed")
+	}
+	targetUser, err := s.userExists([]byte(r.Username), nil)
+	if err != nil {
+		return nil, fmt.Errorf("user %s not found", r.Username)
+	}
+	if !targetUser.Active {
+		return nil, fmt.Errorf("user %s is not active", r.Username)
+	}
+	role, err := s.getRole(r.Role)
+	if err != nil {
+		return nil, fmt.Errorf("role %s not found", r.Role)
+	}
+	//admins can only hand out roles covering databases they administer
+	if !user.IsSysAdmin {
+		for _, db := range role.Databases() {
+			if !user.HasPermission(db, auth.PermissionAdmin) {
+				return nil, fmt.Errorf("you do not have permission on database %s", db)
+			}
+		}
+	}
+
+	var msg string
+	if grant {
+		if !targetUser.GrantRole(role.Name) {
+			return nil, fmt.Errorf("user %s already has role %s", r.Username, role.Name)
+		}
+		msg = "Role granted successfully"
+	} else {
+		if !targetUser.RevokeRole(role.Name) {
+			return nil, fmt.Errorf("user %s does not have role %s", r.Username, role.Name)
+		}
+		msg = "Role revoked successfully"
+	}
+	targetUser.CreatedBy = user.Username
+	targetUser.CreatedAt = time.Now()
+	if err := s.saveUser(targetUser); err != nil {
+		return nil, err
+	}
+	//remove user from loggedin users
+	s.removeUserFromLoginList(targetUser.Username)
+
+	return &schema.Error{
+		Errorcode:    schema.ErrorCodes_Ok,
+		Errormessage: msg,
+	}, nil
+}
+
+// requireAdmin returns the logged in user if it is the system admin or the admin of at least one database.
+// The errors are the ones of requireSysAdmin
+func (s *ImmuServer) requireAdmin(ctx context.Context) (*auth.User, error) {
+	if !s.Options.GetAuth() {
+		return nil, fmt.Errorf("this command is available only with authentication on")
+	}
+	_, user, err := s.getLoggedInUserdataFromCtx(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "please login first")
+	}
+	if !user.IsSysAdmin && !user.HasAtLeastOnePermission(auth.PermissionAdmin) {
+		return nil, fmt.Errorf("Logged In user does not have permissions for this operation")
+	}
+	return user, nil
+}
+
+// getRole returns the role definition stored in the system database, deleted roles are not found
+func (s *ImmuServer) getRole(name string) (*auth.Role, error) {
+	key := sysstore.AddKeyPrefix([]byte(name), sysstore.KeyPrefixRole)
+	item, err := s.dbList.GetByIndex(SystemDbIndex).Store.Get(schema.Key{Key: key})
+	if err != nil {
+		return nil, err
+	}
+	var role auth.Role
+	if err = json.Unmarshal(item.Value, &role); err != nil {
+		return nil, err
+	}
+	if role.Deleted {
+		return nil, store.ErrKeyNotFound
+	}
+	return &role, nil
+}
+
+func (s *ImmuServer) saveRole(role *auth.Role) error {
+	roleData, err := json.Marshal(role)
+	if err != nil {
+		s.Logger.Errorf("error saving role: %v", err)
+		return err
+	}
+	roleKV := schema.KeyValue{
+		Key:   sysstore.AddKeyPrefix([]byte(role.Name), sysstore.KeyPrefixRole),
+		Value: roleData,
+	}
+	_, err = s.dbList.GetByIndex(SystemDbIndex).SafeSet(&schema.SafeSetOptions{
+		Kv: &roleKV,
+	})
+	if err != nil {
+		s.Logger.Errorf("error saving role: %v", err)
+		return err
+	}
+	return nil
+}
+
+// removeRoleHoldersFromLoginList makes the logged in users holding the role login again,
+// so that the permissions they inherit from it are resolved anew
+func (s *ImmuServer) removeRoleHoldersFromLoginList(role string) {
+	s.userdata.Lock()
+	defer s.userdata.Unlock()
+	for username, user := range s.userdata.Userdata {
+		if user.HasRole(role) {
+			delete(s.userdata.Userdata, username)
+		}
+	}
+}
+
+// resolveRolePermissions loads the permissions the user inherits from its roles
+func (s *ImmuServer) resolveRolePermissions(user *auth.User) error {
+	user.RolePermissions = nil
+	for _, name := range user.Roles {
+		role, err := s.getRole(name)
+		if err == store.ErrKeyNotFound {
+			s.Logger.Warningf("role %s assigned to %s not found", name, user.Username)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		user.RolePermissions = append(user.RolePermissions, role.Permissions...)
+	}
+	return nil
+}
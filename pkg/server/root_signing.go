@@ -0,0 +1,84 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/signer"
+)
+
+// loadSigner loads the signing key configured in the options, if any
+func (s *ImmuServer) loadSigner() error {
+	if s.Options.SigningKey == "" {
+		return nil
+	}
+	sig, err := signer.NewSigner(s.Options.SigningKey)
+	if err != nil {
+		return err
+	}
+	return s.setSigner(sig)
+}
+
+func (s *ImmuServer) setSigner(sig signer.Signer) error {
+	publicKey, err := signer.MarshalPublicKey(sig.PublicKey())
+	if err != nil {
+		return err
+	}
+	s.signer = sig
+	s.signerPublicKey = publicKey
+	return nil
+}
+
+// signature returns the signature of the server over its uuid, the database name and the root at index,
+// or nil if no signing key is configured
+func (s *ImmuServer) signature(db *Db, index uint64, root []byte) (*schema.Signature, error) {
+	if s.signer == nil {
+		return nil, nil
+	}
+	sig, err := s.signer.Sign(signer.RootPayload(s.uuid.String(), db.options.dbName, index, root))
+	if err != nil {
+		return nil, err
+	}
+	return &schema.Signature{
+		PublicKey: s.signerPublicKey,
+		Signature: sig,
+	}, nil
+}
+
+// signProof signs the root the proof refers to
+func (s *ImmuServer) signProof(db *Db, proof *schema.Proof) (*schema.Proof, error) {
+	if proof == nil {
+		return nil, nil
+	}
+	sig, err := s.signature(db, proof.At, proof.Root)
+	if err != nil {
+		return nil, err
+	}
+	proof.Signature = sig
+	return proof, nil
+}
+
+// signSafeItem signs the root the proof of the item refers to
+func (s *ImmuServer) signSafeItem(db *Db, item *schema.SafeItem) (*schema.SafeItem, error) {
+	if item == nil {
+		return nil, nil
+	}
+	if _, err := s.signProof(db, item.Proof); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/signer"
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSigningKey(t *testing.T) (ed25519.PublicKey, []byte) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.Nil(t, err)
+	return pub, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func TestSignedRoots(t *testing.T) {
+	s := newInmemoryAuthServer()
+	ctx, err := loginSysAdmin(s)
+	assert.Nil(t, err)
+
+	root, err := s.CurrentRoot(ctx, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, root.Signature)
+
+	pub, pemKey := newTestSigningKey(t)
+	sig, err := signer.ParsePrivateKey(pemKey)
+	assert.Nil(t, err)
+	assert.Nil(t, s.setSigner(sig))
+	s.uuid = xid.New()
+	verify := func(index uint64, root []byte, signature *schema.Signature) error {
+		assert.NotNil(t, signature)
+		payload := signer.RootPayload(s.uuid.String(), s.Options.GetDefaultDbName(), index, root)
+		return signer.Verify(pub, payload, signature.Signature)
+	}
+
+	proof, err := s.SafeSet(ctx, &schema.SafeSetOptions{Kv: &schema.KeyValue{Key: testKey, Value: testValue}})
+	assert.Nil(t, err)
+	assert.Nil(t, verify(proof.At, proof.Root, proof.Signature))
+	pubDer, err := signer.MarshalPublicKey(pub)
+	assert.Nil(t, err)
+	assert.Equal(t, pubDer, proof.Signature.PublicKey)
+
+	root, err = s.CurrentRoot(ctx, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, verify(root.Index, root.Root, root.Signature))
+	assert.Error(t, verify(root.Index+1, root.Root, root.Signature))
+
+	item, err := s.SafeGet(ctx, &schema.SafeGetOptions{Key: testKey})
+	assert.Nil(t, err)
+	assert.Nil(t, verify(item.Proof.At, item.Proof.Root, item.Proof.Signature))
+
+	item, err = s.BySafeIndex(ctx, &schema.SafeIndexOptions{Index: proof.Index})
+	assert.Nil(t, err)
+	assert.Nil(t, verify(item.Proof.At, item.Proof.Root, item.Proof.Signature))
+
+	proof, err = s.SafeReference(ctx, &schema.SafeReferenceOptions{Ro: &schema.ReferenceOptions{Reference: []byte("ref"), Key: testKey}})
+	assert.Nil(t, err)
+	assert.Nil(t, verify(proof.At, proof.Root, proof.Signature))
+
+	proof, err = s.SafeZAdd(ctx, &schema.SafeZAddOptions{Zopts: &schema.ZAddOptions{Set: []byte("set"), Score: 1, Key: testKey}})
+	assert.Nil(t, err)
+	assert.Nil(t, verify(proof.At, proof.Root, proof.Signature))
+}
+
+func TestLoadSigner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "immudb_signing")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	_, pemKey := newTestSigningKey(t)
+	keyPath := filepath.Join(dir, "key.pem")
+	assert.Nil(t, ioutil.WriteFile(keyPath, pemKey, 0600))
+
+	s := DefaultServer()
+	assert.Nil(t, s.loadSigner())
+	assert.Nil(t, s.signer)
+
+	s.Options = s.Options.WithSigningKey(keyPath)
+	assert.Nil(t, s.loadSigner())
+	assert.NotNil(t, s.signer)
+	assert.NotEmpty(t, s.signerPublicKey)
+
+	s.Options = s.Options.WithSigningKey(filepath.Join(dir, "missing.pem"))
+	assert.Error(t, s.loadSigner())
+}
@@ -140,6 +140,11 @@ func (s *ImmuServer) Start() error {
 	if uuid, err = getOrSetUuid(systemDbRootDir); err != nil {
 		return err
 	}
+	s.uuid = uuid
+	if err = s.loadSigner(); err != nil {
+		s.Logger.Errorf("Unable to load signing key: %s", err)
+		return err
+	}
 	auth.AuthEnabled = s.Options.GetAuth()
 	auth.DevMode = s.Options.DevMode
 	adminPassword, err := auth.DecodeBase64Password(s.Options.AdminPassword)
@@ -157,6 +162,7 @@ func (s *ImmuServer) Start() error {
 			func() float64 { return float64(s.dbList.GetByIndex(DefaultDbIndex).Store.CountAll()) },
 			func() float64 { return time.Since(startedAt).Hours() },
 		)
+		Metrics.WithDatabaseUsage(s.databaseUsage)
 		defer func() {
 			if err = metricsServer.Close(); err != nil {
 				s.Logger.Errorf("Failed to shutdown metric server: %s", err)
@@ -193,12 +199,16 @@ func (s *ImmuServer) Start() error {
 	uis := []grpc.UnaryServerInterceptor{
 		uuidContext.UuidContextSetter,
 		grpc_prometheus.UnaryServerInterceptor,
+		s.DatabaseMetricsUnaryInterceptor,
 		auth.ServerUnaryInterceptor,
+		s.PasswordExpiryUnaryInterceptor,
 	}
 	sss := []grpc.StreamServerInterceptor{
 		uuidContext.UuidStreamContextSetter,
 		grpc_prometheus.StreamServerInterceptor,
+		s.DatabaseMetricsStreamInterceptor,
 		auth.ServerStreamInterceptor,
+		s.PasswordExpiryStreamInterceptor,
 	}
 	options = append(
 		options,
@@ -209,6 +219,9 @@ func (s *ImmuServer) Start() error {
 	schema.RegisterImmuServiceServer(s.GrpcServer, s)
 	grpc_prometheus.Register(s.GrpcServer)
 	s.startCorruptionChecker()
+	if err = s.startBackupScheduler(); err != nil {
+		return err
+	}
 	go s.printUsageCallToAction()
 	startedAt = time.Now()
 	err = s.GrpcServer.Serve(listener)
@@ -249,8 +262,7 @@ func (s *ImmuServer) loadSystemDatabase(dataDir string) error {
 			if err != nil {
 				return err
 			}
-			s.databasenameToIndex[s.Options.GetSystemAdminDbName()] = int64(s.dbList.Length())
-			s.dbList.Append(db)
+			s.addDatabase(s.Options.GetSystemAdminDbName(), db)
 			//sys admin can have an empty array of databases as it has full access
 			adminUsername, adminPlainPass, err := s.insertNewUser([]byte(auth.SysAdminUsername), []byte(auth.SysAdminPassword), auth.PermissionSysAdmin, "*", false, "")
 			if err != nil {
@@ -269,8 +281,7 @@ func (s *ImmuServer) loadSystemDatabase(dataDir string) error {
 		if err != nil {
 			return err
 		}
-		s.databasenameToIndex[s.Options.GetSystemAdminDbName()] = int64(s.dbList.Length())
-		s.dbList.Append(db)
+		s.addDatabase(s.Options.GetSystemAdminDbName(), db)
 	}
 
 	return nil
@@ -290,24 +301,24 @@ func (s *ImmuServer) loadDefaultDatabase(dataDir string) error {
 			WithDbName(s.Options.GetDefaultDbName()).
 			WithDbRootPath(dataDir).
 			WithCorruptionChecker(s.Options.CorruptionCheck).
-			WithInMemoryStore(s.Options.GetInMemoryStore()).WithDbRootPath(s.Options.Dir)
+			WithInMemoryStore(s.Options.GetInMemoryStore()).WithDbRootPath(s.Options.Dir).
+			WithReadOnly(s.Options.GetReadOnly())
 		db, err := NewDb(op, s.Logger)
 		if err != nil {
 			return err
 		}
-		s.databasenameToIndex[s.Options.GetDefaultDbName()] = int64(s.dbList.Length())
-		s.dbList.Append(db)
+		s.addDatabase(s.Options.GetDefaultDbName(), db)
 	} else {
 		op := DefaultOption().
 			WithDbName(s.Options.GetDefaultDbName()).
 			WithDbRootPath(dataDir).
-			WithCorruptionChecker(s.Options.CorruptionCheck).WithDbRootPath(s.Options.Dir)
+			WithCorruptionChecker(s.Options.CorruptionCheck).WithDbRootPath(s.Options.Dir).
+			WithReadOnly(s.Options.GetReadOnly())
 		db, err := OpenDb(op, s.Logger)
 		if err != nil {
 			return err
 		}
-		s.databasenameToIndex[s.Options.GetDefaultDbName()] = int64(s.dbList.Length())
-		s.dbList.Append(db)
+		s.addDatabase(s.Options.GetDefaultDbName(), db)
 	}
 	return nil
 }
@@ -323,10 +334,11 @@ func (s *ImmuServer) loadUserDatabases(dataDir string) error {
 		if err != nil {
 			return err
 		}
-		//get only first child directories, exclude datadir, exclude systemdb dir
+		//get only first child directories, exclude datadir, exclude systemdb dir, exclude hidden dirs like the archive
 		if info.IsDir() &&
 			(strings.Count(path, string(filepath.Separator)) == 1) &&
 			(dataDir != path) &&
+			!strings.HasPrefix(info.Name(), ".") &&
 			!strings.Contains(path, s.Options.GetSystemAdminDbName()) &&
 			!strings.Contains(path, s.Options.GetDefaultDbName()) {
 			dirs = append(dirs, path)
@@ -342,15 +354,19 @@ func (s *ImmuServer) loadUserDatabases(dataDir string) error {
 		//path iteration above stores the directories as data/db_name
 		pathparts := strings.Split(val, "/")
 		dbname := pathparts[len(pathparts)-1]
-		op := DefaultOption().WithDbName(dbname).WithCorruptionChecker(s.Options.CorruptionCheck).WithDbRootPath(s.Options.Dir)
+		if _, err := os.Stat(filepath.Join(val, unloadedMarker)); err == nil {
+			s.Logger.Infof("skipping unloaded database %s", dbname)
+			continue
+		}
+		op := DefaultOption().WithDbName(dbname).WithCorruptionChecker(s.Options.CorruptionCheck).WithDbRootPath(s.Options.Dir).
+			WithReadOnly(s.Options.GetReadOnly())
 		db, err := OpenDb(op, s.Logger)
 		if err != nil {
 			return err
 		}
 
 		//associate this database name to it's index in the array
-		s.databasenameToIndex[dbname] = int64(s.dbList.Length())
-		s.dbList.Append(db)
+		s.addDatabase(dbname, db)
 	}
 	return nil
 }
@@ -368,18 +384,24 @@ func (s *ImmuServer) Stop() error {
 //CloseDatabases closes all opened databases including the consinstency checker
 func (s *ImmuServer) CloseDatabases() error {
 	s.stopCorruptionChecker()
+	s.stopBackupScheduler()
 	for i := 0; i < s.dbList.Length(); i++ {
-		val := s.dbList.GetByIndex(int64(i))
-		val.Store.Close()
+		if val := s.dbList.GetByIndex(int64(i)); val != nil {
+			val.close()
+		}
 	}
 	return nil
 }
 func (s *ImmuServer) startCorruptionChecker() {
 	if s.Options.CorruptionCheck {
+		ccOpts := s.Options.CorruptionCheckerOptions
 		cco := CCOptions{}
 		cco.singleiteration = false
-		cco.iterationSleepTime = 5 * time.Second
-		cco.frequencySleepTime = 500 * time.Millisecond
+		cco.iterationSleepTime = ccOpts.interval()
+		cco.frequencySleepTime = ccOpts.pause()
+		cco.mode = ccOpts.Mode
+		cco.batchSize = ccOpts.BatchSize
+		cco.sampleSize = ccOpts.SampleSize
 		s.Cc = NewCorruptionChecker(cco, s.dbList, s.Logger)
 		go func() {
 			s.Logger.Infof("Starting consistency-checker")
@@ -403,13 +425,23 @@ func (s *ImmuServer) Login(ctx context.Context, r *schema.LoginRequest) (*schema
 	if !s.Options.auth {
 		return nil, fmt.Errorf("server is running with authentication disabled, please enable authentication to login")
 	}
+	username := string(r.User)
+	ip := clientIPFromCtx(ctx)
+	if err := s.loginGuard.check(username, ip, s.Options.LoginGuardOptions); err != nil {
+		Metrics.LoginsCounters.WithLabelValues("locked").Inc()
+		return nil, err
+	}
 	u, err := s.userExists(r.User, r.Password)
 	if err != nil {
+		s.loginGuard.failed(username, ip, s.Options.LoginGuardOptions)
+		Metrics.LoginsCounters.WithLabelValues("failure").Inc()
 		return nil, status.Errorf(codes.PermissionDenied, "invalid user name or password")
 	}
 	if !u.Active {
 		return nil, fmt.Errorf("user is not active")
 	}
+	s.loginGuard.succeeded(username, ip)
+	Metrics.LoginsCounters.WithLabelValues("success").Inc()
 
 	//-1 no database yet, must exec the "use" (UseDatabase) command first
 	var token string
@@ -425,6 +457,9 @@ func (s *ImmuServer) Login(ctx context.Context, r *schema.LoginRequest) (*schema
 	if u.Username == auth.SysAdminUsername && string(r.GetPassword()) == auth.SysAdminPassword {
 		loginResponse.Warning = []byte(auth.WarnDefaultAdminPassword)
 	}
+	if warning := s.checkPasswordExpiry(u); warning != "" {
+		loginResponse.Warning = []byte(warning)
+	}
 	if u.Username == auth.SysAdminUsername {
 		u.IsSysAdmin = true
 	}
@@ -533,21 +568,38 @@ func (s *ImmuServer) UpdateMTLSConfig(ctx context.Context, req *schema.MTLSConfi
 
 // CurrentRoot ...
 func (s *ImmuServer) CurrentRoot(ctx context.Context, e *empty.Empty) (*schema.Root, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "CurrentRoot")
+	db, err := s.getDbFromCtx(ctx, "CurrentRoot")
+	if err != nil {
+		return nil, err
+	}
+	defer db.release()
+	root, err := db.CurrentRoot(e)
 	if err != nil {
 		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).CurrentRoot(e)
+	if root.Signature, err = s.signature(db, root.Index, root.Root); err != nil {
+		return nil, err
+	}
+	return root, nil
 }
 
 // Set ...
 func (s *ImmuServer) Set(ctx context.Context, kv *schema.KeyValue) (*schema.Index, error) {
 	s.Logger.Debugf("set %s %d bytes", kv.Key, len(kv.Value))
-	ind, err := s.getDbIndexFromCtx(ctx, "Set")
+	db, err := s.getDbFromCtx(ctx, "Set")
 	if err != nil {
 		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).Set(kv)
+	defer db.release()
+	if err = db.checkQuota(1); err != nil {
+		return nil, err
+	}
+	index, err := db.Set(kv)
+	if err != nil {
+		return nil, err
+	}
+	db.countWrites(1)
+	return index, nil
 }
 
 // SetSV ...
@@ -563,11 +615,20 @@ func (s *ImmuServer) SetSV(ctx context.Context, skv *schema.StructuredKeyValue)
 // SafeSet ...
 func (s *ImmuServer) SafeSet(ctx context.Context, opts *schema.SafeSetOptions) (*schema.Proof, error) {
 	s.Logger.Debugf("SafeSet %+v", opts)
-	ind, err := s.getDbIndexFromCtx(ctx, "SafeSet")
+	db, err := s.getDbFromCtx(ctx, "SafeSet")
 	if err != nil {
 		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).SafeSet(opts)
+	defer db.release()
+	if err = db.checkQuota(1); err != nil {
+		return nil, err
+	}
+	proof, err := db.SafeSet(opts)
+	if err != nil {
+		return nil, err
+	}
+	db.countWrites(1)
+	return s.signProof(db, proof)
 }
 
 // SafeSetSV ...
@@ -587,14 +648,19 @@ func (s *ImmuServer) SafeSetSV(ctx context.Context, sopts *schema.SafeSetSVOptio
 // SetBatch ...
 func (s *ImmuServer) SetBatch(ctx context.Context, kvl *schema.KVList) (*schema.Index, error) {
 	s.Logger.Debugf("set batch %d", len(kvl.KVs))
-	ind, err := s.getDbIndexFromCtx(ctx, "SetBatch")
+	db, err := s.getDbFromCtx(ctx, "SetBatch")
 	if err != nil {
 		return nil, err
 	}
-	index, err := s.dbList.GetByIndex(ind).SetBatch(kvl)
+	defer db.release()
+	if err = db.checkQuota(len(kvl.KVs)); err != nil {
+		return nil, err
+	}
+	index, err := db.SetBatch(kvl)
 	if err != nil {
 		return nil, err
 	}
+	db.countWrites(len(kvl.KVs))
 	return index, nil
 }
 
@@ -610,11 +676,12 @@ func (s *ImmuServer) SetBatchSV(ctx context.Context, skvl *schema.SKVList) (*sch
 
 // Get ...
 func (s *ImmuServer) Get(ctx context.Context, k *schema.Key) (*schema.Item, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "Get")
+	db, err := s.getDbFromCtx(ctx, "Get")
 	if err != nil {
 		return nil, err
 	}
-	item, err := s.dbList.GetByIndex(ind).Get(k)
+	defer db.release()
+	item, err := db.Get(k)
 	if item == nil {
 		s.Logger.Debugf("get %s: item not found", k.Key)
 	} else {
@@ -636,11 +703,16 @@ func (s *ImmuServer) GetSV(ctx context.Context, k *schema.Key) (*schema.Structur
 // SafeGet ...
 func (s *ImmuServer) SafeGet(ctx context.Context, opts *schema.SafeGetOptions) (*schema.SafeItem, error) {
 	s.Logger.Debugf("safeget %s", opts.Key)
-	ind, err := s.getDbIndexFromCtx(ctx, "SafeGet")
+	db, err := s.getDbFromCtx(ctx, "SafeGet")
 	if err != nil {
 		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).SafeGet(opts)
+	defer db.release()
+	item, err := db.SafeGet(opts)
+	if err != nil {
+		return nil, err
+	}
+	return s.signSafeItem(db, item)
 }
 
 // SafeGetSV ...
@@ -655,12 +727,13 @@ func (s *ImmuServer) SafeGetSV(ctx context.Context, opts *schema.SafeGetOptions)
 // GetBatch ...
 func (s *ImmuServer) GetBatch(ctx context.Context, kl *schema.KeyList) (*schema.ItemList, error) {
 	list := &schema.ItemList{}
-	ind, err := s.getDbIndexFromCtx(ctx, "GetBatch")
+	db, err := s.getDbFromCtx(ctx, "GetBatch")
 	if err != nil {
 		return nil, err
 	}
+	defer db.release()
 	for _, key := range kl.Keys {
-		item, err := s.dbList.GetByIndex(ind).Get(key)
+		item, err := db.Get(key)
 		if err == nil || err == store.ErrKeyNotFound {
 			if item != nil {
 				list.Items = append(list.Items, item)
@@ -684,69 +757,76 @@ func (s *ImmuServer) GetBatchSV(ctx context.Context, kl *schema.KeyList) (*schem
 // Scan ...
 func (s *ImmuServer) Scan(ctx context.Context, opts *schema.ScanOptions) (*schema.ItemList, error) {
 	s.Logger.Debugf("scan %+v", *opts)
-	ind, err := s.getDbIndexFromCtx(ctx, "Scan")
+	db, err := s.getDbFromCtx(ctx, "Scan")
 	if err != nil {
 		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).Scan(opts)
+	defer db.release()
+	return db.Scan(opts)
 }
 
 // ScanSV ...
 func (s *ImmuServer) ScanSV(ctx context.Context, opts *schema.ScanOptions) (*schema.StructuredItemList, error) {
 	s.Logger.Debugf("scan %+v", *opts)
-	ind, err := s.getDbIndexFromCtx(ctx, "ScanSV")
+	db, err := s.getDbFromCtx(ctx, "ScanSV")
 	if err != nil {
 		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).ScanSV(opts)
+	defer db.release()
+	return db.ScanSV(opts)
 }
 
 // Count ...
 func (s *ImmuServer) Count(ctx context.Context, prefix *schema.KeyPrefix) (*schema.ItemsCount, error) {
 	s.Logger.Debugf("count %s", prefix.Prefix)
-	ind, err := s.getDbIndexFromCtx(ctx, "Count")
+	db, err := s.getDbFromCtx(ctx, "Count")
 	if err != nil {
 		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).Count(prefix)
+	defer db.release()
+	return db.Count(prefix)
 }
 
 // Inclusion ...
 func (s *ImmuServer) Inclusion(ctx context.Context, index *schema.Index) (*schema.InclusionProof, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "Inclusion")
+	db, err := s.getDbFromCtx(ctx, "Inclusion")
 	if err != nil {
 		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).Inclusion(index)
+	defer db.release()
+	return db.Inclusion(index)
 }
 
 // Consistency ...
 func (s *ImmuServer) Consistency(ctx context.Context, index *schema.Index) (*schema.ConsistencyProof, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "Consistency")
+	db, err := s.getDbFromCtx(ctx, "Consistency")
 	if err != nil {
 		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).Consistency(index)
+	defer db.release()
+	return db.Consistency(index)
 }
 
 // ByIndex ...
 func (s *ImmuServer) ByIndex(ctx context.Context, index *schema.Index) (*schema.Item, error) {
 	s.Logger.Debugf("get by index %d ", index.Index)
-	ind, err := s.getDbIndexFromCtx(ctx, "ByIndex")
+	db, err := s.getDbFromCtx(ctx, "ByIndex")
 	if err != nil {
 		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).ByIndex(index)
+	defer db.release()
+	return db.ByIndex(index)
 }
 
 // ByIndexSV ...
 func (s *ImmuServer) ByIndexSV(ctx context.Context, index *schema.Index) (*schema.StructuredItem, error) {
 	s.Logger.Debugf("get by index %d ", index.Index)
-	ind, err := s.getDbIndexFromCtx(ctx, "ByIndexSV")
+	db, err := s.getDbFromCtx(ctx, "ByIndexSV")
 	if err != nil {
 		return nil, err
 	}
-	item, err := s.dbList.GetByIndex(ind).ByIndex(index)
+	defer db.release()
+	item, err := db.ByIndex(index)
 	if err != nil {
 		return nil, err
 	}
@@ -756,31 +836,38 @@ func (s *ImmuServer) ByIndexSV(ctx context.Context, index *schema.Index) (*schem
 // BySafeIndex ...
 func (s *ImmuServer) BySafeIndex(ctx context.Context, sio *schema.SafeIndexOptions) (*schema.SafeItem, error) {
 	s.Logger.Debugf("get by safeIndex %d ", sio.Index)
-	ind, err := s.getDbIndexFromCtx(ctx, "BySafeIndex")
+	db, err := s.getDbFromCtx(ctx, "BySafeIndex")
 	if err != nil {
 		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).BySafeIndex(sio)
+	defer db.release()
+	item, err := db.BySafeIndex(sio)
+	if err != nil {
+		return nil, err
+	}
+	return s.signSafeItem(db, item)
 }
 
 // History ...
 func (s *ImmuServer) History(ctx context.Context, key *schema.Key) (*schema.ItemList, error) {
 	s.Logger.Debugf("history for key %s ", string(key.Key))
-	ind, err := s.getDbIndexFromCtx(ctx, "History")
+	db, err := s.getDbFromCtx(ctx, "History")
 	if err != nil {
 		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).History(key)
+	defer db.release()
+	return db.History(key)
 }
 
 // HistorySV ...
 func (s *ImmuServer) HistorySV(ctx context.Context, key *schema.Key) (*schema.StructuredItemList, error) {
 	s.Logger.Debugf("history for key %s ", string(key.Key))
-	ind, err := s.getDbIndexFromCtx(ctx, "HistorySV")
+	db, err := s.getDbFromCtx(ctx, "HistorySV")
 	if err != nil {
 		return nil, err
 	}
-	list, err := s.dbList.GetByIndex(ind).History(key)
+	defer db.release()
+	list, err := db.History(key)
 	if err != nil {
 		return nil, err
 	}
@@ -789,62 +876,104 @@ func (s *ImmuServer) HistorySV(ctx context.Context, key *schema.Key) (*schema.St
 
 // Health ...
 func (s *ImmuServer) Health(ctx context.Context, e *empty.Empty) (*schema.HealthResponse, error) {
-	ind, _ := s.getDbIndexFromCtx(ctx, "Health")
+	ind, err := s.getSelectedDbIndexFromCtx(ctx, "Health")
 
-	if ind < 0 { //probably immuclient hasn't logged in yet
-		return s.dbList.GetByIndex(DefaultDbIndex).Health(e)
+	if err != nil || ind < 0 { //probably immuclient hasn't logged in yet
+		ind = DefaultDbIndex
+	}
+	db, err := s.acquireDb(ind)
+	if err != nil {
+		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).Health(e)
+	defer db.release()
+	health, err := db.Health(e)
+	if err != nil {
+		return nil, err
+	}
+	if db.checkTampered() != nil {
+		health.Status = false
+	}
+	health.Tampered = s.tamperedDatabases(ctx)
+	return health, nil
 }
 
 // Reference ...
 func (s *ImmuServer) Reference(ctx context.Context, refOpts *schema.ReferenceOptions) (index *schema.Index, err error) {
 	s.Logger.Debugf("reference options: %v", refOpts)
-	ind, err := s.getDbIndexFromCtx(ctx, "Reference")
+	db, err := s.getDbFromCtx(ctx, "Reference")
+	if err != nil {
+		return nil, err
+	}
+	defer db.release()
+	if err = db.checkQuota(1); err != nil {
+		return nil, err
+	}
+	index, err = db.Reference(refOpts)
 	if err != nil {
 		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).Reference(refOpts)
+	db.countWrites(1)
+	return index, nil
 }
 
 // SafeReference ...
 func (s *ImmuServer) SafeReference(ctx context.Context, safeRefOpts *schema.SafeReferenceOptions) (proof *schema.Proof, err error) {
 	s.Logger.Debugf("safe reference options: %v", safeRefOpts)
-	ind, err := s.getDbIndexFromCtx(ctx, "SafeReference")
+	db, err := s.getDbFromCtx(ctx, "SafeReference")
+	if err != nil {
+		return nil, err
+	}
+	defer db.release()
+	if err = db.checkQuota(1); err != nil {
+		return nil, err
+	}
+	proof, err = db.SafeReference(safeRefOpts)
 	if err != nil {
 		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).SafeReference(safeRefOpts)
+	db.countWrites(1)
+	return s.signProof(db, proof)
 }
 
 // ZAdd ...
 func (s *ImmuServer) ZAdd(ctx context.Context, opts *schema.ZAddOptions) (*schema.Index, error) {
 	s.Logger.Debugf("zadd %+v", *opts)
-	ind, err := s.getDbIndexFromCtx(ctx, "ZAdd")
+	db, err := s.getDbFromCtx(ctx, "ZAdd")
+	if err != nil {
+		return nil, err
+	}
+	defer db.release()
+	if err = db.checkQuota(1); err != nil {
+		return nil, err
+	}
+	index, err := db.ZAdd(opts)
 	if err != nil {
 		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).ZAdd(opts)
+	db.countWrites(1)
+	return index, nil
 }
 
 // ZScan ...
 func (s *ImmuServer) ZScan(ctx context.Context, opts *schema.ZScanOptions) (*schema.ItemList, error) {
 	s.Logger.Debugf("zscan %+v", *opts)
-	ind, err := s.getDbIndexFromCtx(ctx, "ZScan")
+	db, err := s.getDbFromCtx(ctx, "ZScan")
 	if err != nil {
 		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).ZScan(opts)
+	defer db.release()
+	return db.ZScan(opts)
 }
 
 // ZScanSV ...
 func (s *ImmuServer) ZScanSV(ctx context.Context, opts *schema.ZScanOptions) (*schema.StructuredItemList, error) {
 	s.Logger.Debugf("zscan %+v", *opts)
-	ind, err := s.getDbIndexFromCtx(ctx, "ZScanSV")
+	db, err := s.getDbFromCtx(ctx, "ZScanSV")
 	if err != nil {
 		return nil, err
 	}
-	list, err := s.dbList.GetByIndex(ind).ZScan(opts)
+	defer db.release()
+	list, err := db.ZScan(opts)
 	if err != nil {
 		return nil, err
 	}
@@ -854,31 +983,42 @@ func (s *ImmuServer) ZScanSV(ctx context.Context, opts *schema.ZScanOptions) (*s
 // SafeZAdd ...
 func (s *ImmuServer) SafeZAdd(ctx context.Context, opts *schema.SafeZAddOptions) (*schema.Proof, error) {
 	s.Logger.Debugf("zadd %+v", *opts)
-	ind, err := s.getDbIndexFromCtx(ctx, "SafeZAdd")
+	db, err := s.getDbFromCtx(ctx, "SafeZAdd")
+	if err != nil {
+		return nil, err
+	}
+	defer db.release()
+	if err = db.checkQuota(1); err != nil {
+		return nil, err
+	}
+	proof, err := db.SafeZAdd(opts)
 	if err != nil {
 		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).SafeZAdd(opts)
+	db.countWrites(1)
+	return s.signProof(db, proof)
 }
 
 // IScan ...
 func (s *ImmuServer) IScan(ctx context.Context, opts *schema.IScanOptions) (*schema.Page, error) {
 	s.Logger.Debugf("iscan %+v", *opts)
-	ind, err := s.getDbIndexFromCtx(ctx, "IScan")
+	db, err := s.getDbFromCtx(ctx, "IScan")
 	if err != nil {
 		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).IScan(opts)
+	defer db.release()
+	return db.IScan(opts)
 }
 
 // IScanSV ...
 func (s *ImmuServer) IScanSV(ctx context.Context, opts *schema.IScanOptions) (*schema.SPage, error) {
 	s.Logger.Debugf("zscan %+v", *opts)
-	ind, err := s.getDbIndexFromCtx(ctx, "IScanSV")
+	db, err := s.getDbFromCtx(ctx, "IScanSV")
 	if err != nil {
 		return nil, err
 	}
-	page, err := s.dbList.GetByIndex(ind).IScan(opts)
+	defer db.release()
+	page, err := db.IScan(opts)
 	if err != nil {
 		return nil, err
 	}
@@ -887,11 +1027,12 @@ func (s *ImmuServer) IScanSV(ctx context.Context, opts *schema.IScanOptions) (*s
 
 // Dump ...
 func (s *ImmuServer) Dump(in *empty.Empty, stream schema.ImmuService_DumpServer) error {
-	ind, err := s.getDbIndexFromCtx(stream.Context(), "Dump")
+	db, err := s.getDbFromCtx(stream.Context(), "Dump")
 	if err != nil {
 		return err
 	}
-	err = s.dbList.GetByIndex(ind).Dump(in, stream)
+	defer db.release()
+	err = db.Dump(in, stream)
 	s.Logger.Debugf("Dump stream complete")
 	return err
 }
@@ -951,14 +1092,16 @@ func (s *ImmuServer) ChangePassword(ctx context.Context, r *schema.ChangePasswor
 	}
 	_, user, err := s.getLoggedInUserdataFromCtx(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("please login first")
+		return nil, status.Error(codes.Unauthenticated, "please login first")
 	}
-	if string(r.User) == auth.SysAdminUsername {
+	//users changing their own password, e.g. because it expired, must know the old one
+	ownPassword := string(r.User) == user.Username
+	if string(r.User) == auth.SysAdminUsername || ownPassword {
 		if err = auth.ComparePasswords(user.HashedPassword, r.OldPassword); err != nil {
 			return new(empty.Empty), status.Errorf(codes.PermissionDenied, "old password is incorrect")
 		}
 	}
-	if !user.IsSysAdmin {
+	if !user.IsSysAdmin && !ownPassword {
 		if !user.HasAtLeastOnePermission(auth.PermissionAdmin) {
 			return nil, fmt.Errorf("user is not system admin nor admin in any of the databases")
 		}
@@ -972,14 +1115,19 @@ func (s *ImmuServer) ChangePassword(ctx context.Context, r *schema.ChangePasswor
 	}
 
 	//if the user is not sys admin then let's make sure the target was created from this admin
-	if !user.IsSysAdmin {
+	if !user.IsSysAdmin && !ownPassword {
 		if user.Username != targetUser.CreatedBy {
 			return nil, fmt.Errorf("%s was not created by you", string(r.User))
 		}
 	}
 
-	_, err = targetUser.SetPassword(r.NewPassword)
-	if err != nil {
+	if err = s.Options.GetPasswordPolicy().Check(string(r.NewPassword)); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err = targetUser.ChangePassword(r.NewPassword, s.Options.GetPasswordPolicy().HistoryDepth); err != nil {
+		if err == auth.ErrPasswordReused {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
 		return nil, err
 	}
 	targetUser.CreatedBy = user.Username
@@ -1013,9 +1161,15 @@ func (s *ImmuServer) CreateDatabase(ctx context.Context, newdb *schema.Database)
 	if err = IsAllowedDbName(newdb.Databasename); err != nil {
 		return nil, err
 	}
+	settings, err := dbSettingsFromProto(newdb.Settings)
+	if err != nil {
+		return nil, err
+	}
 
+	s.dbManageLock.Lock()
+	defer s.dbManageLock.Unlock()
 	//check if database exists
-	if _, ok := s.databasenameToIndex[newdb.GetDatabasename()]; ok {
+	if _, ok := s.getDbIndexByName(newdb.GetDatabasename()); ok {
 		return nil, fmt.Errorf("database %s already exists", newdb.GetDatabasename())
 	}
 
@@ -1025,14 +1179,15 @@ func (s *ImmuServer) CreateDatabase(ctx context.Context, newdb *schema.Database)
 		WithDbName(newdb.Databasename).
 		WithDbRootPath(dataDir).
 		WithCorruptionChecker(s.Options.CorruptionCheck).
-		WithInMemoryStore(s.Options.GetInMemoryStore()).WithDbRootPath(s.Options.Dir)
+		WithInMemoryStore(s.Options.GetInMemoryStore()).WithDbRootPath(s.Options.Dir).
+		WithSettings(settings).
+		WithReadOnly(s.Options.GetReadOnly())
 	db, err := NewDb(op, s.Logger)
 	if err != nil {
 		s.Logger.Errorf(err.Error())
 		return nil, err
 	}
-	s.databasenameToIndex[newdb.Databasename] = int64(s.dbList.Length())
-	s.dbList.Append(db)
+	s.addDatabase(newdb.Databasename, db)
 	return &schema.CreateDatabaseReply{
 		Error: &schema.Error{
 			Errorcode:    0,
@@ -1056,7 +1211,7 @@ func (s *ImmuServer) CreateUser(ctx context.Context, r *schema.CreateUserRequest
 		}
 
 		//check if database exists
-		if _, ok := s.databasenameToIndex[r.Database]; !ok {
+		if _, ok := s.getDbIndexByName(r.Database); !ok {
 			return nil, fmt.Errorf("database %s does not exist", r.Database)
 		}
 		if len(r.User) == 0 {
@@ -1140,7 +1295,9 @@ func (s *ImmuServer) ListUsers(ctx context.Context, req *empty.Empty) (*schema.U
 				Createdat:   loggedInuser.CreatedAt.String(),
 				Createdby:   loggedInuser.CreatedBy,
 				Permissions: permissions,
+				Roles:       loggedInuser.Roles,
 				Active:      loggedInuser.Active,
+				Locked:      s.loginGuard.isLocked(loggedInuser.Username),
 			}
 			userlist.Users = append(userlist.Users, &u)
 			return userlist, nil
@@ -1178,12 +1335,15 @@ func (s *ImmuServer) ListUsers(ctx context.Context, req *empty.Empty) (*schema.U
 				Createdat:   user.CreatedAt.String(),
 				Createdby:   user.CreatedBy,
 				Permissions: permissions,
+				Roles:       user.Roles,
 				Active:      user.Active,
+				Locked:      s.loginGuard.isLocked(user.Username),
 			}
 			userlist.Users = append(userlist.Users, &u)
 		}
 		return userlist, nil
-	} else if loggedInuser.WhichPermission(s.dbList.GetByIndex(dbInd).options.dbName) == auth.PermissionAdmin {
+	} else if dbInd >= 0 && s.dbList.GetByIndex(dbInd) != nil &&
+		loggedInuser.WhichPermission(s.dbList.GetByIndex(dbInd).options.dbName) == auth.PermissionAdmin {
 		//for admin users return only users for the database where that is has selected
 		selectedDbname := s.dbList.GetByIndex(dbInd).options.dbName
 		userlist := &schema.UserList{}
@@ -1198,6 +1358,13 @@ func (s *ImmuServer) ListUsers(ctx context.Context, req *empty.Empty) (*schema.U
 					continue
 				}
 			}
+			if err = s.resolveRolePermissions(&user); err != nil {
+				return nil, err
+			}
+			//permissions inherited from roles count as well
+			if user.WhichPermission(selectedDbname) != auth.PermissionNone {
+				include = true
+			}
 			permissions := []*schema.Permission{}
 			for _, val := range user.Permissions {
 				//check if this user has any permission for this database
@@ -1216,7 +1383,9 @@ func (s *ImmuServer) ListUsers(ctx context.Context, req *empty.Empty) (*schema.U
 					Createdat:   user.CreatedAt.String(),
 					Createdby:   user.CreatedBy,
 					Permissions: permissions,
+					Roles:       user.Roles,
 					Active:      user.Active,
+					Locked:      s.loginGuard.isLocked(user.Username),
 				}
 				userlist.Users = append(userlist.Users, &u)
 			}
@@ -1237,7 +1406,9 @@ func (s *ImmuServer) ListUsers(ctx context.Context, req *empty.Empty) (*schema.U
 			Createdat:   loggedInuser.CreatedAt.String(),
 			Createdby:   loggedInuser.CreatedBy,
 			Permissions: permissions,
+			Roles:       loggedInuser.Roles,
 			Active:      loggedInuser.Active,
+			Locked:      s.loginGuard.isLocked(loggedInuser.Username),
 		}
 		userlist.Users = append(userlist.Users, &u)
 		return userlist, nil
@@ -1262,20 +1433,27 @@ func (s *ImmuServer) DatabaseList(ctx context.Context, req *empty.Empty) (*schem
 	if loggedInuser.IsSysAdmin || s.Options.GetMaintenance() {
 		for i := 0; i < s.dbList.Length(); i++ {
 			val := s.dbList.GetByIndex(int64(i))
-			if val.options.dbName == SystemdbName {
+			if val == nil || val.options.dbName == SystemdbName {
 				//do not put sysemdb in the list
 				continue
 			}
-			db := &schema.Database{
-				Databasename: val.options.dbName,
-			}
+			db := val.TamperStatus()
+			db.Settings = val.Settings().toProto()
+			db.ReadOnly = val.IsReadOnly()
 			dbList.Databases = append(dbList.Databases, db)
 		}
+		dbList.Databases = append(dbList.Databases, s.unloadedDatabases()...)
 	} else {
-		for _, val := range loggedInuser.Permissions {
+		for _, val := range loggedInuser.EffectivePermissions() {
 			db := &schema.Database{
 				Databasename: val.Database,
 			}
+			if ind, ok := s.getDbIndexByName(val.Database); ok {
+				if loaded := s.dbList.GetByIndex(ind); loaded != nil {
+					db = loaded.TamperStatus()
+					db.ReadOnly = loaded.IsReadOnly()
+				}
+			}
 			dbList.Databases = append(dbList.Databases, db)
 		}
 	}
@@ -1285,11 +1463,12 @@ func (s *ImmuServer) DatabaseList(ctx context.Context, req *empty.Empty) (*schem
 // PrintTree ...
 func (s *ImmuServer) PrintTree(ctx context.Context, r *empty.Empty) (*schema.Tree, error) {
 	s.Logger.Debugf("PrintTree")
-	ind, err := s.getDbIndexFromCtx(ctx, "PrintTree")
+	db, err := s.getDbFromCtx(ctx, "PrintTree")
 	if err != nil {
 		return nil, err
 	}
-	return s.dbList.GetByIndex(ind).PrintTree(), nil
+	defer db.release()
+	return db.PrintTree(), nil
 }
 
 // UseDatabase ...
@@ -1332,7 +1511,7 @@ func (s *ImmuServer) UseDatabase(ctx context.Context, db *schema.Database) (*sch
 		s.addUserToLoginList(user)
 	}
 	//check if database exists
-	ind, ok := s.databasenameToIndex[db.Databasename]
+	ind, ok := s.getDbIndexByName(db.Databasename)
 	if !ok {
 		return &schema.UseDatabaseReply{Error: &schema.Error{
 			Errorcode:    schema.ErrorCodes_ERROR_DB_DOES_NOT_EXIST,
@@ -1448,7 +1627,7 @@ func (s *ImmuServer) SetActiveUser(ctx context.Context, r *schema.SetActiveUserR
 		}
 		_, user, err = s.getLoggedInUserdataFromCtx(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("please login first")
+			return nil, status.Error(codes.Unauthenticated, "please login first")
 		}
 		if !user.IsSysAdmin {
 			if !user.HasAtLeastOnePermission(auth.PermissionAdmin) {
@@ -1478,6 +1657,10 @@ func (s *ImmuServer) SetActiveUser(ctx context.Context, r *schema.SetActiveUserR
 	if err := s.saveUser(targetUser); err != nil {
 		return nil, err
 	}
+	//reactivating a user also lifts a login lockout
+	if r.Active {
+		s.loginGuard.unlock(targetUser.Username)
+	}
 	//remove user from loggedin users
 	s.removeUserFromLoginList(targetUser.Username)
 	return new(empty.Empty), nil
@@ -1486,6 +1669,43 @@ func (s *ImmuServer) SetActiveUser(ctx context.Context, r *schema.SetActiveUserR
 // getDbIndexFromCtx checks if user (loggedin from context) has access to methodname.
 // returns index of database
 func (s *ImmuServer) getDbIndexFromCtx(ctx context.Context, methodname string) (int64, error) {
+	ind, err := s.getSelectedDbIndexFromCtx(ctx, methodname)
+	if err != nil {
+		return ind, err
+	}
+	db, err := s.acquireDb(ind)
+	if err != nil {
+		return 0, err
+	}
+	defer db.release()
+	//calls to a tampered database are refused until an admin resolves the tampering
+	if err = db.checkTampered(); err != nil {
+		return 0, err
+	}
+	return ind, nil
+}
+
+// getDbFromCtx works as getDbIndexFromCtx and returns the database acquired: the caller has to release it
+// once done, and until then it can not be unloaded, renamed or dropped
+func (s *ImmuServer) getDbFromCtx(ctx context.Context, methodname string) (*Db, error) {
+	ind, err := s.getSelectedDbIndexFromCtx(ctx, methodname)
+	if err != nil {
+		return nil, err
+	}
+	db, err := s.acquireDb(ind)
+	if err != nil {
+		return nil, err
+	}
+	//calls to a tampered database are refused until an admin resolves the tampering
+	if err = db.checkTampered(); err != nil {
+		db.release()
+		return nil, err
+	}
+	return db, nil
+}
+
+// getSelectedDbIndexFromCtx returns the database selected by the caller without checking its tamper state
+func (s *ImmuServer) getSelectedDbIndexFromCtx(ctx context.Context, methodname string) (int64, error) {
 	//if auth is disabled return index zero (defaultdb) as it is the first database created/loaded
 	if !s.Options.auth {
 		if !s.multidbmode {
@@ -1497,16 +1717,20 @@ func (s *ImmuServer) getDbIndexFromCtx(ctx context.Context, methodname string) (
 		if s.Options.GetMaintenance() {
 			return 0, fmt.Errorf("please select database first")
 		}
-		return 0, fmt.Errorf("please login first")
+		return 0, status.Error(codes.Unauthenticated, "please login first")
 	}
 	if ind < 0 {
 		return 0, fmt.Errorf("please select a database first")
 	}
+	db := s.dbList.GetByIndex(ind)
+	if db == nil {
+		return 0, status.Error(codes.FailedPrecondition, ErrDatabaseNotLoaded)
+	}
 	if usr.IsSysAdmin {
 		return ind, nil
 	}
 
-	if ok := auth.HasPermissionForMethod(usr.WhichPermission(s.dbList.GetByIndex(ind).options.dbName), methodname); !ok {
+	if ok := auth.HasPermissionForMethod(usr.WhichPermission(db.options.dbName), methodname); !ok {
 		return 0, fmt.Errorf("you do not have permission for this operation")
 	}
 	return ind, nil
@@ -1514,6 +1738,9 @@ func (s *ImmuServer) getDbIndexFromCtx(ctx context.Context, methodname string) (
 func (s *ImmuServer) getLoggedInUserdataFromCtx(ctx context.Context) (int64, *auth.User, error) {
 	jsUser, err := auth.GetLoggedInUser(ctx)
 	if err != nil {
+		if ind, u, ok := s.getCertUserdataFromCtx(ctx); ok {
+			return ind, u, nil
+		}
 		return -1, nil, fmt.Errorf("could not get userdata from token")
 	}
 	u, err := s.getLoggedInUserDataFromUsername(jsUser.Username)
@@ -1539,7 +1766,7 @@ func (s *ImmuServer) insertNewUser(username []byte, plainPassword []byte, permis
 		}
 	}
 	if enforceStrongAuth {
-		if err := auth.IsStrongPassword(string(plainPassword)); err != nil {
+		if err := s.Options.GetPasswordPolicy().Check(string(plainPassword)); err != nil {
 			return nil, nil, status.Errorf(codes.InvalidArgument, "%v", err)
 		}
 	}
@@ -1596,8 +1823,11 @@ func (s *ImmuServer) getUser(username []byte, includeDeactivated bool) (*auth.Us
 	if err != nil {
 		return nil, err
 	}
+	if err = s.resolveRolePermissions(&usr); err != nil {
+		return nil, err
+	}
 	if !includeDeactivated {
-		if usr.Active {
+		if !usr.Active {
 			return nil, fmt.Errorf("user not found")
 		}
 	}
@@ -1664,7 +1894,8 @@ func (s *ImmuServer) mandatoryAuth() bool {
 	//check if there are user created databases, should be zero for auth to be off
 	for i := 0; i < s.dbList.Length(); i++ {
 		val := s.dbList.GetByIndex(int64(i))
-		if (val.options.dbName != s.Options.defaultDbName) &&
+		if val != nil &&
+			(val.options.dbName != s.Options.defaultDbName) &&
 			(val.options.dbName != s.Options.systemAdminDbName) {
 			return true
 		}
@@ -27,7 +27,9 @@ import (
 
 	"github.com/codenotary/immudb/pkg/api/schema"
 	"github.com/codenotary/immudb/pkg/auth"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -272,6 +274,206 @@ func testChangePermission(ctx context.Context, s *ImmuServer, t *testing.T) {
 		t.Errorf("error changing permission, got %v", perm)
 	}
 }
+func testRoles(ctx context.Context, s *ImmuServer, t *testing.T) {
+	_, err := s.CreateRole(ctx, &schema.CreateRoleRequest{
+		Name: "auditors",
+		Permissions: []*schema.Permission{
+			{Database: testDatabase, Permission: auth.PermissionRW},
+		},
+	})
+	if err != nil {
+		t.Errorf("CreateRole error %v", err)
+	}
+	if _, err = s.CreateRole(ctx, &schema.CreateRoleRequest{Name: "auditors"}); err == nil {
+		t.Errorf("CreateRole expected error creating a role without permissions")
+	}
+	roles, err := s.ListRoles(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Errorf("ListRoles error %v", err)
+	}
+	if len(roles.Roles) != 1 {
+		t.Errorf("ListRoles, expected 1 got %v", len(roles.Roles))
+	}
+	resp, err := s.GrantRole(ctx, &schema.RoleRequest{Username: string(testUsername), Role: "auditors"})
+	if err != nil {
+		t.Errorf("GrantRole error %v", err)
+	}
+	if resp.Errorcode != schema.ErrorCodes_Ok {
+		t.Errorf("error granting role, got %v", resp)
+	}
+	u, err := s.userExists(testUsername, nil)
+	if err != nil {
+		t.Errorf("userExists error %v", err)
+	}
+	if !u.HasPermission(testDatabase, auth.PermissionRW) {
+		t.Errorf("expected permission inherited from role")
+	}
+	if _, err = s.RevokeRole(ctx, &schema.RoleRequest{Username: string(testUsername), Role: "auditors"}); err != nil {
+		t.Errorf("RevokeRole error %v", err)
+	}
+	u, err = s.userExists(testUsername, nil)
+	if err != nil {
+		t.Errorf("userExists error %v", err)
+	}
+	if u.HasRole("auditors") {
+		t.Errorf("role was not revoked")
+	}
+
+	//the logged in users holding a role login again when it changes
+	if _, err = s.GrantRole(ctx, &schema.RoleRequest{Username: string(testUsername), Role: "auditors"}); err != nil {
+		t.Errorf("GrantRole error %v", err)
+	}
+	u, _ = s.userExists(testUsername, nil)
+	s.addUserToLoginList(u)
+	_, err = s.UpdateRole(ctx, &schema.UpdateRoleRequest{
+		Name: "auditors",
+		Permissions: []*schema.Permission{
+			{Database: DefaultdbName, Permission: auth.PermissionAdmin},
+		},
+	})
+	if err != nil {
+		t.Errorf("UpdateRole error %v", err)
+	}
+	if _, err = s.getLoggedInUserDataFromUsername(string(testUsername)); err == nil {
+		t.Errorf("UpdateRole expected the users holding the role to login again")
+	}
+	u, _ = s.userExists(testUsername, nil)
+	if !u.HasPermission(DefaultdbName, auth.PermissionAdmin) {
+		t.Errorf("expected permission inherited from the updated role")
+	}
+	if _, err = s.UpdateRole(ctx, &schema.UpdateRoleRequest{Name: "missing", Permissions: roles.Roles[0].Permissions}); err == nil {
+		t.Errorf("UpdateRole expected error updating a missing role")
+	}
+
+	s.addUserToLoginList(u)
+	if _, err = s.DeleteRole(ctx, &schema.DeleteRoleRequest{Name: "auditors"}); err != nil {
+		t.Errorf("DeleteRole error %v", err)
+	}
+	if _, err = s.getLoggedInUserDataFromUsername(string(testUsername)); err == nil {
+		t.Errorf("DeleteRole expected the users holding the role to login again")
+	}
+	if roles, err = s.ListRoles(ctx, &emptypb.Empty{}); err != nil || len(roles.Roles) != 0 {
+		t.Errorf("ListRoles expected no roles after DeleteRole, got %v %v", roles, err)
+	}
+	if _, err = s.DeleteRole(ctx, &schema.DeleteRoleRequest{Name: "auditors"}); err == nil {
+		t.Errorf("DeleteRole expected error deleting a deleted role")
+	}
+	//a role created again with the same name is not granted to the former holders
+	_, err = s.CreateRole(ctx, &schema.CreateRoleRequest{
+		Name: "auditors",
+		Permissions: []*schema.Permission{
+			{Database: testDatabase, Permission: auth.PermissionR},
+		},
+	})
+	if err != nil {
+		t.Errorf("CreateRole error %v", err)
+	}
+	u, _ = s.userExists(testUsername, nil)
+	if u.HasRole("auditors") || u.HasPermission(DefaultdbName, auth.PermissionAdmin) {
+		t.Errorf("deleted role still granted")
+	}
+
+	//every role operation refuses the calls without a login in the same way
+	noLogin := context.Background()
+	permissions := []*schema.Permission{{Database: testDatabase, Permission: auth.PermissionR}}
+	calls := map[string]func() error{
+		"CreateRole": func() error {
+			_, err := s.CreateRole(noLogin, &schema.CreateRoleRequest{Name: "nologin", Permissions: permissions})
+			return err
+		},
+		"ListRoles": func() error { _, err := s.ListRoles(noLogin, &emptypb.Empty{}); return err },
+		"GrantRole": func() error {
+			_, err := s.GrantRole(noLogin, &schema.RoleRequest{Username: string(testUsername), Role: "auditors"})
+			return err
+		},
+		"RevokeRole": func() error {
+			_, err := s.RevokeRole(noLogin, &schema.RoleRequest{Username: string(testUsername), Role: "auditors"})
+			return err
+		},
+		"UpdateRole": func() error {
+			_, err := s.UpdateRole(noLogin, &schema.UpdateRoleRequest{Name: "auditors", Permissions: permissions})
+			return err
+		},
+		"DeleteRole": func() error { _, err := s.DeleteRole(noLogin, &schema.DeleteRoleRequest{Name: "auditors"}); return err },
+	}
+	for name, call := range calls {
+		if err = call(); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("%s expected Unauthenticated without login, got %v", name, err)
+		}
+	}
+}
+func testLoginLockout(ctx context.Context, s *ImmuServer, t *testing.T) {
+	s.Options.LoginGuardOptions = DefaultLoginGuardOptions().WithMaxAttempts(2).WithBaseDelay(0)
+	defer func() { s.Options.LoginGuardOptions = DefaultLoginGuardOptions() }()
+
+	wrong := &schema.LoginRequest{User: testUsername, Password: []byte("wrong")}
+	for i := 0; i < 2; i++ {
+		if _, err := s.Login(context.Background(), wrong); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("Login with wrong password expected PermissionDenied, got %v", err)
+		}
+	}
+	right := &schema.LoginRequest{User: testUsername, Password: testPassword}
+	if _, err := s.Login(context.Background(), right); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Login of locked out user expected ResourceExhausted, got %v", err)
+	}
+	users, err := s.ListUsers(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Errorf("ListUsers error %v", err)
+	}
+	for _, u := range users.Users {
+		if string(u.User) == string(testUsername) && !u.Locked {
+			t.Errorf("ListUsers expected %s to be locked", testUsername)
+		}
+	}
+	testSetActiveUser(ctx, s, t)
+	if _, err := s.Login(context.Background(), right); err != nil {
+		t.Errorf("Login after unlock error %v", err)
+	}
+}
+func testPasswordExpiry(ctx context.Context, s *ImmuServer, t *testing.T) {
+	s.Options.PasswordPolicy = auth.DefaultPasswordPolicy().WithMaxAge(time.Nanosecond).WithHistoryDepth(2)
+	defer func() { s.Options.PasswordPolicy = auth.DefaultPasswordPolicy() }()
+
+	l, err := s.Login(context.Background(), &schema.LoginRequest{User: testUsername, Password: testPassword})
+	if err != nil {
+		t.Fatalf("Login error %v", err)
+	}
+	if string(l.Warning) != auth.WarnPasswordExpired {
+		t.Errorf("Login expected password expired warning, got %s", l.Warning)
+	}
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+string(l.Token)))
+	if err = s.passwordExpired(userCtx, "/immudb.schema.ImmuService/Set"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expired password expected PermissionDenied, got %v", err)
+	}
+	if err = s.passwordExpired(userCtx, "/immudb.schema.ImmuService/ChangePassword"); err != nil {
+		t.Errorf("expired password must not prevent ChangePassword, got %v", err)
+	}
+	_, err = s.ChangePassword(userCtx, &schema.ChangePasswordRequest{
+		User:        testUsername,
+		OldPassword: testPassword,
+		NewPassword: testPassword,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ChangePassword reusing the password expected InvalidArgument, got %v", err)
+	}
+	_, err = s.ChangePassword(userCtx, &schema.ChangePasswordRequest{
+		User:        testUsername,
+		OldPassword: testPassword,
+		NewPassword: []byte("Rotated@3"),
+	})
+	if err != nil {
+		t.Errorf("ChangePassword of expired password error %v", err)
+	}
+
+	s.Options.PasswordPolicy = s.Options.PasswordPolicy.WithMaxAge(time.Hour).WithExpiryWarning(2 * time.Hour)
+	l, err = s.Login(context.Background(), &schema.LoginRequest{User: testUsername, Password: []byte("Rotated@3")})
+	if err != nil {
+		t.Fatalf("Login error %v", err)
+	}
+	if !bytes.Contains(l.Warning, []byte("password expires")) {
+		t.Errorf("Login expected password expiring warning, got %s", l.Warning)
+	}
+}
 func testDeactivateUser(ctx context.Context, s *ImmuServer, t *testing.T) {
 	_, err := s.SetActiveUser(ctx, &schema.SetActiveUserRequest{
 		Active:   false,
@@ -812,6 +1014,9 @@ func TestUsermanagement(t *testing.T) {
 	testListDatabases(ctx, s, t)
 	testUseDatabase(ctx, s, t)
 	testChangePermission(ctx, s, t)
+	testRoles(ctx, s, t)
+	testLoginLockout(ctx, s, t)
+	testPasswordExpiry(ctx, s, t)
 	testDeactivateUser(ctx, s, t)
 	testSetActiveUser(ctx, s, t)
 	testChangePassword(ctx, s, t)
@@ -24,6 +24,8 @@ import (
 
 	"github.com/codenotary/immudb/pkg/auth"
 	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/codenotary/immudb/pkg/signer"
+	"github.com/rs/xid"
 )
 
 // userDatabasePairs keeps an associacion of username to userdata
@@ -42,6 +44,7 @@ const SystemDbIndex = 1
 type DatabaseList interface {
 	Append(database *Db)
 	GetByIndex(index int64) *Db
+	Replace(index int64, database *Db)
 	Length() int
 }
 
@@ -54,9 +57,18 @@ type ImmuServer struct {
 	Pid                 PIDFile
 	quit                chan struct{}
 	databasenameToIndex map[string]int64
+	dbNamesLock         *sync.RWMutex
+	dbManageLock        *sync.Mutex
 	userdata            *usernameToUserdataMap
 	multidbmode         bool
 	Cc                  CorruptionChecker
+	loginGuard          *loginGuard
+	backupLock          *sync.Mutex
+	backupQuit          chan struct{}
+	backupWg            *sync.WaitGroup
+	uuid                xid.ID
+	signer              signer.Signer
+	signerPublicKey     []byte
 }
 
 // DefaultServer ...
@@ -67,7 +79,12 @@ func DefaultServer() *ImmuServer {
 		Options:             DefaultOptions(),
 		quit:                make(chan struct{}),
 		databasenameToIndex: make(map[string]int64),
+		dbNamesLock:         &sync.RWMutex{},
+		dbManageLock:        &sync.Mutex{},
 		userdata:            &usernameToUserdataMap{Userdata: make(map[string]*auth.User)},
+		loginGuard:          newLoginGuard(),
+		backupLock:          &sync.Mutex{},
+		backupWg:            &sync.WaitGroup{},
 	}
 }
 
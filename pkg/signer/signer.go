@@ -0,0 +1,170 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signer
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+)
+
+// ErrInvalidSignature is returned when a signature does not match the payload and the public key
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// rootPayloadPrefix separates root signatures from any other use of the same key
+const rootPayloadPrefix = "immudb-root-v1"
+
+// Signer signs payloads with an ed25519 or ECDSA private key
+type Signer interface {
+	Sign(payload []byte) ([]byte, error)
+	PublicKey() crypto.PublicKey
+}
+
+type signer struct {
+	key crypto.Signer
+}
+
+// NewSigner returns a signer using the private key of a PEM file, in PKCS#8 or SEC 1 (EC PRIVATE KEY) format
+func NewSigner(privateKeyPath string) (Signer, error) {
+	data, err := ioutil.ReadFile(privateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePrivateKey(data)
+}
+
+// ParsePrivateKey returns a signer using the PEM encoded private key
+func ParsePrivateKey(pemData []byte) (Signer, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in private key")
+	}
+	if block.Type == "EC PRIVATE KEY" {
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return &signer{key}, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		return &signer{k}, nil
+	case *ecdsa.PrivateKey:
+		return &signer{k}, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T: only ed25519 and ECDSA keys are supported", key)
+	}
+}
+
+// Sign signs the payload as is with ed25519 keys and its sha256 digest with ECDSA keys
+func (s *signer) Sign(payload []byte) ([]byte, error) {
+	if _, ok := s.key.(ed25519.PrivateKey); ok {
+		return s.key.Sign(rand.Reader, payload, crypto.Hash(0))
+	}
+	digest := sha256.Sum256(payload)
+	return s.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+func (s *signer) PublicKey() crypto.PublicKey {
+	return s.key.Public()
+}
+
+// LoadPublicKey reads a PEM encoded PKIX public key
+func LoadPublicKey(path string) (crypto.PublicKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+	return ParsePublicKey(block.Bytes)
+}
+
+// ParsePublicKey parses a DER encoded PKIX public key
+func ParsePublicKey(der []byte) (crypto.PublicKey, error) {
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	switch key.(type) {
+	case ed25519.PublicKey, *ecdsa.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T: only ed25519 and ECDSA keys are supported", key)
+	}
+}
+
+// MarshalPublicKey returns the DER encoded PKIX form of the public key
+func MarshalPublicKey(key crypto.PublicKey) ([]byte, error) {
+	return x509.MarshalPKIXPublicKey(key)
+}
+
+// Verify checks the signature of the payload with the public key
+func Verify(key crypto.PublicKey, payload []byte, signature []byte) error {
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		if ed25519.Verify(k, payload, signature) {
+			return nil
+		}
+	case *ecdsa.PublicKey:
+		var sig struct{ R, S *big.Int }
+		if rest, err := asn1.Unmarshal(signature, &sig); err != nil || len(rest) > 0 {
+			return ErrInvalidSignature
+		}
+		digest := sha256.Sum256(payload)
+		if ecdsa.Verify(k, digest[:], sig.R, sig.S) {
+			return nil
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+	return ErrInvalidSignature
+}
+
+// RootPayload returns the payload signed for a root of a database of a server
+func RootPayload(serverUUID string, database string, index uint64, root []byte) []byte {
+	c := make([]byte, 0, len(rootPayloadPrefix)+8+len(serverUUID)+8+len(database)+8+len(root))
+	c = append(c, rootPayloadPrefix...)
+	c = appendWithLength(c, []byte(serverUUID))
+	c = appendWithLength(c, []byte(database))
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], index)
+	c = append(c, b[:]...)
+	return append(c, root...)
+}
+
+func appendWithLength(c []byte, v []byte) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(len(v)))
+	return append(append(c, b[:]...), v...)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signer
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func pkcs8PEM(t *testing.T, key interface{}) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.Nil(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func TestSignAndVerify(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	ecDer, err := x509.MarshalECPrivateKey(ecKey)
+	assert.Nil(t, err)
+
+	payload := RootPayload("uuid", "defaultdb", 7, []byte{1, 2, 3})
+	for _, pemData := range [][]byte{
+		pkcs8PEM(t, edKey),
+		pkcs8PEM(t, ecKey),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDer}),
+	} {
+		s, err := ParsePrivateKey(pemData)
+		assert.Nil(t, err)
+		signature, err := s.Sign(payload)
+		assert.Nil(t, err)
+
+		der, err := MarshalPublicKey(s.PublicKey())
+		assert.Nil(t, err)
+		pub, err := ParsePublicKey(der)
+		assert.Nil(t, err)
+		assert.Nil(t, Verify(pub, payload, signature))
+		assert.Equal(t, ErrInvalidSignature, Verify(pub, RootPayload("uuid", "otherdb", 7, []byte{1, 2, 3}), signature))
+		assert.Equal(t, ErrInvalidSignature, Verify(pub, payload, []byte("garbage")))
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.Nil(t, err)
+	_, err = ParsePrivateKey(pkcs8PEM(t, rsaKey))
+	assert.Error(t, err)
+	_, err = ParsePrivateKey([]byte("not a key"))
+	assert.Error(t, err)
+	rsaDer, err := MarshalPublicKey(&rsaKey.PublicKey)
+	assert.Nil(t, err)
+	_, err = ParsePublicKey(rsaDer)
+	assert.Error(t, err)
+}
+
+func TestLoadKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "immudb_signer")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	keyPath := filepath.Join(dir, "key.pem")
+	assert.Nil(t, ioutil.WriteFile(keyPath, pkcs8PEM(t, key), 0600))
+	der, err := MarshalPublicKey(pub)
+	assert.Nil(t, err)
+	pubPath := filepath.Join(dir, "pub.pem")
+	assert.Nil(t, ioutil.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644))
+
+	s, err := NewSigner(keyPath)
+	assert.Nil(t, err)
+	loaded, err := LoadPublicKey(pubPath)
+	assert.Nil(t, err)
+	signature, err := s.Sign([]byte("payload"))
+	assert.Nil(t, err)
+	assert.Nil(t, Verify(loaded, []byte("payload"), signature))
+
+	_, err = NewSigner(filepath.Join(dir, "missing.pem"))
+	assert.Error(t, err)
+	_, err = LoadPublicKey(keyPath)
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/merkletree"
+	"github.com/dgraph-io/badger/v2"
+)
+
+// Backup writes to w a badger backup of the store taken at the current root, the tree layers included, and returns that root.
+// Writes are paused only while the tree is flushed, the entries committed later are not part of the backup.
+func (t *Store) Backup(w io.Writer) (*schema.Root, error) {
+	t.wg.Wait()
+	t.tree.Lock()
+	t.tree.flush()
+	width := t.tree.w
+	root := &schema.Root{}
+	if width > 0 {
+		r := merkletree.Root(t.tree)
+		root.Index = width - 1
+		root.Root = r[:]
+	}
+	t.tree.Unlock()
+	if width == 0 {
+		return root, nil
+	}
+	// entries and tree nodes up to the root have a version not greater than the tree width
+	stream := t.db.NewStreamAt(width)
+	stream.LogPrefix = "Badger.Backup"
+	if _, err := stream.Backup(w, 0); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// Restore loads a backup written by Backup into a new store at the path of badgerOptions and returns the root of the
+// restored store, which callers can compare with the one returned by Backup.
+func Restore(r io.Reader, options Options, badgerOptions badger.Options) (*schema.Root, error) {
+	if _, err := os.Stat(filepath.Join(badgerOptions.Dir, badger.ManifestFilename)); err == nil {
+		return nil, fmt.Errorf("cannot restore a backup into the existing store %s", badgerOptions.Dir)
+	}
+	badgerOpts := badgerOptions
+	badgerOpts.ValueDir = badgerOptions.Dir
+	badgerOpts.NumVersionsToKeep = math.MaxInt64
+	db, err := badger.OpenManaged(badgerOpts)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	if err = db.Load(r, 256); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err = db.Close(); err != nil {
+		return nil, err
+	}
+	st, err := Open(options, badgerOptions)
+	if err != nil {
+		return nil, err
+	}
+	root, err := st.CurrentRoot()
+	if err != nil {
+		st.Close()
+		return nil, err
+	}
+	return root, st.Close()
+}
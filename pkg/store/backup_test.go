@@ -0,0 +1,72 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreBackup(t *testing.T) {
+	st, closer := makeStore()
+	defer closer()
+
+	var buf bytes.Buffer
+	root, err := st.Backup(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, &schema.Root{}, root)
+	assert.Equal(t, 0, buf.Len())
+
+	for n := 0; n < 10; n++ {
+		_, err = st.SafeSet(schema.SafeSetOptions{Kv: &schema.KeyValue{Key: []byte{byte(n + 1)}, Value: []byte{byte(n + 1)}}})
+		assert.NoError(t, err)
+	}
+	root, err = st.Backup(&buf)
+	assert.NoError(t, err)
+	current, err := st.CurrentRoot()
+	assert.NoError(t, err)
+	assert.Equal(t, current, root)
+
+	// entries committed after the backup are not part of it
+	_, err = st.SafeSet(schema.SafeSetOptions{Kv: &schema.KeyValue{Key: []byte("after"), Value: []byte("backup")}})
+	assert.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "immu_restore")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	opts, badgerOpts := DefaultOptions(dir, st.log)
+	data := buf.Bytes()
+	restoredRoot, err := Restore(bytes.NewReader(data), opts, badgerOpts)
+	assert.NoError(t, err)
+	assert.Equal(t, root, restoredRoot)
+	_, err = Restore(bytes.NewReader(data), opts, badgerOpts)
+	assert.Error(t, err)
+
+	restored, err := Open(opts, badgerOpts)
+	assert.NoError(t, err)
+	defer restored.Close()
+	item, err := restored.Get(schema.Key{Key: []byte{10}})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{10}, item.Value)
+	_, err = restored.Get(schema.Key{Key: []byte("after")})
+	assert.Equal(t, ErrKeyNotFound, err)
+}
@@ -24,9 +24,13 @@ import (
 	"runtime"
 )
 
+// DefaultTreeCacheSize number of tree entries kept in memory before being flushed
+const DefaultTreeCacheSize = 750_000
+
 // Options ...
 type Options struct {
-	log logger.Logger
+	log           logger.Logger
+	treeCacheSize uint64
 }
 
 // DefaultOptions ...
@@ -39,7 +43,13 @@ func DefaultOptions(path string, log logger.Logger) (Options, badger.Options) {
 	if runtime.GOOS == "windows" {
 		badgerOptions.Truncate = true
 	}
-	return Options{log}, badgerOptions
+	return Options{log: log, treeCacheSize: DefaultTreeCacheSize}, badgerOptions
+}
+
+// WithTreeCacheSize sets the number of tree entries kept in memory before being flushed
+func (o Options) WithTreeCacheSize(size uint64) Options {
+	o.treeCacheSize = size
+	return o
 }
 
 // WriteOptions ...
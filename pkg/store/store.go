@@ -57,7 +57,7 @@ func Open(options Options, badgerOptions badger.Options) (*Store, error) {
 	t := &Store{
 		db: db,
 		// fixme(leogr): cache size could be calculated using db.MaxBatchCount()
-		tree: newTreeStore(db, 750_000, options.log),
+		tree: newTreeStore(db, options.treeCacheSize, options.log),
 		log:  options.log,
 	}
 
@@ -234,6 +234,19 @@ func (t *Store) CountAll() (count uint64) {
 	return
 }
 
+// EntriesCount returns the number of entries inserted so far, without scanning the store
+func (t *Store) EntriesCount() uint64 {
+	return t.tree.LastIndex() + 1
+}
+
+// TreeStats returns the width of the tree and the number of entries not yet added to the tree or flushed to disk
+func (t *Store) TreeStats() (width uint64, pending uint64) {
+	pending = t.tree.Pending()
+	t.tree.RLock()
+	defer t.tree.RUnlock()
+	return t.tree.Width(), pending
+}
+
 // Count returns the number of entris having the specified key prefix
 func (t *Store) Count(prefix schema.KeyPrefix) (count *schema.ItemsCount, err error) {
 	if len(prefix.Prefix) == 0 || prefix.Prefix[0] == tsPrefix {
@@ -253,6 +266,21 @@ func (t *Store) Count(prefix schema.KeyPrefix) (count *schema.ItemsCount, err er
 
 func (t *Store) itemAt(readTs uint64) (index uint64, key, value []byte, err error) {
 	index = readTs - 1
+	hash, item, err := t.entryAt(index)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	// this guard ensure that the insertion order index was not tampered.
+	realHash := api.Digest(item.Index, item.Key, item.Value)
+	if hash != realHash {
+		return 0, nil, nil, ErrInconsistentDigest
+	}
+	return index, item.Key, item.Value, nil
+}
+
+// entryAt returns the leaf hash stored at the insertion order index and the key/value version it refers to
+func (t *Store) entryAt(index uint64) (hash [sha256.Size]byte, item *schema.Item, err error) {
 	var refkey []byte
 	// cache reference lookup
 	t.tree.RLock()
@@ -276,19 +304,19 @@ func (t *Store) itemAt(readTs uint64) (index uint64, key, value []byte, err erro
 			if err == badger.ErrKeyNotFound {
 				err = ErrIndexNotFound
 			}
-			return 0, nil, nil, err
+			return hash, nil, err
 		}
 	}
 
-	var hash [sha256.Size]byte
+	var key []byte
 	// reference parsing
 	if hash, key, err = decodeRefTreeKey(refkey); err != nil {
-		return 0, nil, nil, err
+		return hash, nil, err
 	}
 
 	if key == nil {
 		// this shouldn't happen
-		return 0, nil, nil, ErrObsoleteDataFormat
+		return hash, nil, ErrObsoleteDataFormat
 	}
 
 	// disk value lookup
@@ -296,24 +324,20 @@ func (t *Store) itemAt(readTs uint64) (index uint64, key, value []byte, err erro
 	defer txn.Discard()
 	it := txn.NewKeyIterator(key, badger.IteratorOptions{})
 	defer it.Close()
-	var item *schema.Item
 	for it.Rewind(); it.Valid(); it.Next() {
 		item, err = itemToSchema(key, it.Item())
 		if err != nil {
-			return 0, nil, nil, err
+			return hash, nil, err
 		}
 		// there are multiple possible versions of a key. Here we retrieve the one with the correct timestamp
 		if item.Index == index {
 			break
 		}
 	}
-
-	// this guard ensure that the insertion order index was not tampered.
-	realHash := api.Digest(item.Index, key, item.Value)
-	if hash != realHash {
-		return 0, nil, nil, ErrInconsistentDigest
+	if item == nil {
+		return hash, nil, ErrIndexNotFound
 	}
-	return index, item.Key, item.Value, nil
+	return hash, item, nil
 }
 
 // ByIndex fetches the entry at the specified index
@@ -22,6 +22,8 @@ import "bytes"
 const (
 	//All user keys in the key/value store are prefixed by this keys to distinguish them from keys that have other purposes
 	KeyPrefixUser = iota + 1
+	//All role keys in the key/value store are prefixed by this key
+	KeyPrefixRole
 )
 
 // AddKeyPrefix ...
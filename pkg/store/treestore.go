@@ -122,6 +122,7 @@ type treeStore struct {
 	c           chan *treeStoreEntry
 	quit        chan struct{}
 	lastFlushed uint64
+	persisted   uint64 // width of the tree stored on disk
 	db          *badger.DB
 	log         logger.Logger
 	caches      [256]ring.Buffer
@@ -166,6 +167,7 @@ func (t *treeStore) loadTreeState() {
 		}
 		t.w = t.cPos[0]
 		t.ts = t.w
+		t.persisted = t.w
 		return nil
 	})
 }
@@ -214,6 +216,14 @@ func (t *treeStore) WaitUntil(index uint64) {
 	}
 }
 
+// Pending returns the number of entries waiting to be added to the tree or to be flushed to disk.
+// It's thread-safe.
+func (t *treeStore) Pending() uint64 {
+	t.RLock()
+	defer t.RUnlock()
+	return atomic.LoadUint64(&t.ts) - t.persisted
+}
+
 // LastIndex returns the index of last tree commitment.
 // It's thread-safe.
 func (t *treeStore) LastIndex() uint64 {
@@ -315,6 +325,7 @@ func (t *treeStore) flush() {
 				t.cPos[l] = c.Tail()
 			}
 			t.lastFlushed = t.w
+			t.persisted = t.w
 		}
 		//workaround possible badger bug
 		//Commit cannot be called with managedDB=true. Use CommitAt.
@@ -41,12 +41,16 @@ func TestNewTreeStore(t *testing.T) {
 	ts.WaitUntil(1) // second item at idx 1
 
 	assert.Equal(t, uint64(2), ts.Width())
+	assert.Equal(t, uint64(2), ts.Pending())
 
 	ts.Close()
 	db.Restart()
 
 	ts = newTreeStore(db.DB, 1000, log)
 	assert.Equal(t, uint64(2), ts.Width())
+	// the entries loaded are not pending, but they do not delay the next flush
+	assert.Zero(t, ts.Pending())
+	assert.Zero(t, ts.lastFlushed)
 
 	ts.Close()
 }
@@ -0,0 +1,203 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/codenotary/immudb/pkg/api"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/merkletree"
+	"github.com/dgraph-io/badger/v2"
+)
+
+// TreeMismatch a node of the tree which differs from the one rebuilt out of the stored entries
+type TreeMismatch struct {
+	Layer    uint8
+	Index    uint64
+	Expected []byte
+	Actual   []byte
+	Err      error
+}
+
+func (m TreeMismatch) String() string {
+	if m.Err != nil {
+		return fmt.Sprintf("layer %d index %d: %v", m.Layer, m.Index, m.Err)
+	}
+	return fmt.Sprintf("layer %d index %d: expected %x, found %x", m.Layer, m.Index, m.Expected, m.Actual)
+}
+
+// VerifyTree recomputes the leaf digests of the entries up to the current root out of the stored key/value versions,
+// rebuilds every layer of the tree and compares it with the persisted layers and with the root.
+// onProgress is called after each entry and onMismatch for each difference, an error returned by them stops the verification.
+// It returns the verified root and whether no difference was found.
+func (t *Store) VerifyTree(onProgress func(verified uint64, total uint64) error, onMismatch func(TreeMismatch) error) (*schema.Root, bool, error) {
+	root, err := t.CurrentRoot()
+	if err != nil {
+		return nil, false, err
+	}
+	if root.Root == nil {
+		return root, true, nil
+	}
+	at := root.Index
+	consistent := true
+	report := func(m TreeMismatch) error {
+		consistent = false
+		return onMismatch(m)
+	}
+
+	var cbErr error
+	rebuilt := &frontierStore{}
+	// only frozen nodes are final, the others are overwritten while the tree grows and are covered by the root
+	rebuilt.onSet = func(layer uint8, index uint64, h [sha256.Size]byte) {
+		if cbErr != nil || !merkletree.IsFrozen(layer, index, rebuilt.w-1) {
+			return
+		}
+		persisted, err := t.persistedNode(layer, index)
+		switch {
+		case err != nil:
+			cbErr = report(TreeMismatch{Layer: layer, Index: index, Expected: h[:], Err: err})
+		case *persisted != h:
+			cbErr = report(TreeMismatch{Layer: layer, Index: index, Expected: h[:], Actual: persisted[:]})
+		}
+	}
+
+	for i := uint64(0); i <= at; i++ {
+		_, item, err := t.entryAt(i)
+		var digest [sha256.Size]byte
+		if err != nil {
+			if cbErr = report(TreeMismatch{Layer: 0, Index: i, Err: err}); cbErr != nil {
+				return root, false, cbErr
+			}
+			// keep rebuilding the tree on top of the persisted leaf, if any
+			if persisted, err := t.persistedNode(0, i); err == nil {
+				digest = *persisted
+			}
+		} else {
+			digest = api.Digest(item.Index, item.Key, item.Value)
+		}
+		merkletree.AppendHash(rebuilt, &digest)
+		if cbErr != nil {
+			return root, false, cbErr
+		}
+		if err = onProgress(i+1, at+1); err != nil {
+			return root, false, err
+		}
+	}
+
+	if r := merkletree.Root(rebuilt); !bytes.Equal(r[:], root.Root) {
+		layer := uint8(merkletree.Depth(rebuilt))
+		if err = report(TreeMismatch{Layer: layer, Index: 0, Expected: r[:], Actual: root.Root}); err != nil {
+			return root, false, err
+		}
+	}
+	return root, consistent, nil
+}
+
+// persistedNode returns a node of the tree as it is stored, either flushed to disk or still in the caches
+func (t *Store) persistedNode(layer uint8, index uint64) (*[sha256.Size]byte, error) {
+	t.tree.RLock()
+	defer t.tree.RUnlock()
+	// nodes from the flushed position on may have been overwritten in the caches
+	if index >= t.tree.cPos[layer] {
+		if v := t.tree.caches[layer].Get(index); v != nil {
+			h := *v.(*[sha256.Size]byte)
+			return &h, nil
+		}
+	}
+	var h [sha256.Size]byte
+	err := t.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(treeKey(layer, index))
+		if err != nil {
+			return err
+		}
+		value, err := item.ValueCopy(nil)
+		if err != nil {
+			return err
+		}
+		// leaves are stored along with the key of the entry they refer to
+		if layer == 0 {
+			h, _, err = decodeRefTreeKey(value)
+			return err
+		}
+		if len(value) != sha256.Size {
+			return ErrInconsistentState
+		}
+		copy(h[:], value)
+		return nil
+	})
+	if err == badger.ErrKeyNotFound {
+		return nil, fmt.Errorf("node is missing")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+// frontierStore is a merkletree.Storer keeping just the nodes needed to append further leaves,
+// the tree of a whole database can be rebuilt with it in constant memory
+type frontierStore struct {
+	w      uint64
+	layers [][]frontierNode
+	onSet  func(layer uint8, index uint64, h [sha256.Size]byte)
+}
+
+type frontierNode struct {
+	index uint64
+	h     [sha256.Size]byte
+}
+
+func (f *frontierStore) Width() uint64 {
+	return f.w
+}
+
+// Set keeps the last two nodes of each layer: appending a leaf only reads the left sibling of the latest node
+func (f *frontierStore) Set(layer uint8, index uint64, value [sha256.Size]byte) {
+	for len(f.layers) <= int(layer) {
+		f.layers = append(f.layers, make([]frontierNode, 0, 2))
+	}
+	nodes := f.layers[layer]
+	if n := len(nodes); n > 0 && nodes[n-1].index == index {
+		nodes[n-1].h = value
+	} else if n < 2 {
+		nodes = append(nodes, frontierNode{index, value})
+	} else {
+		nodes[0], nodes[1] = nodes[1], frontierNode{index, value}
+	}
+	f.layers[layer] = nodes
+	if layer == 0 && f.w <= index {
+		f.w = index + 1
+	}
+	if f.onSet != nil {
+		f.onSet(layer, index, value)
+	}
+}
+
+func (f *frontierStore) Get(layer uint8, index uint64) *[sha256.Size]byte {
+	if int(layer) >= len(f.layers) {
+		return nil
+	}
+	for i := range f.layers[layer] {
+		if f.layers[layer][i].index == index {
+			return &f.layers[layer][i].h
+		}
+	}
+	return nil
+}
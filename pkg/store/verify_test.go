@@ -0,0 +1,84 @@
+/*
+Copyright 2019-2020 vChain, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"crypto/sha256"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/merkletree"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrontierStore(t *testing.T) {
+	full := merkletree.NewMemStore()
+	frontier := &frontierStore{}
+	for i := 0; i < 100; i++ {
+		h := sha256.Sum256([]byte(strconv.Itoa(i)))
+		h2 := h
+		merkletree.AppendHash(full, &h)
+		merkletree.AppendHash(frontier, &h2)
+		assert.Equal(t, merkletree.Root(full), merkletree.Root(frontier))
+	}
+}
+
+func TestVerifyTree(t *testing.T) {
+	st, closer := makeStore()
+	defer closer()
+
+	var last *schema.Index
+	for i := 0; i < 11; i++ {
+		last, _ = st.Set(schema.KeyValue{Key: []byte(strconv.Itoa(i)), Value: []byte("value")})
+	}
+	st.tree.WaitUntil(last.Index)
+
+	var verified, total uint64
+	var mismatches []TreeMismatch
+	progress := func(v uint64, tot uint64) error {
+		verified, total = v, tot
+		return nil
+	}
+	mismatch := func(m TreeMismatch) error {
+		mismatches = append(mismatches, m)
+		return nil
+	}
+	root, consistent, err := st.VerifyTree(progress, mismatch)
+	assert.NoError(t, err)
+	assert.True(t, consistent)
+	assert.Empty(t, mismatches)
+	assert.Equal(t, uint64(10), root.Index)
+	assert.Equal(t, uint64(11), verified)
+	assert.Equal(t, uint64(11), total)
+
+	// TAMPER: overwrite the value of the entry at index 3 keeping its version
+	txn := st.db.NewTransactionAt(math.MaxUint64, true)
+	defer txn.Discard()
+	assert.NoError(t, txn.Set([]byte("3"), []byte("tampered")))
+	assert.NoError(t, txn.CommitAt(4, nil))
+
+	_, consistent, err = st.VerifyTree(progress, mismatch)
+	assert.NoError(t, err)
+	assert.False(t, consistent)
+	assert.NotEmpty(t, mismatches)
+	assert.Equal(t, uint8(0), mismatches[0].Layer)
+	assert.Equal(t, uint64(3), mismatches[0].Index)
+	// the rebuilt root differs as well
+	assert.Equal(t, root.Root, mismatches[len(mismatches)-1].Actual)
+}